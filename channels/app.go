@@ -4,26 +4,71 @@
 package channels
 
 import (
-	log "github.com/sirupsen/logrus"
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/gin-contrib/gzip"
 	"github.com/lisomatrix/channels/channels/connection"
 	"github.com/lisomatrix/channels/channels/core"
+	"github.com/lisomatrix/channels/channels/metrics"
 
 	"github.com/gin-gonic/gin"
 )
 
+// CORSMiddleware - Send the CORS headers to the allowed origins, see core.CORSConfig
+// Preflight requests from other origins are refused, other requests go on without the headers so browsers block them
 func CORSMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		config := core.GetCORSConfig()
+		origin := c.Request.Header.Get("Origin")
+		isPreflight := c.Request.Method == "OPTIONS"
 
-		// c.Header("Content-Type", "application/json")
-		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Control-Allow-Credentials", "true")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, AppID")
-		c.Header("Access-Control-Allow-Methods", "POST,HEAD,PATCH, OPTIONS, GET, PUT")
+		var allowOrigin string
 
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
+		if isPreflight {
+			allowOrigin = core.CORSPreflightAllowOrigin(origin)
+		} else {
+			appID := c.Request.Header.Get("AppID")
+
+			// Browser WebSockets and SSE send it as query param
+			if appID == "" {
+				appID = c.Query("AppID")
+			}
+
+			allowOrigin = core.CORSAllowOrigin(origin, appID)
+		}
+
+		if allowOrigin != "" {
+			c.Header("Access-Control-Allow-Origin", allowOrigin)
+			c.Header("Access-Control-Allow-Headers", strings.Join(config.AllowedHeaders, ", "))
+			c.Header("Access-Control-Allow-Methods", strings.Join(config.AllowedMethods, ", "))
+
+			if allowOrigin != "*" {
+				c.Header("Vary", "Origin")
+
+				if config.AllowCredentials {
+					c.Header("Access-Control-Allow-Credentials", "true")
+				}
+			}
+
+			if isPreflight && config.MaxAge > 0 {
+				c.Header("Access-Control-Max-Age", strconv.Itoa(int(config.MaxAge/time.Second)))
+			}
+		}
+
+		if isPreflight {
+			if origin != "" && allowOrigin == "" {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+
+			c.AbortWithStatus(http.StatusNoContent)
 			return
 		}
 
@@ -31,7 +76,15 @@ func CORSMiddleware() gin.HandlerFunc {
 	}
 }
 
+// MetricsAddress - If set, /metrics is served without authentication on this host:port instead of the main router
+// Use it only on a private network the scraper can reach
+var MetricsAddress = ""
+
+// ShutdownTimeout - How long to wait for connections, queued events and push notifications when shutting down
+var ShutdownTimeout = 30 * time.Second
+
 // Start channel server, make sure you configured the Engine first
+// It blocks until SIGINT or SIGTERM is received and the server is gracefully shut down
 func Start(host string, port string, router *gin.Engine) {
 	gin.SetMode(gin.ReleaseMode)
 
@@ -40,6 +93,12 @@ func Start(host string, port string, router *gin.Engine) {
 	// WebSocket route
 	//router.GET("/", connection.RequestHandler)
 	router.GET("/optimized", connection.OptimizedRequestHandler)
+
+	// Server Sent Events route
+	router.GET("/sse", connection.SSEHandler)
+
+	// Single use tickets to connect without the token in the URL
+	router.POST("/ticket", connection.PostTicketHandler)
 	// router.GET("/optimized", wsHandler)
 
 	// Only enabled GZIP Compressesion on non websocket connections
@@ -54,12 +113,20 @@ func Start(host string, port string, router *gin.Engine) {
 	router.DELETE("/app/:appID", core.DeleteApp)
 	router.PUT("/app/:appID", core.UpdateApp)
 	router.GET("/app", core.GetApps)
+	router.PUT("/app/:appID/events", core.PutAppEventRulesHandler)
+	router.GET("/app/:appID/events", core.GetAppEventRulesHandler)
+	router.POST("/app/:appID/keys", core.PostAPIKeyHandler)
+	router.GET("/app/:appID/keys", core.GetAPIKeysHandler)
+	router.DELETE("/app/:appID/keys/:keyID", core.DeleteAPIKeyHandler)
 
 	// Channel management routes
 	router.POST("/channel", core.CreateChannelHandler)
 	router.POST("/channel/:channelID/join/:clientID", core.PostJoinChannel)
 	router.POST("/channel/:channelID/leave/:clientID", core.PostLeaveChannel)
+	router.POST("/channel/:channelID/join", core.PostJoinChannelClients)
+	router.POST("/channel/:channelID/leave", core.PostLeaveChannelClients)
 	router.DELETE("/channel/:channelID", core.DeleteChannelHandler)
+	router.PUT("/channel/:channelID", core.UpdateChannelHandler)
 	router.POST("/channel/:channelID/close", core.PostCloseChannel)
 	router.POST("/channel/:channelID/open", core.PostOpenChannel)
 	router.GET("/channel/open", core.GetOpenChannels)
@@ -71,9 +138,28 @@ func Start(host string, port string, router *gin.Engine) {
 	router.GET("/last/:channelID/:amount", core.GetLastMessages)
 	router.GET("/last/:channelID/:amount/last/:lastTimeStamp", core.GetLastMessagesSinceTimeStamp)
 	router.GET("/last/:channelID/:amount/before/:lastTimeStamp", core.GetLastMessagesBeforeTimeStamp)
+	router.GET("/c/:channelID/after/:eventID", core.GetMessagesAfterEventID)
+	router.GET("/last/:channelID/:amount/after/:eventID", core.GetLastMessagesAfterEventID)
+
+	// Read receipts
+	router.GET("/c/:channelID/read", core.GetReadMarkerHandler)
+	router.GET("/c/:channelID/receipts", core.GetReadMarkersHandler)
+
+	// Channel members
+	router.GET("/c/:channelID/clients", core.GetChannelMembersHandler)
+	router.PUT("/channel/:channelID/role/:clientID", core.PutChannelClientRole)
 
 	// Channel Publish
 	router.POST("/channel/:channelID/publish", core.PostEventHandler)
+	router.PUT("/channel/:channelID/event/:eventID", core.PutEventHandler)
+	router.DELETE("/channel/:channelID/event/:eventID", core.DeleteEventHandler)
+
+	// Channel event type rules
+	router.PUT("/channel/:channelID/events", core.PutChannelEventRulesHandler)
+	router.GET("/c/:channelID/events", core.GetChannelEventRulesHandler)
+
+	// Direct messages
+	router.POST("/direct/:clientID", core.PostDirectChannel)
 
 	// Client routes
 	router.POST("/client", core.CreateClientHandler)
@@ -81,11 +167,74 @@ func Start(host string, port string, router *gin.Engine) {
 	router.PUT("/client/:clientID", core.UpdateClientHandler)
 	router.GET("/client", core.GetClients)
 	router.GET("/client/:clientID", core.GetClientHandler)
+	router.POST("/client/:clientID/disconnect", core.DisconnectClientHandler)
+
+	// Metrics route, without authentication on its own listener
+	if MetricsAddress == "" {
+		router.GET("/metrics", core.MetricsHandler)
+	}
 
 	// Presence routes
 	//router.GET("/presence/:clientID", handlers.GetClientDevicesPresences)
 	//router.GET("/online/:clientID", handlers.GetClientOnlineDevices)
 
-	log.Info("Running on host %s and port %v", host, port)
-	log.Fatal(router.Run(host + ":" + port))
+	server := &http.Server{
+		Addr:    host + ":" + port,
+		Handler: router,
+	}
+
+	// SSE streams are regular requests, they must be closed for the server shutdown to finish
+	server.RegisterOnShutdown(core.GetEngine().CloseSessions)
+
+	var metricsServer *http.Server
+
+	if MetricsAddress != "" {
+		metricsRouter := gin.New()
+		metricsRouter.GET("/metrics", metrics.Handler)
+
+		metricsServer = &http.Server{
+			Addr:    MetricsAddress,
+			Handler: metricsRouter,
+		}
+
+		go func() {
+			core.Logger().Infof("Serving metrics on %s", MetricsAddress)
+
+			if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				core.Logger().Fatal(err)
+			}
+		}()
+	}
+
+	go func() {
+		core.Logger().Infof("Running on host %s and port %v", host, port)
+
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			core.Logger().Fatal(err)
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	core.Logger().Info("Shutting down server")
+
+	ctx, cancel := context.WithTimeout(context.Background(), ShutdownTimeout)
+	defer cancel()
+
+	// Stop accepting new requests, connected sessions are closed by the engine
+	if err := server.Shutdown(ctx); err != nil {
+		core.Logger().WithError(err).Error("Server shutdown failed")
+	}
+
+	if err := core.GetEngine().Shutdown(ctx); err != nil {
+		core.Logger().WithError(err).Error("Engine shutdown failed")
+	}
+
+	if metricsServer != nil {
+		if err := metricsServer.Shutdown(ctx); err != nil {
+			core.Logger().WithError(err).Error("Metrics server shutdown failed")
+		}
+	}
 }
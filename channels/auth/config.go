@@ -0,0 +1,45 @@
+package auth
+
+import "time"
+
+// Config - Token validation settings
+type Config struct {
+	Algorithms    []string          `yaml:"algorithms"`     // Accepted in signed tokens, defaults to HS256
+	Issuer        string            `yaml:"issuer"`         // When set, tokens must have it in iss
+	Audience      []string          `yaml:"audience"`       // When set, tokens must have one of them in aud
+	Leeway        time.Duration     `yaml:"leeway"`         // Clock skew allowed on exp, nbf and iat, defaults to 1 minute
+	RequireExpiry bool              `yaml:"require_expiry"` // Reject tokens without exp, off by default so older tokens keep working
+	KeyFiles      map[string]string `yaml:"key_files"`      // PEM public key files by kid, an empty kid is used for tokens without one
+	JWKSFile      string            `yaml:"jwks_file"`      // Local JWKS document with keys by kid
+}
+
+// Configure - Apply the settings and load the keys, the secret is still set with SetSecret
+func Configure(config Config) error {
+	if len(config.Algorithms) > 0 {
+		if err := SetAlgorithms(config.Algorithms...); err != nil {
+			return err
+		}
+	}
+
+	for keyID, path := range config.KeyFiles {
+		if err := LoadKeyFile(keyID, path); err != nil {
+			return err
+		}
+	}
+
+	if config.JWKSFile != "" {
+		if err := LoadJWKS(config.JWKSFile); err != nil {
+			return err
+		}
+	}
+
+	if config.Leeway != 0 {
+		SetLeeway(config.Leeway)
+	}
+
+	SetIssuer(config.Issuer)
+	SetAudience(config.Audience...)
+	SetRequireExpiry(config.RequireExpiry)
+
+	return nil
+}
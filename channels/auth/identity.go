@@ -23,6 +23,9 @@ type Identity struct {
 	Role     string
 	AppID    string
 	ClientID string
+
+	IssuedAt  int64 `json:"-"` // Token iat in Unix seconds, 0 if the token has none
+	ExpiresAt int64 `json:"-"` // Token exp in Unix seconds, 0 if the token has none
 }
 
 func GetTokenAndVerify(request *http.Request, role string) (*Identity, bool) {
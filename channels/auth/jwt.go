@@ -1,53 +1,164 @@
 package auth
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"gopkg.in/square/go-jose.v2"
 	"gopkg.in/square/go-jose.v2/jwt"
 )
 
+// DefaultLeeway - Clock skew allowed when checking exp, nbf and iat
+const DefaultLeeway = time.Minute
+
+// ErrUnsupportedAlgorithm - The algorithm isn't one of the supported signing algorithms
+var ErrUnsupportedAlgorithm = errors.New("unsupported token algorithm")
+
+// Algorithms signed tokens can use
+var supportedAlgorithms = []jose.SignatureAlgorithm{
+	jose.HS256, jose.HS384, jose.HS512,
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.PS256, jose.PS384, jose.PS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.EdDSA,
+}
+
 var jwtSecret = []byte("")
 var issuer = ""
+var audience []string
+var leeway = DefaultLeeway
+var requireExpiry = false
+var algorithms = []jose.SignatureAlgorithm{jose.HS256}
+var signingKey *jose.SigningKey
+var signingKeyID = ""
 
+// SetSecret - Set the secret of HS256 tokens without kid and of encrypted tokens
 func SetSecret(secret string) {
 	jwtSecret = []byte(secret)
 }
 
-func SetIssuer(issuer string) {
-	issuer = issuer
+// SetIssuer - Set the issuer of created tokens, when set verified tokens must have it in iss
+func SetIssuer(tokenIssuer string) {
+	issuer = tokenIssuer
+}
+
+// SetAudience - When set, verified tokens must have one of the given values in aud
+func SetAudience(tokenAudience ...string) {
+	audience = tokenAudience
+}
+
+// SetLeeway - Set the clock skew allowed when checking exp, nbf and iat
+func SetLeeway(tokenLeeway time.Duration) {
+	leeway = tokenLeeway
+}
+
+// SetRequireExpiry - Reject tokens without exp
+func SetRequireExpiry(require bool) {
+	requireExpiry = require
 }
 
-// CreateToken - Create token with given info
+// SetAlgorithms - Set the algorithms accepted in signed tokens, like HS256, RS256, ES256 or EdDSA
+func SetAlgorithms(names ...string) error {
+	accepted := make([]jose.SignatureAlgorithm, 0, len(names))
+
+	for _, name := range names {
+		algorithm, err := parseAlgorithm(name)
+
+		if err != nil {
+			return err
+		}
+
+		accepted = append(accepted, algorithm)
+	}
+
+	algorithms = accepted
+
+	return nil
+}
+
+// SetSigningKey - Sign the created tokens with the given algorithm and private key, or secret for HMAC
+// The kid is added to the token header when not empty, by default tokens are signed with HS256 and the secret
+func SetSigningKey(name string, keyID string, key interface{}) error {
+	algorithm, err := parseAlgorithm(name)
+
+	if err != nil {
+		return err
+	}
+
+	signingKey = &jose.SigningKey{Algorithm: algorithm, Key: key}
+	signingKeyID = keyID
+
+	return nil
+}
+
+func parseAlgorithm(name string) (jose.SignatureAlgorithm, error) {
+	for _, algorithm := range supportedAlgorithms {
+		if string(algorithm) == name {
+			return algorithm, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, name)
+}
+
+func isAllowedAlgorithm(algorithm jose.SignatureAlgorithm) bool {
+	for _, allowed := range algorithms {
+		if allowed == algorithm {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CreateToken - Create token with given info, signed with the signing key
 func CreateToken(clientID string, role string, appID string, expire *jwt.NumericDate) (string, error) {
+	key := jose.SigningKey{Algorithm: jose.HS256, Key: jwtSecret}
 
-	sig, err := jose.NewEncrypter(
-		jose.A128GCM,
-		jose.Recipient{Algorithm: jose.DIRECT, Key: jwtSecret},
-		(&jose.EncrypterOptions{}).WithType("JWT"),
-	)
+	if signingKey != nil {
+		key = *signingKey
+	}
+
+	options := (&jose.SignerOptions{}).WithType("JWT")
 
-	//sig, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: jwtSecret}, (&jose.SignerOptions{}).WithType("JWT"))
+	if signingKeyID != "" {
+		options = options.WithHeader("kid", signingKeyID)
+	}
+
+	sig, err := jose.NewSigner(key, options)
 
 	if err != nil {
 		fmt.Printf("Error creating jwt %s \n", err)
 		return "", err
 	}
 
-	c := &jwt.Claims{
-		Subject: clientID,
-		Issuer:  issuer,
-		Expiry:  expire,
+	raw, err := jwt.Signed(sig).Claims(newClaims(clientID, expire)).Claims(newIdentity(clientID, role, appID)).CompactSerialize()
+
+	if err != nil {
+		fmt.Printf("Error creating jwt %s \n", err)
+		return "", err
 	}
 
-	tokenData := Identity{
-		AppID:    appID,
-		ClientID: clientID,
-		Role:     role,
+	return raw, nil
+}
+
+// CreateEncryptedToken - Create token with given info, encrypted with the secret which must have 16 bytes
+func CreateEncryptedToken(clientID string, role string, appID string, expire *jwt.NumericDate) (string, error) {
+
+	sig, err := jose.NewEncrypter(
+		jose.A128GCM,
+		jose.Recipient{Algorithm: jose.DIRECT, Key: jwtSecret},
+		(&jose.EncrypterOptions{}).WithType("JWT"),
+	)
+
+	if err != nil {
+		fmt.Printf("Error creating jwt %s \n", err)
+		return "", err
 	}
 
-	raw, err := jwt.Encrypted(sig).Claims(c).Claims(tokenData).CompactSerialize()
-	//raw, err := jwt.Signed(sig).Claims(c).Claims(tokenData).CompactSerialize()
+	raw, err := jwt.Encrypted(sig).Claims(newClaims(clientID, expire)).Claims(newIdentity(clientID, role, appID)).CompactSerialize()
 
 	if err != nil {
 		fmt.Printf("Error creating jwt %s \n", err)
@@ -57,6 +168,29 @@ func CreateToken(clientID string, role string, appID string, expire *jwt.Numeric
 	return raw, nil
 }
 
+func newClaims(clientID string, expire *jwt.NumericDate) *jwt.Claims {
+	claims := &jwt.Claims{
+		Subject:  clientID,
+		Issuer:   issuer,
+		Expiry:   expire,
+		IssuedAt: jwt.NewNumericDate(time.Now()),
+	}
+
+	if len(audience) > 0 {
+		claims.Audience = jwt.Audience{audience[0]}
+	}
+
+	return claims
+}
+
+func newIdentity(clientID string, role string, appID string) Identity {
+	return Identity{
+		AppID:    appID,
+		ClientID: clientID,
+		Role:     role,
+	}
+}
+
 // AuthenticateAdmin - Check if token is valid and is admin kind
 func AuthenticateAdmin(tokenString string) (*Identity, bool) {
 
@@ -77,22 +211,92 @@ func AuthenticateAdmin(tokenString string) (*Identity, bool) {
 }
 
 // VerifyToken - Check token validity and payload
+// Signed tokens are verified with the key of their kid, encrypted tokens with the secret
 func VerifyToken(tokenString string) (Identity, bool) {
+	claims, identity, isOK := parseToken(tokenString)
+
+	if !isOK || !validateClaims(claims) {
+		return Identity{}, false
+	}
 
-	var tokenData Identity
+	if claims.IssuedAt != nil {
+		identity.IssuedAt = claims.IssuedAt.Time().Unix()
+	}
 
-	token, err := jwt.ParseEncrypted(tokenString)
-	//token, err := jwt.ParseSigned(tokenString)
+	if claims.Expiry != nil {
+		identity.ExpiresAt = claims.Expiry.Time().Unix()
+	}
 
-	if err != nil {
-		return tokenData, false
+	return identity, true
+}
+
+// parseToken - Verify the signature, or decrypt, the token and extract its claims
+func parseToken(tokenString string) (jwt.Claims, Identity, bool) {
+	var claims jwt.Claims
+	var identity Identity
+
+	// Encrypted tokens have 5 parts, signed ones 3
+	if strings.Count(tokenString, ".") == 4 {
+		token, err := jwt.ParseEncrypted(tokenString)
+
+		if err != nil || len(jwtSecret) == 0 {
+			return claims, identity, false
+		}
+
+		if err := token.Claims(jwtSecret, &claims, &identity); err != nil {
+			return claims, identity, false
+		}
+
+		return claims, identity, true
+	}
+
+	token, err := jwt.ParseSigned(tokenString)
+
+	if err != nil || len(token.Headers) != 1 {
+		return claims, identity, false
+	}
+
+	header := token.Headers[0]
+	algorithm := jose.SignatureAlgorithm(header.Algorithm)
+
+	if !isAllowedAlgorithm(algorithm) {
+		return claims, identity, false
+	}
+
+	key, isOK := getVerificationKey(header.KeyID, algorithm)
+
+	if !isOK {
+		return claims, identity, false
 	}
 
-	out := Identity{}
+	if err := token.Claims(key, &claims, &identity); err != nil {
+		return claims, identity, false
+	}
+
+	return claims, identity, true
+}
+
+// validateClaims - Check exp, nbf, iat, iss and aud
+func validateClaims(claims jwt.Claims) bool {
+	if requireExpiry && claims.Expiry == nil {
+		return false
+	}
+
+	expected := jwt.Expected{Issuer: issuer, Time: time.Now()}
+
+	if err := claims.ValidateWithLeeway(expected, leeway); err != nil {
+		return false
+	}
+
+	if len(audience) == 0 {
+		return true
+	}
 
-	if err := token.Claims(jwtSecret, &out); err != nil {
-		return out, false
+	for _, value := range audience {
+		if claims.Audience.Contains(value) {
+			return true
+		}
 	}
 
-	return out, true
+	return false
 }
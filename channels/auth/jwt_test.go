@@ -0,0 +1,178 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+// signToken - Sign the claims with the given key and kid
+func signToken(t *testing.T, algorithm jose.SignatureAlgorithm, keyID string, key interface{}, claims jwt.Claims) string {
+	options := (&jose.SignerOptions{}).WithType("JWT")
+
+	if keyID != "" {
+		options = options.WithHeader("kid", keyID)
+	}
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: algorithm, Key: key}, options)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := jwt.Signed(signer).Claims(claims).Claims(Identity{Role: ClientRole, AppID: "123", ClientID: "321"}).CompactSerialize()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return token
+}
+
+func TestVerifyTokenKeyRotation(t *testing.T) {
+	defer SetAlgorithms("HS256")
+
+	if err := SetAlgorithms("RS256"); err != nil {
+		t.Fatal(err)
+	}
+
+	oldKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	newKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	_ = AddKey("old", oldKey)
+	_ = AddKey("new", &newKey.PublicKey)
+	defer RemoveKey("new")
+
+	oldToken := signToken(t, jose.RS256, "old", oldKey, jwt.Claims{})
+	newToken := signToken(t, jose.RS256, "new", newKey, jwt.Claims{})
+
+	if _, isOK := VerifyToken(oldToken); !isOK {
+		t.Error("Failed to verify token of the old key")
+	}
+
+	if identity, isOK := VerifyToken(newToken); !isOK || identity.ClientID != "321" {
+		t.Error("Failed to verify token of the new key")
+	}
+
+	RemoveKey("old")
+
+	if _, isOK := VerifyToken(oldToken); isOK {
+		t.Error("Token of a removed key was verified")
+	}
+
+	if _, isOK := VerifyToken(signToken(t, jose.RS256, "new", oldKey, jwt.Claims{})); isOK {
+		t.Error("Token signed with another key was verified")
+	}
+
+	if _, isOK := VerifyToken(signToken(t, jose.HS256, "", []byte("123"), jwt.Claims{})); isOK {
+		t.Error("Token of a not allowed algorithm was verified")
+	}
+}
+
+func TestLoadJWKS(t *testing.T) {
+	defer SetAlgorithms("HS256")
+
+	if err := SetAlgorithms("ES256", "EdDSA"); err != nil {
+		t.Fatal(err)
+	}
+
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	edPublic, edKey, _ := ed25519.GenerateKey(rand.Reader)
+
+	data, _ := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+		{Key: &ecKey.PublicKey, KeyID: "ec", Algorithm: string(jose.ES256), Use: "sig"},
+		{Key: edPublic, KeyID: "ed", Algorithm: string(jose.EdDSA)},
+	}})
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := LoadJWKS(path); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, isOK := VerifyToken(signToken(t, jose.ES256, "ec", ecKey, jwt.Claims{})); !isOK {
+		t.Error("Failed to verify ES256 token")
+	}
+
+	if _, isOK := VerifyToken(signToken(t, jose.EdDSA, "ed", edKey, jwt.Claims{})); !isOK {
+		t.Error("Failed to verify EdDSA token")
+	}
+
+	// Reloading the document drops its removed keys
+	data, _ = json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: edPublic, KeyID: "ed"}}})
+	_ = ioutil.WriteFile(path, data, 0600)
+
+	if err := LoadJWKS(path); err != nil {
+		t.Fatal(err)
+	}
+
+	defer RemoveKey("ed")
+
+	if _, isOK := VerifyToken(signToken(t, jose.ES256, "ec", ecKey, jwt.Claims{})); isOK {
+		t.Error("Token of a key removed from the document was verified")
+	}
+}
+
+func TestVerifyTokenClaims(t *testing.T) {
+	SetSecret("123")
+	SetIssuer("channels")
+	SetAudience("app", "other")
+	defer SetIssuer("")
+	defer SetAudience()
+
+	now := time.Now()
+
+	cases := []struct {
+		claims jwt.Claims
+		valid  bool
+	}{
+		{jwt.Claims{Issuer: "channels", Audience: jwt.Audience{"other"}, Expiry: jwt.NewNumericDate(now.Add(time.Hour))}, true},
+		{jwt.Claims{Issuer: "channels", Audience: jwt.Audience{"app"}, Expiry: jwt.NewNumericDate(now.Add(-time.Hour))}, false},
+		{jwt.Claims{Issuer: "channels", Audience: jwt.Audience{"app"}, NotBefore: jwt.NewNumericDate(now.Add(time.Hour))}, false},
+		{jwt.Claims{Issuer: "another", Audience: jwt.Audience{"app"}}, false},
+		{jwt.Claims{Issuer: "channels", Audience: jwt.Audience{"another"}}, false},
+		{jwt.Claims{Issuer: "channels"}, false},
+	}
+
+	for i, c := range cases {
+		if _, isOK := VerifyToken(signToken(t, jose.HS256, "", []byte("123"), c.claims)); isOK != c.valid {
+			t.Errorf("Case %d: expected valid %v", i, c.valid)
+		}
+	}
+
+	SetRequireExpiry(true)
+	defer SetRequireExpiry(false)
+
+	if _, isOK := VerifyToken(signToken(t, jose.HS256, "", []byte("123"), jwt.Claims{Issuer: "channels", Audience: jwt.Audience{"app"}})); isOK {
+		t.Error("Token without exp was verified")
+	}
+}
+
+func TestCreateEncryptedToken(t *testing.T) {
+	SetSecret("0123456789abcdef")
+	defer SetSecret("123")
+
+	token, err := CreateEncryptedToken("321", AdminRole, "123", nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if identity, isOK := AuthenticateAdmin(token); !isOK || identity.AppID != "123" {
+		t.Error("Failed to verify encrypted token")
+	}
+}
@@ -0,0 +1,202 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sync"
+
+	"gopkg.in/square/go-jose.v2"
+)
+
+// ErrInvalidKey - The key isn't a HMAC secret, RSA, ECDSA or Ed25519 public key
+var ErrInvalidKey = errors.New("invalid token verification key")
+
+// verificationKey - Key used to verify signed tokens with its kid
+type verificationKey struct {
+	key       interface{}
+	algorithm jose.SignatureAlgorithm // If set, the only algorithm the key can be used with
+	source    string                  // JWKS file the key was loaded from, replaced when the file is loaded again
+}
+
+var keysLock sync.RWMutex
+var verificationKeys = make(map[string]*verificationKey)
+
+// publicKey - Get the verification key out of a secret, public or private key
+func publicKey(key interface{}) (interface{}, error) {
+	switch value := key.(type) {
+	case []byte:
+		if len(value) == 0 {
+			return nil, ErrInvalidKey
+		}
+
+		return value, nil
+	case string:
+		return publicKey([]byte(value))
+	case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey:
+		return value, nil
+	case *rsa.PrivateKey:
+		return &value.PublicKey, nil
+	case *ecdsa.PrivateKey:
+		return &value.PublicKey, nil
+	case ed25519.PrivateKey:
+		return value.Public(), nil
+	default:
+		return nil, ErrInvalidKey
+	}
+}
+
+// AddKey - Add a key to verify signed tokens having the given kid, replacing the previous one
+// Tokens without kid are verified with the key added with an empty kid, or the secret for HMAC algorithms
+// During a key rotation add the new key before issuing tokens with it, and remove the old one once its tokens expire
+func AddKey(keyID string, key interface{}) error {
+	verification, err := publicKey(key)
+
+	if err != nil {
+		return err
+	}
+
+	keysLock.Lock()
+	verificationKeys[keyID] = &verificationKey{key: verification}
+	keysLock.Unlock()
+
+	return nil
+}
+
+// RemoveKey - Stop accepting tokens signed with the key of the given kid
+func RemoveKey(keyID string) {
+	keysLock.Lock()
+	delete(verificationKeys, keyID)
+	keysLock.Unlock()
+}
+
+// LoadKeyFile - Add the PEM public key, or certificate, in the file to verify tokens having the given kid
+func LoadKeyFile(keyID string, path string) error {
+	data, err := ioutil.ReadFile(path)
+
+	if err != nil {
+		return err
+	}
+
+	key, err := parsePEMPublicKey(data)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	return AddKey(keyID, key)
+}
+
+// parsePEMPublicKey - Parse the first PKIX or PKCS1 public key, or certificate, of the PEM data
+func parsePEMPublicKey(data []byte) (interface{}, error) {
+	block, _ := pem.Decode(data)
+
+	if block == nil {
+		return nil, fmt.Errorf("%w: no PEM data found", ErrInvalidKey)
+	}
+
+	switch block.Type {
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		certificate, err := x509.ParseCertificate(block.Bytes)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return certificate.PublicKey, nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported PEM block %s", ErrInvalidKey, block.Type)
+	}
+}
+
+// LoadJWKS - Add the signing keys of the local JWKS document by their kid
+// Loading the same file again replaces its keys, so keys removed from the document stop being accepted
+func LoadJWKS(path string) error {
+	data, err := ioutil.ReadFile(path)
+
+	if err != nil {
+		return err
+	}
+
+	var keySet jose.JSONWebKeySet
+
+	if err := json.Unmarshal(data, &keySet); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	keys := make(map[string]*verificationKey, len(keySet.Keys))
+
+	for _, jsonKey := range keySet.Keys {
+		// Skip encryption keys
+		if jsonKey.Use != "" && jsonKey.Use != "sig" {
+			continue
+		}
+
+		key, err := publicKey(jsonKey.Key)
+
+		if err != nil {
+			return fmt.Errorf("%s: key %s: %w", path, jsonKey.KeyID, err)
+		}
+
+		if _, exists := keys[jsonKey.KeyID]; exists {
+			return fmt.Errorf("%s: %w: kid %s is repeated", path, ErrInvalidKey, jsonKey.KeyID)
+		}
+
+		keys[jsonKey.KeyID] = &verificationKey{
+			key:       key,
+			algorithm: jose.SignatureAlgorithm(jsonKey.Algorithm),
+			source:    path,
+		}
+	}
+
+	keysLock.Lock()
+	defer keysLock.Unlock()
+
+	for keyID, key := range verificationKeys {
+		if key.source == path {
+			delete(verificationKeys, keyID)
+		}
+	}
+
+	for keyID, key := range keys {
+		verificationKeys[keyID] = key
+	}
+
+	return nil
+}
+
+// getVerificationKey - Get the key to verify a token signed with the algorithm and kid
+func getVerificationKey(keyID string, algorithm jose.SignatureAlgorithm) (interface{}, bool) {
+	keysLock.RLock()
+	key, isOK := verificationKeys[keyID]
+	keysLock.RUnlock()
+
+	if isOK {
+		if key.algorithm != "" && key.algorithm != algorithm {
+			return nil, false
+		}
+
+		return key.key, true
+	}
+
+	// Without kid fall back to the secret
+	if keyID == "" && isHMAC(algorithm) && len(jwtSecret) > 0 {
+		return jwtSecret, true
+	}
+
+	return nil, false
+}
+
+func isHMAC(algorithm jose.SignatureAlgorithm) bool {
+	return algorithm == jose.HS256 || algorithm == jose.HS384 || algorithm == jose.HS512
+}
@@ -1,20 +1,24 @@
 package cache
 
 import (
-	"fmt"
-	"os"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/lisomatrix/channels/channels/core"
 
 	lediscfg "github.com/ledisdb/ledisdb/config"
 	"github.com/ledisdb/ledisdb/ledis"
+	log "github.com/sirupsen/logrus"
 	"google.golang.org/protobuf/proto"
 )
 
 // LedisCacheStorage - Cache implementation in Ledis
 type LedisCacheStorage struct {
-	db *ledis.DB
+	db          *ledis.DB
+	eventIDLock sync.Mutex
+	eventsLock  sync.Mutex // Ledis has no transactions, so the events queue is locked while it is changed
+	ticketsLock sync.Mutex // So a ticket is only taken once
 }
 
 // GetChannelEvents - Get given cached events from the channel queue
@@ -22,7 +26,7 @@ func (cache *LedisCacheStorage) GetChannelEvents(channelID string, appID string,
 	dData, err := cache.db.LRange([]byte("app:"+appID+"channel:"+channelID+":events"), 0, int32(amount))
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Ledis Cache: failed get LRANGE result %v\n", err)
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Ledis Cache: failed get LRANGE result")
 		return nil
 	}
 
@@ -38,7 +42,7 @@ func (cache *LedisCacheStorage) GetChannelEvents(channelID string, appID string,
 		err = proto.Unmarshal([]byte(data), &cachedEvent)
 
 		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "Ledis Cache: failed umarshal cached event %v\n", err)
+			core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Ledis Cache: failed umarshal cached event")
 			return nil
 		}
 
@@ -48,6 +52,7 @@ func (cache *LedisCacheStorage) GetChannelEvents(channelID string, appID string,
 			EventType: cachedEvent.EventType,
 			ChannelID: channelID,
 			Timestamp: cachedEvent.Timestamp,
+			ID:        cachedEvent.ID,
 		})
 	}
 
@@ -61,7 +66,7 @@ func (cache *LedisCacheStorage) GetChannelEventsSize(channelID string, appID str
 	result, err := cache.db.LLen(key)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Ledis Cache: failed get LRANGE result %v\n", err)
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Ledis Cache: failed get LRANGE result")
 		return 0
 	}
 
@@ -73,7 +78,7 @@ func (cache *LedisCacheStorage) GetOldestChannelEvent(channelID string, appID st
 	results, err := cache.db.LRange([]byte("app:"+appID+":channel:"+channelID+":events"), -1, -1)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Ledis Cache: failed get LRANGE result %v\n", err)
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Ledis Cache: failed get LRANGE result")
 		return nil
 	}
 
@@ -86,7 +91,7 @@ func (cache *LedisCacheStorage) GetOldestChannelEvent(channelID string, appID st
 	err = proto.Unmarshal([]byte(results[0]), &cachedEvent)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Ledis Cache: failed umarshal cached event %v\n", err)
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Ledis Cache: failed umarshal cached event")
 		return nil
 	}
 
@@ -96,6 +101,7 @@ func (cache *LedisCacheStorage) GetOldestChannelEvent(channelID string, appID st
 		EventType: cachedEvent.EventType,
 		ChannelID: channelID,
 		Timestamp: cachedEvent.Timestamp,
+		ID:        cachedEvent.ID,
 	}
 }
 
@@ -107,24 +113,28 @@ func (cache *LedisCacheStorage) StoreChannelEvent(channelID string, appID string
 		Payload:   event.Payload,
 		Timestamp: event.Timestamp,
 		EventType: event.EventType,
+		ID:        event.ID,
 	}
 
 	eventData, err := proto.Marshal(&cachedEvent)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Ledis Cache: failed to marshal cached event %v\n", err)
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Ledis Cache: failed to marshal cached event")
 		return
 	}
 
 	key := []byte("app:" + appID + "channel:" + channelID + ":events")
 
+	cache.eventsLock.Lock()
+	defer cache.eventsLock.Unlock()
+
 	// Push new event and update expire period
 	amount, err := cache.db.LPush(key, eventData)
 
 	_, _ = cache.db.Expire(key, int64((4 * time.Hour).Seconds()))
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Ledis Cache: failed get LPUSH result %v\n", err)
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Ledis Cache: failed get LPUSH result")
 		return
 	}
 
@@ -134,12 +144,226 @@ func (cache *LedisCacheStorage) StoreChannelEvent(channelID string, appID string
 
 }
 
+// UpdateChannelEvent - Replace the cached event with the same ID, if it is still cached
+func (cache *LedisCacheStorage) UpdateChannelEvent(channelID string, appID string, event *core.ChannelEvent) {
+
+	cachedEvent := CachedChannelEvent{
+		SenderID:  event.SenderID,
+		Payload:   event.Payload,
+		Timestamp: event.Timestamp,
+		EventType: event.EventType,
+		ID:        event.ID,
+	}
+
+	eventData, err := proto.Marshal(&cachedEvent)
+
+	if err != nil {
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Ledis Cache: failed to marshal cached event")
+		return
+	}
+
+	key := []byte("app:" + appID + "channel:" + channelID + ":events")
+
+	cache.eventsLock.Lock()
+	defer cache.eventsLock.Unlock()
+
+	_, index := cache.findChannelEvent(key, event.ID)
+
+	if index == -1 {
+		return
+	}
+
+	if err := cache.db.LSet(key, int32(index), eventData); err != nil {
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "EventID": event.ID}).WithError(err).Error("Ledis Cache: failed to update cached event")
+	}
+}
+
+// RemoveChannelEvent - Remove the cached event with the given ID, if it is still cached
+func (cache *LedisCacheStorage) RemoveChannelEvent(channelID string, appID string, eventID uint64) {
+	key := []byte("app:" + appID + "channel:" + channelID + ":events")
+
+	cache.eventsLock.Lock()
+	defer cache.eventsLock.Unlock()
+
+	items, index := cache.findChannelEvent(key, eventID)
+
+	if index == -1 {
+		return
+	}
+
+	// Ledis can't remove items in the middle of a list, so it is rebuilt without the event
+	items = append(items[:index], items[index+1:]...)
+
+	if _, err := cache.db.LClear(key); err != nil {
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "EventID": eventID}).WithError(err).Error("Ledis Cache: failed to remove cached event")
+		return
+	}
+
+	if len(items) == 0 {
+		return
+	}
+
+	if _, err := cache.db.RPush(key, items...); err != nil {
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "EventID": eventID}).WithError(err).Error("Ledis Cache: failed to remove cached event")
+		return
+	}
+
+	_, _ = cache.db.LExpire(key, int64((4 * time.Hour).Seconds()))
+}
+
+// findChannelEvent - Get the cached events and the index of the one with the given ID, -1 if it isn't cached
+func (cache *LedisCacheStorage) findChannelEvent(key []byte, eventID uint64) ([][]byte, int) {
+	items, err := cache.db.LRange(key, 0, -1)
+
+	if err != nil {
+		return nil, -1
+	}
+
+	for index, item := range items {
+		var cachedEvent CachedChannelEvent
+
+		if err := proto.Unmarshal(item, &cachedEvent); err == nil && cachedEvent.ID == eventID {
+			return items, index
+		}
+	}
+
+	return items, -1
+}
+
+// InitChannelEventID - Set the channel event ID counter, if it isn't set yet
+func (cache *LedisCacheStorage) InitChannelEventID(channelID string, appID string, lastID uint64) {
+	key := []byte("app:" + appID + ":channel:" + channelID + ":eventID")
+
+	_, err := cache.db.SetNX(key, []byte(strconv.FormatUint(lastID, 10)))
+
+	if err != nil {
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Ledis Cache: failed to init channel event ID")
+	}
+}
+
+// NextChannelEventID - Increment and get the channel event ID counter, returns false if the counter isn't set
+func (cache *LedisCacheStorage) NextChannelEventID(channelID string, appID string) (uint64, bool) {
+	key := []byte("app:" + appID + ":channel:" + channelID + ":eventID")
+
+	cache.eventIDLock.Lock()
+	defer cache.eventIDLock.Unlock()
+
+	exists, err := cache.db.Exists(key)
+
+	if err != nil || exists == 0 {
+		return 0, false
+	}
+
+	ID, err := cache.db.Incr(key)
+
+	if err != nil {
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Ledis Cache: failed to increment channel event ID")
+		return 0, false
+	}
+
+	return uint64(ID), true
+}
+
+// StoreConnectionTicket - Store the ticket until it expires
+func (cache *LedisCacheStorage) StoreConnectionTicket(ticketID string, ticket *core.ConnectionTicket) bool {
+	key := []byte("ticket:" + ticketID)
+
+	err := cache.db.HMset(
+		key,
+		ledis.FVPair{Field: []byte("appID"), Value: []byte(ticket.AppID)},
+		ledis.FVPair{Field: []byte("clientID"), Value: []byte(ticket.ClientID)},
+		ledis.FVPair{Field: []byte("deviceID"), Value: []byte(ticket.DeviceID)},
+		ledis.FVPair{Field: []byte("role"), Value: []byte(ticket.Role)},
+		ledis.FVPair{Field: []byte("expiresAt"), Value: []byte(strconv.FormatInt(ticket.ExpiresAt, 10))},
+		ledis.FVPair{Field: []byte("issuedAt"), Value: []byte(strconv.FormatInt(ticket.IssuedAt, 10))},
+	)
+
+	if err != nil {
+		core.Logger().WithFields(log.Fields{"AppID": ticket.AppID, "ClientID": ticket.ClientID}).WithError(err).Error("Ledis Cache: failed to store connection ticket")
+		return false
+	}
+
+	// Ledis expires by seconds, the ticket expiration is checked when it is used
+	_, _ = cache.db.HExpire(key, int64(ticket.TTL()/time.Second)+1)
+
+	return true
+}
+
+// TakeConnectionTicket - Get and remove the ticket
+func (cache *LedisCacheStorage) TakeConnectionTicket(ticketID string) *core.ConnectionTicket {
+	key := []byte("ticket:" + ticketID)
+
+	cache.ticketsLock.Lock()
+	defer cache.ticketsLock.Unlock()
+
+	values, err := cache.db.HMget(key, []byte("appID"), []byte("clientID"), []byte("deviceID"), []byte("role"), []byte("expiresAt"), []byte("issuedAt"))
+
+	if err != nil {
+		core.Logger().WithError(err).Error("Ledis Cache: failed to take connection ticket")
+		return nil
+	}
+
+	if len(values) != 6 || values[4] == nil {
+		return nil
+	}
+
+	_, _ = cache.db.HClear(key)
+
+	expiresAt, err := strconv.ParseInt(string(values[4]), 10, 64)
+
+	if err != nil {
+		return nil
+	}
+
+	issuedAt, _ := strconv.ParseInt(string(values[5]), 10, 64)
+
+	return &core.ConnectionTicket{
+		AppID:     string(values[0]),
+		ClientID:  string(values[1]),
+		DeviceID:  string(values[2]),
+		Role:      string(values[3]),
+		IssuedAt:  issuedAt,
+		ExpiresAt: expiresAt,
+	}
+}
+
+// StoreRevocation - Store when the client, or device, tokens were revoked for the ttl
+func (cache *LedisCacheStorage) StoreRevocation(appID string, clientID string, deviceID string, revokedAt int64, ttl time.Duration) bool {
+	// Ledis expires by seconds
+	err := cache.db.SetEX([]byte(revocationKey(appID, clientID, deviceID)), int64(ttl/time.Second)+1, []byte(strconv.FormatInt(revokedAt, 10)))
+
+	if err != nil {
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ClientID": clientID, "DeviceID": deviceID}).WithError(err).Error("Ledis Cache: failed to store revocation")
+		return false
+	}
+
+	return true
+}
+
+// GetRevocation - Get when the client, or device, tokens were revoked
+func (cache *LedisCacheStorage) GetRevocation(appID string, clientID string, deviceID string) int64 {
+	value, err := cache.db.Get([]byte(revocationKey(appID, clientID, deviceID)))
+
+	if err != nil {
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ClientID": clientID, "DeviceID": deviceID}).WithError(err).Error("Ledis Cache: failed to get revocation")
+		return 0
+	}
+
+	if value == nil {
+		return 0
+	}
+
+	revokedAt, _ := strconv.ParseInt(string(value), 10, 64)
+
+	return revokedAt
+}
+
 // CheckDeviceExistence - Check if device exists in cache
 func (cache *LedisCacheStorage) CheckDeviceExistence(clientID string, id string) bool {
 	amount, err := cache.db.HGet([]byte(clientID+":device"), []byte(id))
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Ledis Cache: failed to check device existence %v\n", err)
+		core.Logger().WithField("ClientID", clientID).WithError(err).Error("Ledis Cache: failed to check device existence")
 		return false
 	}
 
@@ -151,7 +375,7 @@ func (cache *LedisCacheStorage) RemoveDevice(clientID string, id string) {
 	_, err := cache.db.HDel([]byte(clientID+":device"), []byte(id))
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Ledis Cache: failed to remove device %v\n", err)
+		core.Logger().WithField("ClientID", clientID).WithError(err).Error("Ledis Cache: failed to remove device")
 	}
 }
 
@@ -160,7 +384,7 @@ func (cache *LedisCacheStorage) AddDevice(clientID string, device *core.Device)
 	_, err := cache.db.HSet([]byte(clientID+":device"), []byte(device.ID), []byte(device.Token))
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Ledis Cache: failed to add device %v\n", err)
+		core.Logger().WithField("ClientID", clientID).WithError(err).Error("Ledis Cache: failed to add device")
 	}
 }
 
@@ -169,7 +393,7 @@ func (cache *LedisCacheStorage) GetClientDevices(clientID string) []*core.Device
 	data, err := cache.db.HGetAll([]byte(clientID + ":device"))
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Ledis Cache: failed to get devices data %v\n", err)
+		core.Logger().WithField("ClientID", clientID).WithError(err).Error("Ledis Cache: failed to get devices data")
 		return nil
 	}
 
@@ -192,7 +416,7 @@ func (cache *LedisCacheStorage) RemoveClient(appID string, clientID string) {
 	_, err := cache.db.Del([]byte(appID + ":client:" + clientID))
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Ledis Cache: failed to remove client %v\n", err)
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ClientID": clientID}).WithError(err).Error("Ledis Cache: failed to remove client")
 	}
 }
 
@@ -201,7 +425,7 @@ func (cache *LedisCacheStorage) RemoveClientChannels(clientID string) {
 	_, err := cache.db.Del([]byte("client:" + clientID + ":channels"))
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Ledis Cache: failed to remove client channels %v\n", err)
+		core.Logger().WithField("ClientID", clientID).WithError(err).Error("Ledis Cache: failed to remove client channels")
 	}
 }
 
@@ -210,7 +434,7 @@ func (cache *LedisCacheStorage) RemoveApp(appID string) {
 	_, err := cache.db.Del([]byte("app:" + appID))
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Ledis Cache: failed to remove app %v\n", err)
+		core.Logger().WithField("AppID", appID).WithError(err).Error("Ledis Cache: failed to remove app")
 	}
 }
 
@@ -219,7 +443,7 @@ func (cache *LedisCacheStorage) RemoveChannel(appID string, channelID string) {
 	_, err := cache.db.Del([]byte(appID + ":channel:" + channelID))
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Ledis Cache: failed to remove channel %v\n", err)
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Ledis Cache: failed to remove channel")
 	}
 }
 
@@ -236,7 +460,7 @@ func (cache *LedisCacheStorage) StoreClient(appID string, clientID string, clien
 		)
 
 		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "Ledis Cache: failed to store client %v\n", err)
+			core.Logger().WithFields(log.Fields{"AppID": appID, "ClientID": clientID}).WithError(err).Error("Ledis Cache: failed to store client")
 			return
 		}
 	}()
@@ -247,7 +471,7 @@ func (cache *LedisCacheStorage) CheckClientExistence(appID string, clientID stri
 	amount, err := cache.db.Exists([]byte(appID + ":client:" + clientID))
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Ledis Cache: failed to check client existence %v\n", err)
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ClientID": clientID}).WithError(err).Error("Ledis Cache: failed to check client existence")
 		return false
 	}
 
@@ -259,7 +483,7 @@ func (cache *LedisCacheStorage) GetClient(appID string, clientID string) *core.C
 	dData, err := cache.db.HMget([]byte(appID+":client:"+clientID), []byte("username"), []byte("extra"))
 
 	if err != nil || len(dData) != 2 {
-		_, _ = fmt.Fprintf(os.Stderr, "Ledis Cache: failed to retrieve cached client %v\n", err)
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ClientID": clientID}).WithError(err).Error("Ledis Cache: failed to retrieve cached client")
 		return nil
 	}
 
@@ -296,7 +520,7 @@ func (cache *LedisCacheStorage) StoreApp(appID string, name string) {
 	err := cache.db.Set([]byte("app:"+appID), []byte(name))
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Ledis Cache: failed to store cached app %v\n", err)
+		core.Logger().WithField("AppID", appID).WithError(err).Error("Ledis Cache: failed to store cached app")
 	}
 }
 
@@ -305,7 +529,7 @@ func (cache *LedisCacheStorage) GetApp(appID string) *core.App {
 	data, err := cache.db.Get([]byte("app:" + appID))
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Ledis Cache: failed to parse cached app %v\n", err)
+		core.Logger().WithField("AppID", appID).WithError(err).Error("Ledis Cache: failed to parse cached app")
 		return nil
 	}
 
@@ -338,14 +562,14 @@ func (cache *LedisCacheStorage) StoreChannel(appID string, channelID string, cha
 		data, err := proto.Marshal(&cachedChannel)
 
 		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "Ledis Cache: failed to marshal cached channel %v\n", err)
+			core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Ledis Cache: failed to marshal cached channel")
 			return
 		}
 
 		err = cache.db.Set([]byte(appID+":channel:"+channelID), data)
 
 		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "Ledis Cache: failed to store cached channel %v\n", err)
+			core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Ledis Cache: failed to store cached channel")
 			return
 		}
 	}()
@@ -356,7 +580,7 @@ func (cache *LedisCacheStorage) GetChannel(appID string, channelID string) *core
 	data, err := cache.db.Get([]byte(appID + ":channel:" + channelID))
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Ledis Cache: failed to retrieve cached channel %v\n", err)
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Ledis Cache: failed to retrieve cached channel")
 		return nil
 	}
 
@@ -369,7 +593,7 @@ func (cache *LedisCacheStorage) GetChannel(appID string, channelID string) *core
 	err = proto.Unmarshal(data, &cachedChannel)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Ledis Cache: failed to umarshal cached channel %v\n", err)
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Ledis Cache: failed to umarshal cached channel")
 		return nil
 	}
 
@@ -392,7 +616,7 @@ func (cache *LedisCacheStorage) CheckChannelExistence(appID string, channelID st
 	amount, err := cache.db.Exists([]byte(appID + ":channel:" + channelID))
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Ledis Cache: failed to verify channel existence %v\n", err)
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Ledis Cache: failed to verify channel existence")
 		return false
 	}
 
@@ -411,7 +635,7 @@ func (cache *LedisCacheStorage) AddClientChannels(clientID string, channelIDs []
 	_, err := cache.db.SAdd([]byte("client:"+clientID+":channels"), channelsBin...)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Ledis Cache: failed to add multiple client channels %v\n", err)
+		core.Logger().WithField("ClientID", clientID).WithError(err).Error("Ledis Cache: failed to add multiple client channels")
 	}
 
 }
@@ -421,7 +645,7 @@ func (cache *LedisCacheStorage) GetClientChannels(clientID string) ([]string, bo
 	dData, err := cache.db.SMembers([]byte("client:" + clientID + ":channels"))
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Ledis Cache: failed to retrieve client channels %v\n", err)
+		core.Logger().WithField("ClientID", clientID).WithError(err).Error("Ledis Cache: failed to retrieve client channels")
 		return nil, false
 	}
 
@@ -443,7 +667,7 @@ func (cache *LedisCacheStorage) AddClientChannel(clientID string, channelID stri
 	_, err := cache.db.SAdd([]byte("client:"+clientID+":channels"), []byte(channelID))
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Ledis Cache: failed to add single client channel %v\n", err)
+		core.Logger().WithFields(log.Fields{"ChannelID": channelID, "ClientID": clientID}).WithError(err).Error("Ledis Cache: failed to add single client channel")
 	}
 }
 
@@ -452,7 +676,7 @@ func (cache *LedisCacheStorage) RemoveClientChannel(clientID string, channelID s
 	_, err := cache.db.SRem([]byte("client:"+clientID+":channels"), []byte(channelID))
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Ledis Cache: failed to store client channels %v\n", err)
+		core.Logger().WithFields(log.Fields{"ChannelID": channelID, "ClientID": clientID}).WithError(err).Error("Ledis Cache: failed to store client channels")
 	}
 }
 
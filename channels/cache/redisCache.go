@@ -3,20 +3,21 @@ package cache
 
 import (
 	"context"
-	"fmt"
-	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/lisomatrix/channels/channels/core"
+	"github.com/lisomatrix/channels/channels/redisconfig"
 
 	"github.com/go-redis/redis/v8"
+	log "github.com/sirupsen/logrus"
 	"google.golang.org/protobuf/proto"
 )
 
 // RedisCacheStorage - Cache implementation in Redis
 type RedisCacheStorage struct {
-	db  *redis.Client
+	db  redis.UniversalClient
 	ctx context.Context
 }
 
@@ -25,14 +26,14 @@ func (cache *RedisCacheStorage) GetChannelEvents(channelID string, appID string,
 	cmd := cache.db.LRange(cache.ctx, "app:" + appID + ":channel:" + channelID + ":events", 0, amount)
 
 	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed get cached events %v\n", cmd.Err())
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(cmd.Err()).Error("Redis Cache: failed get cached events")
 		return nil
 	}
 
 	dData, err := cmd.Result()
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed get LRANGE result %v\n", cmd.Err())
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(cmd.Err()).Error("Redis Cache: failed get LRANGE result")
 		return nil
 	}
 
@@ -44,7 +45,7 @@ func (cache *RedisCacheStorage) GetChannelEvents(channelID string, appID string,
 		err = proto.Unmarshal([]byte(data), &cachedEvent)
 
 		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed umarshal cached event %v\n", cmd.Err())
+			core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(cmd.Err()).Error("Redis Cache: failed umarshal cached event")
 			return nil
 		}
 
@@ -54,6 +55,7 @@ func (cache *RedisCacheStorage) GetChannelEvents(channelID string, appID string,
 			EventType: cachedEvent.EventType,
 			ChannelID: channelID,
 			Timestamp: cachedEvent.Timestamp,
+			ID:        cachedEvent.ID,
 		})
 	}
 
@@ -66,14 +68,14 @@ func (cache *RedisCacheStorage) GetChannelEventsSize(channelID string, appID str
 	cmd := cache.db.LLen(cache.ctx, key)
 
 	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed get cached event queue size %v\n", cmd.Err())
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(cmd.Err()).Error("Redis Cache: failed get cached event queue size")
 		return 0
 	}
 
 	result, err := cmd.Result()
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed get LRANGE result %v\n", cmd.Err())
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(cmd.Err()).Error("Redis Cache: failed get LRANGE result")
 		return 0
 	}
 
@@ -85,14 +87,14 @@ func (cache *RedisCacheStorage) GetOldestChannelEvent(channelID string, appID st
 	cmd := cache.db.LRange(cache.ctx, "app:" + appID + ":channel:" + channelID + ":events", -1, -1)
 
 	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed get oldest channel event %v\n", cmd.Err())
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(cmd.Err()).Error("Redis Cache: failed get oldest channel event")
 		return nil
 	}
 
 	results, err := cmd.Result()
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed get LRANGE result %v\n", cmd.Err())
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(cmd.Err()).Error("Redis Cache: failed get LRANGE result")
 		return nil
 	}
 
@@ -105,7 +107,7 @@ func (cache *RedisCacheStorage) GetOldestChannelEvent(channelID string, appID st
 	err = proto.Unmarshal([]byte(results[0]), &cachedEvent)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed umarshal cached event %v\n", cmd.Err())
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(cmd.Err()).Error("Redis Cache: failed umarshal cached event")
 		return nil
 	}
 
@@ -115,6 +117,7 @@ func (cache *RedisCacheStorage) GetOldestChannelEvent(channelID string, appID st
 		EventType: cachedEvent.EventType,
 		ChannelID: channelID,
 		Timestamp: cachedEvent.Timestamp,
+		ID:        cachedEvent.ID,
 	}
 }
 
@@ -126,12 +129,13 @@ func (cache *RedisCacheStorage) StoreChannelEvent(channelID string, appID string
 		Payload:   event.Payload,
 		Timestamp: event.Timestamp,
 		EventType: event.EventType,
+		ID:        event.ID,
 	}
 
 	eventData, err := proto.Marshal(&cachedEvent)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed to marshal cached event %v\n", err)
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Redis Cache: failed to marshal cached event")
 		return
 	}
 
@@ -144,14 +148,14 @@ func (cache *RedisCacheStorage) StoreChannelEvent(channelID string, appID string
 	_, err = pipeliner.Exec(cache.ctx)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed to pipeline LPUSH and EXPIRE %v\n", err)
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Redis Cache: failed to pipeline LPUSH and EXPIRE")
 		return
 	}
 
 	amount, err := cmd.Result()
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed get LPUSH result %v\n", cmd.Err())
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(cmd.Err()).Error("Redis Cache: failed get LPUSH result")
 		return
 	}
 
@@ -161,12 +165,226 @@ func (cache *RedisCacheStorage) StoreChannelEvent(channelID string, appID string
 
 }
 
+// UpdateChannelEvent - Replace the cached event with the same ID, if it is still cached
+func (cache *RedisCacheStorage) UpdateChannelEvent(channelID string, appID string, event *core.ChannelEvent) {
+
+	cachedEvent := CachedChannelEvent{
+		SenderID:  event.SenderID,
+		Payload:   event.Payload,
+		Timestamp: event.Timestamp,
+		EventType: event.EventType,
+		ID:        event.ID,
+	}
+
+	eventData, err := proto.Marshal(&cachedEvent)
+
+	if err != nil {
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Redis Cache: failed to marshal cached event")
+		return
+	}
+
+	if err := cache.changeChannelEvent(channelID, appID, event.ID, eventData); err != nil {
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "EventID": event.ID}).WithError(err).Error("Redis Cache: failed to update cached event")
+	}
+}
+
+// RemoveChannelEvent - Remove the cached event with the given ID, if it is still cached
+func (cache *RedisCacheStorage) RemoveChannelEvent(channelID string, appID string, eventID uint64) {
+	if err := cache.changeChannelEvent(channelID, appID, eventID, nil); err != nil {
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "EventID": eventID}).WithError(err).Error("Redis Cache: failed to remove cached event")
+	}
+}
+
+// changeChannelEvent - Replace the cached event with the given ID by eventData, or remove it if eventData is nil
+// The queue is watched, so events pushed meanwhile don't shift the one being changed
+func (cache *RedisCacheStorage) changeChannelEvent(channelID string, appID string, eventID uint64, eventData []byte) error {
+	key := "app:" + appID + ":channel:" + channelID + ":events"
+
+	change := func(tx *redis.Tx) error {
+		items, err := tx.LRange(cache.ctx, key, 0, -1).Result()
+
+		if err != nil {
+			return err
+		}
+
+		for index, item := range items {
+			var cachedEvent CachedChannelEvent
+
+			if err := proto.Unmarshal([]byte(item), &cachedEvent); err != nil || cachedEvent.ID != eventID {
+				continue
+			}
+
+			_, err = tx.TxPipelined(cache.ctx, func(pipeliner redis.Pipeliner) error {
+				if eventData != nil {
+					pipeliner.LSet(cache.ctx, key, int64(index), eventData)
+				} else {
+					pipeliner.LRem(cache.ctx, key, 1, item)
+				}
+
+				return nil
+			})
+
+			return err
+		}
+
+		return nil
+	}
+
+	var err error
+
+	for attempt := 0; attempt < 3; attempt++ {
+		if err = cache.db.Watch(cache.ctx, change, key); err != redis.TxFailedErr {
+			return err
+		}
+	}
+
+	return err
+}
+
+// incrExistingScript - Only increments the key if it already exists, otherwise returns -1
+var incrExistingScript = redis.NewScript(`if redis.call("EXISTS", KEYS[1]) == 1 then return redis.call("INCR", KEYS[1]) end return -1`)
+
+// InitChannelEventID - Set the channel event ID counter, if it isn't set yet
+func (cache *RedisCacheStorage) InitChannelEventID(channelID string, appID string, lastID uint64) {
+	cmd := cache.db.SetNX(cache.ctx, "app:"+appID+":channel:"+channelID+":eventID", lastID, 0)
+
+	if cmd.Err() != nil {
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(cmd.Err()).Error("Redis Cache: failed to init channel event ID")
+	}
+}
+
+// NextChannelEventID - Increment and get the channel event ID counter, returns false if the counter isn't set
+func (cache *RedisCacheStorage) NextChannelEventID(channelID string, appID string) (uint64, bool) {
+	key := "app:" + appID + ":channel:" + channelID + ":eventID"
+
+	ID, err := incrExistingScript.Run(cache.ctx, cache.db, []string{key}).Int64()
+
+	if err != nil {
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Redis Cache: failed to increment channel event ID")
+		return 0, false
+	}
+
+	if ID < 0 {
+		return 0, false
+	}
+
+	return uint64(ID), true
+}
+
+// takeTicketScript - Get the ticket fields and remove it, so two servers can't both use it
+var takeTicketScript = redis.NewScript(`local values = redis.call("HMGET", KEYS[1], "appID", "clientID", "deviceID", "role", "expiresAt", "issuedAt") redis.call("DEL", KEYS[1]) return values`)
+
+// StoreConnectionTicket - Store the ticket until it expires
+func (cache *RedisCacheStorage) StoreConnectionTicket(ticketID string, ticket *core.ConnectionTicket) bool {
+	key := "ticket:" + ticketID
+
+	_, err := cache.db.TxPipelined(cache.ctx, func(pipeliner redis.Pipeliner) error {
+		pipeliner.HSet(cache.ctx, key, "appID", ticket.AppID, "clientID", ticket.ClientID, "deviceID", ticket.DeviceID, "role", ticket.Role, "expiresAt", ticket.ExpiresAt, "issuedAt", ticket.IssuedAt)
+		pipeliner.PExpire(cache.ctx, key, ticket.TTL())
+		return nil
+	})
+
+	if err != nil {
+		core.Logger().WithFields(log.Fields{"AppID": ticket.AppID, "ClientID": ticket.ClientID}).WithError(err).Error("Redis Cache: failed to store connection ticket")
+		return false
+	}
+
+	return true
+}
+
+// TakeConnectionTicket - Get and remove the ticket
+func (cache *RedisCacheStorage) TakeConnectionTicket(ticketID string) *core.ConnectionTicket {
+	result, err := takeTicketScript.Run(cache.ctx, cache.db, []string{"ticket:" + ticketID}).Result()
+
+	if err != nil {
+		core.Logger().WithError(err).Error("Redis Cache: failed to take connection ticket")
+		return nil
+	}
+
+	values, isOK := result.([]interface{})
+
+	if !isOK || len(values) != 6 {
+		return nil
+	}
+
+	fields := make([]string, 0, len(values))
+
+	for _, value := range values {
+		field, isOK := value.(string)
+
+		// Missing ticket
+		if !isOK {
+			return nil
+		}
+
+		fields = append(fields, field)
+	}
+
+	expiresAt, err := strconv.ParseInt(fields[4], 10, 64)
+
+	if err != nil {
+		return nil
+	}
+
+	issuedAt, err := strconv.ParseInt(fields[5], 10, 64)
+
+	if err != nil {
+		return nil
+	}
+
+	return &core.ConnectionTicket{
+		AppID:     fields[0],
+		ClientID:  fields[1],
+		DeviceID:  fields[2],
+		Role:      fields[3],
+		IssuedAt:  issuedAt,
+		ExpiresAt: expiresAt,
+	}
+}
+
+// revocationKey - Key of the client revocation, or of one of its devices
+func revocationKey(appID string, clientID string, deviceID string) string {
+	if deviceID == "" {
+		return "revoked:" + appID + ":" + clientID
+	}
+
+	return "revoked:" + appID + ":" + clientID + ":" + deviceID
+}
+
+// StoreRevocation - Store when the client, or device, tokens were revoked for the ttl
+func (cache *RedisCacheStorage) StoreRevocation(appID string, clientID string, deviceID string, revokedAt int64, ttl time.Duration) bool {
+	cmd := cache.db.Set(cache.ctx, revocationKey(appID, clientID, deviceID), revokedAt, ttl)
+
+	if cmd.Err() != nil {
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ClientID": clientID, "DeviceID": deviceID}).WithError(cmd.Err()).Error("Redis Cache: failed to store revocation")
+		return false
+	}
+
+	return true
+}
+
+// GetRevocation - Get when the client, or device, tokens were revoked
+func (cache *RedisCacheStorage) GetRevocation(appID string, clientID string, deviceID string) int64 {
+	revokedAt, err := cache.db.Get(cache.ctx, revocationKey(appID, clientID, deviceID)).Int64()
+
+	if err == redis.Nil {
+		return 0
+	}
+
+	if err != nil {
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ClientID": clientID, "DeviceID": deviceID}).WithError(err).Error("Redis Cache: failed to get revocation")
+		return 0
+	}
+
+	return revokedAt
+}
+
 // CheckDeviceExistence - Check if device exists in cache
 func (cache *RedisCacheStorage) CheckDeviceExistence(clientID string, id string) bool {
 	cmd := cache.db.HExists(cache.ctx, clientID+":device", id)
 
 	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed to check device existence %v\n", cmd.Err())
+		core.Logger().WithField("ClientID", clientID).WithError(cmd.Err()).Error("Redis Cache: failed to check device existence")
 		return false
 	}
 
@@ -178,7 +396,7 @@ func (cache *RedisCacheStorage) RemoveDevice(clientID string, id string) {
 	cmd := cache.db.HDel(cache.ctx, clientID+":device", id)
 
 	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed to remove device %v\n", cmd.Err())
+		core.Logger().WithField("ClientID", clientID).WithError(cmd.Err()).Error("Redis Cache: failed to remove device")
 	}
 }
 
@@ -187,7 +405,7 @@ func (cache *RedisCacheStorage) AddDevice(clientID string, device *core.Device)
 	cmd := cache.db.HSet(cache.ctx, clientID+":device", device.ID, device.Token)
 
 	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed to add device %v\n", cmd.Err())
+		core.Logger().WithField("ClientID", clientID).WithError(cmd.Err()).Error("Redis Cache: failed to add device")
 	}
 }
 
@@ -196,14 +414,14 @@ func (cache *RedisCacheStorage) GetClientDevices(clientID string) []*core.Device
 	cmd := cache.db.HGetAll(cache.ctx, clientID+":device")
 
 	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: to get devices %v\n", cmd.Err())
+		core.Logger().WithField("ClientID", clientID).WithError(cmd.Err()).Error("Redis Cache: to get devices")
 		return nil
 	}
 
 	data, err := cmd.Result()
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed to get devices data %v\n", err)
+		core.Logger().WithField("ClientID", clientID).WithError(err).Error("Redis Cache: failed to get devices data")
 		return nil
 	}
 
@@ -226,7 +444,7 @@ func (cache *RedisCacheStorage) RemoveClient(appID string, clientID string) {
 	cmd := cache.db.Del(cache.ctx, appID+":client:"+clientID)
 
 	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed to remove client %v\n", cmd.Err())
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ClientID": clientID}).WithError(cmd.Err()).Error("Redis Cache: failed to remove client")
 	}
 }
 
@@ -235,7 +453,7 @@ func (cache *RedisCacheStorage) RemoveClientChannels(clientID string) {
 	cmd := cache.db.Del(cache.ctx, "client:"+clientID+":channels")
 
 	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed to remove client channels %v\n", cmd.Err())
+		core.Logger().WithField("ClientID", clientID).WithError(cmd.Err()).Error("Redis Cache: failed to remove client channels")
 	}
 }
 
@@ -244,7 +462,7 @@ func (cache *RedisCacheStorage) RemoveApp(appID string) {
 	cmd := cache.db.Del(cache.ctx, "app:"+appID)
 
 	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed to remove app %v\n", cmd.Err())
+		core.Logger().WithField("AppID", appID).WithError(cmd.Err()).Error("Redis Cache: failed to remove app")
 	}
 }
 
@@ -253,7 +471,7 @@ func (cache *RedisCacheStorage) RemoveChannel(appID string, channelID string) {
 	cmd := cache.db.Del(cache.ctx, appID+":channel:"+channelID)
 
 	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed to remove channel %v\n", cmd.Err())
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(cmd.Err()).Error("Redis Cache: failed to remove channel")
 	}
 }
 
@@ -262,10 +480,10 @@ func (cache *RedisCacheStorage) StoreClient(appID string, clientID string, clien
 
 	// Lunch a goroutine to reduce latency
 	go func() {
-		err := cache.db.HMSet(cache.ctx, appID+":client:"+clientID, "username", client.Username, "extra", client.Extra)
+		cmd := cache.db.HMSet(cache.ctx, appID+":client:"+clientID, "username", client.Username, "extra", client.Extra)
 
-		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed to store client %v\n", err)
+		if cmd.Err() != nil {
+			core.Logger().WithFields(log.Fields{"AppID": appID, "ClientID": clientID}).WithError(cmd.Err()).Error("Redis Cache: failed to store client")
 			return
 		}
 	}()
@@ -276,7 +494,7 @@ func (cache *RedisCacheStorage) CheckClientExistence(appID string, clientID stri
 	cmd := cache.db.Exists(cache.ctx, appID+":client:"+clientID)
 
 	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed to check client existence %v\n", cmd.Err())
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ClientID": clientID}).WithError(cmd.Err()).Error("Redis Cache: failed to check client existence")
 		return false
 	}
 
@@ -290,7 +508,7 @@ func (cache *RedisCacheStorage) GetClient(appID string, clientID string) *core.C
 	dData, err := cmd.Result()
 
 	if cmd.Err() != nil || len(dData) != 2 {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed to retrieve cached client %v\n", err)
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ClientID": clientID}).WithError(err).Error("Redis Cache: failed to retrieve cached client")
 		return nil
 	}
 
@@ -299,7 +517,7 @@ func (cache *RedisCacheStorage) GetClient(appID string, clientID string) *core.C
 	err = cmd.Scan(&cachedClient)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed to umarshal cached client %v\n", err)
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ClientID": clientID}).WithError(err).Error("Redis Cache: failed to umarshal cached client")
 		return nil
 	}
 
@@ -321,7 +539,7 @@ func (cache *RedisCacheStorage) StoreApp(appID string, name string) {
 	cmd := cache.db.Set(cache.ctx, "app:"+appID, name, 0)
 
 	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed to store cached app %v\n", cmd.Err())
+		core.Logger().WithField("AppID", appID).WithError(cmd.Err()).Error("Redis Cache: failed to store cached app")
 	}
 }
 
@@ -330,14 +548,14 @@ func (cache *RedisCacheStorage) GetApp(appID string) *core.App {
 	cmd := cache.db.Get(cache.ctx, "app:"+appID)
 
 	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed to retrieve cached app %v\n", cmd.Err())
+		core.Logger().WithField("AppID", appID).WithError(cmd.Err()).Error("Redis Cache: failed to retrieve cached app")
 		return nil
 	}
 
 	data, err := cmd.Result()
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed to parse cached app %v\n", err)
+		core.Logger().WithField("AppID", appID).WithError(err).Error("Redis Cache: failed to parse cached app")
 		return nil
 	}
 
@@ -366,14 +584,14 @@ func (cache *RedisCacheStorage) StoreChannel(appID string, channelID string, cha
 		data, err := proto.Marshal(&cachedChannel)
 
 		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed to marshal cached channel %v\n", err)
+			core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Redis Cache: failed to marshal cached channel")
 			return
 		}
 
 		cmd := cache.db.Set(cache.ctx, appID+":channel:"+channelID, data, 0)
 
 		if cmd.Err() != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed to store cached channel %v\n", err)
+			core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Redis Cache: failed to store cached channel")
 			return
 		}
 	}()
@@ -385,14 +603,14 @@ func (cache *RedisCacheStorage) GetChannel(appID string, channelID string) *core
 
 	// In case it has nil then there is ni need to log this data, just means it didn't find the key
 	if cmd.Err() != nil && strings.Contains(cmd.Err().Error(), "nil") {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed to retrieve execute command %v\n", cmd.Err())
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(cmd.Err()).Error("Redis Cache: failed to retrieve execute command")
 		return nil
 	}
 
 	data, err := cmd.Bytes()
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed to retrieve cached channel %v\n", err)
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Redis Cache: failed to retrieve cached channel")
 		return nil
 	}
 
@@ -401,7 +619,7 @@ func (cache *RedisCacheStorage) GetChannel(appID string, channelID string) *core
 	err = proto.Unmarshal(data, &cachedChannel)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed to umarshal cached channel %v\n", err)
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Redis Cache: failed to umarshal cached channel")
 		return nil
 	}
 
@@ -424,14 +642,14 @@ func (cache *RedisCacheStorage) CheckChannelExistence(appID string, channelID st
 	cmd := cache.db.Exists(cache.ctx, appID+":channel:"+channelID)
 
 	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed to check cached channel existence %v\n", cmd.Err())
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(cmd.Err()).Error("Redis Cache: failed to check cached channel existence")
 		return false
 	}
 
 	amount, err := cmd.Uint64()
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed to verify channel existence %v\n", cmd.Err())
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(cmd.Err()).Error("Redis Cache: failed to verify channel existence")
 		return false
 	}
 
@@ -450,7 +668,7 @@ func (cache *RedisCacheStorage) AddClientChannels(clientID string, channelIDs []
 	cmd := cache.db.SAdd(cache.ctx, "client:"+clientID+":channels", channelsBin)
 
 	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed to add multiple client channels %v\n", cmd.Err())
+		core.Logger().WithField("ClientID", clientID).WithError(cmd.Err()).Error("Redis Cache: failed to add multiple client channels")
 	}
 
 }
@@ -460,14 +678,14 @@ func (cache *RedisCacheStorage) GetClientChannels(clientID string) ([]string, bo
 	cmd := cache.db.SMembers(cache.ctx, "client:"+clientID+":channels")
 
 	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed to executre command %v\n", cmd.Err())
+		core.Logger().WithField("ClientID", clientID).WithError(cmd.Err()).Error("Redis Cache: failed to executre command")
 		return nil, false
 	}
 
 	dData, err := cmd.Result()
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed to retrieve client channels %v\n", err)
+		core.Logger().WithField("ClientID", clientID).WithError(err).Error("Redis Cache: failed to retrieve client channels")
 		return nil, false
 	}
 
@@ -489,7 +707,7 @@ func (cache *RedisCacheStorage) AddClientChannel(clientID string, channelID stri
 	cmd := cache.db.SAdd(cache.ctx, "client:"+clientID+":channels", channelID)
 
 	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed to add single client channel %v\n", cmd.Err())
+		core.Logger().WithFields(log.Fields{"ChannelID": channelID, "ClientID": clientID}).WithError(cmd.Err()).Error("Redis Cache: failed to add single client channel")
 	}
 }
 
@@ -498,21 +716,24 @@ func (cache *RedisCacheStorage) RemoveClientChannel(clientID string, channelID s
 	cmd := cache.db.SRem(cache.ctx, "client:"+clientID+":channels", channelID)
 
 	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Cache: failed to store client channels %v\n", cmd.Err())
+		core.Logger().WithFields(log.Fields{"ChannelID": channelID, "ClientID": clientID}).WithError(cmd.Err()).Error("Redis Cache: failed to store client channels")
 	}
 }
 
 
-// NewRedisCacheStorage - Create a new Redis cache instance
-func NewRedisCacheStorage() *RedisCacheStorage {
+// NewRedisCacheStorage - Create a new Redis cache instance connected with the given settings, use redisconfig.Default() for a local node
+func NewRedisCacheStorage(config redisconfig.Config) (*RedisCacheStorage, error) {
 
-	db := redis.NewClient(
-		&redis.Options{
-			Addr: "127.0.0.1:6379",
-			// Password: "CulP3gnpgSAxFlbjO/JrNCR/uTKFKvTLbW7gJoVQfg1sh1BmzeNBUs5TsXy0Q7YDgGbfazSZy5LKnU3l", // no password set
-			DB:       0,
-			PoolSize: 5,
-		})
+	db, err := config.NewClient()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewRedisCacheStorageWithClient(db), nil
+}
 
+// NewRedisCacheStorageWithClient - Create cache with the given client, see redisconfig.Config to create one
+func NewRedisCacheStorageWithClient(db redis.UniversalClient) *RedisCacheStorage {
 	return &RedisCacheStorage{db: db, ctx: context.Background()}
 }
@@ -3,14 +3,19 @@ package channels
 import (
 	"os"
 
+	"github.com/lisomatrix/channels/channels/auth"
+	"github.com/lisomatrix/channels/channels/core"
+	"github.com/lisomatrix/channels/channels/redisconfig"
 	"gopkg.in/yaml.v2"
 )
 
 type Config struct {
-	JWTSecret string `yaml:"jwt"`
+	JWTSecret string      `yaml:"jwt"`
+	Auth      auth.Config `yaml:"auth"` // Given to auth.Configure
 	Server    struct {
-		Host string `yaml:"host"`
-		Port string `yaml:"port"`
+		Host           string `yaml:"host"`
+		Port           string `yaml:"port"`
+		MetricsAddress string `yaml:"metrics_address"` // Given to channels.MetricsAddress
 	} `yaml:"server"`
 	Database struct {
 		User     string `yaml:"user"`
@@ -19,11 +24,16 @@ type Config struct {
 		Host     string `yaml:"host"`
 		Port     string `yaml:"port"`
 	} `yaml:"database"`
+	Redis redisconfig.Config `yaml:"redis"` // Used by the Redis cache, presence and publisher
+	Log   core.LogConfig     `yaml:"log"`   // Given to EngineConfig.LogConfig
+	CORS  core.CORSConfig    `yaml:"cors"`  // Given to EngineConfig.CORS
 }
 
 func NewConfig(configPath string) (*Config, error) {
 	// Create config structure
-	config := &Config{}
+	config := &Config{
+		Redis: redisconfig.Default(),
+	}
 
 	// Open config file
 	file, err := os.Open(configPath)
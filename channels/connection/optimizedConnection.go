@@ -2,18 +2,24 @@
 package connection
 
 import (
-	"log"
 	"net"
+	"sync"
 	"time"
 
 	"github.com/gobwas/ws"
 	"github.com/gobwas/ws/wsutil"
+	"github.com/lisomatrix/channels/channels/core"
 )
 
+type owsMsg struct {
+	op      ws.OpCode
+	payload []byte
+}
+
 // OWebSocketConnection - O stands for optimized
 // Implementation of the Connection interface for WebSocket clients
 type OWebSocketConnection struct {
-	messageSendChannel chan []byte
+	messageSendChannel chan owsMsg
 	ws                 net.Conn
 	onCloseCB          func()
 	onMessage          func([]byte)
@@ -21,6 +27,8 @@ type OWebSocketConnection struct {
 	isClosed           bool
 	pongTimer          *time.Timer
 	didReceivePong     bool
+	writeLock          sync.Mutex
+	isJSON             bool // Events are sent as JSON text frames instead of protobuf binary frames
 }
 
 // IsConnected - If connection is still alive
@@ -29,23 +37,31 @@ func (connection *OWebSocketConnection) IsConnected() bool {
 }
 
 // Init - Initialize connection and start reading and writing messages
-func (connection *OWebSocketConnection) Init(ws net.Conn) {
+// If isJSON is set, events are sent as JSON text frames
+func (connection *OWebSocketConnection) Init(ws net.Conn, isJSON bool) {
 
 	connection.ws = ws
-	connection.messageSendChannel = make(chan []byte, 10)
+	connection.isJSON = isJSON
+	connection.messageSendChannel = make(chan owsMsg, 10)
 
 	go connection.writeMessages()
 	go connection.readMessages()
 }
 
-// Send - Send the data to the client
+// Send - Send the protobuf event to the client
 func (connection *OWebSocketConnection) Send(data []byte) {
-	connection.messageSendChannel <- data
+	connection.messageSendChannel <- owsMsg{
+		op:      ws.OpBinary,
+		payload: data,
+	}
 }
 
-// SendText - Send the data to the client
+// SendText - Send the data to the client as a text frame
 func (connection *OWebSocketConnection) SendText(data []byte) {
-	connection.messageSendChannel <- data
+	connection.messageSendChannel <- owsMsg{
+		op:      ws.OpText,
+		payload: data,
+	}
 }
 
 // SetOnMessage - Set user sent message callback
@@ -74,6 +90,28 @@ func (connection *OWebSocketConnection) Close() {
 	}
 }
 
+// CloseWithStatus - Send a close frame with the given status, then close the connection
+func (connection *OWebSocketConnection) CloseWithStatus(code uint16, reason string) {
+	if connection.isClosed {
+		return
+	}
+
+	body := ws.NewCloseFrameBody(ws.StatusCode(code), reason)
+
+	_ = connection.ws.SetWriteDeadline(time.Now().Add(writeWait))
+	_ = connection.write(ws.OpClose, body)
+
+	connection.Close()
+}
+
+// write - Write message, it's safe to call from multiple goroutines
+func (connection *OWebSocketConnection) write(op ws.OpCode, data []byte) error {
+	connection.writeLock.Lock()
+	defer connection.writeLock.Unlock()
+
+	return wsutil.WriteServerMessage(connection.ws, op, data)
+}
+
 func (connection *OWebSocketConnection) readMessages() {
 	defer func() {
 		if !connection.isClosed {
@@ -91,7 +129,7 @@ func (connection *OWebSocketConnection) readMessages() {
 
 		msg, err := wsutil.ReadClientMessage(connection.ws, msg[:0])
 		if err != nil {
-			log.Printf("read message error: %v", err)
+			core.Logger().WithError(err).Debug("Optimized Connection: read message error")
 			return
 		}
 		for _, m := range msg {
@@ -108,10 +146,13 @@ func (connection *OWebSocketConnection) readMessages() {
 					continue
 				}
 
+				// Replies to pings and close frames, so it must not overlap other writes
+				connection.writeLock.Lock()
 				err := wsutil.HandleClientControlMessage(connection.ws, m)
+				connection.writeLock.Unlock()
 
 				if err != nil {
-					log.Printf("handle control error: %v", err)
+					core.Logger().WithError(err).Debug("Optimized Connection: handle control error")
 					return
 				}
 
@@ -122,6 +163,18 @@ func (connection *OWebSocketConnection) readMessages() {
 				if connection.onMessage != nil {
 					connection.onMessage(m.Payload)
 				}
+			} else if m.OpCode == ws.OpText {
+				// Text frames are JSON events, the session only understands protobuf
+				data, err := core.UnmarshalNewEventJSON(m.Payload)
+
+				if err != nil {
+					core.Logger().WithError(err).Warn("Optimized Connection: invalid json event")
+					continue
+				}
+
+				if connection.onMessage != nil {
+					connection.onMessage(data)
+				}
 			}
 		}
 	}
@@ -138,7 +191,7 @@ func (connection *OWebSocketConnection) writeMessages() {
 		}
 	}()
 
-	if err := wsutil.WriteServerMessage(connection.ws, ws.OpPing, nil); err != nil {
+	if err := connection.write(ws.OpPing, nil); err != nil {
 		return
 	}
 
@@ -158,9 +211,20 @@ func (connection *OWebSocketConnection) writeMessages() {
 					return
 				}
 
+				if message.op == ws.OpBinary && connection.isJSON {
+					data, err := core.MarshalNewEventJSON(message.payload)
+
+					if err != nil {
+						core.Logger().WithError(err).Error("Optimized Connection: failed to convert event to json")
+						continue
+					}
+
+					message = owsMsg{op: ws.OpText, payload: data}
+				}
+
 				// Gob WS websockets
 				// If an error occurred while sending messages then we have a problem
-				if err := wsutil.WriteServerMessage(connection.ws, ws.OpBinary, message); err != nil {
+				if err := connection.write(message.op, message.payload); err != nil {
 					return
 				}
 			}
@@ -180,7 +244,7 @@ func (connection *OWebSocketConnection) writeMessages() {
 						connection.didReceivePong = false
 						pingPongTimer.Reset(pingPeriod)
 
-						if err := wsutil.WriteServerMessage(connection.ws, ws.OpPing, nil); err != nil {
+						if err := connection.write(ws.OpPing, nil); err != nil {
 							return
 						}
 
@@ -1,30 +1,66 @@
 package connection
 
-import "github.com/gorilla/websocket"
+import (
+	"bytes"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/lisomatrix/channels/channels/core"
+	"go.uber.org/atomic"
+)
+
+const (
+	// Send heartbeats with this period, so proxies don't close idle streams
+	sseHeartBeatPeriod = 15 * time.Second
+)
+
+var sseHeartBeat = []byte(": heartbeat\n\n")
 
 // SSEConnection - Implementation of the Connection interface for Server Sent Event clients
+// The connection is read only, events are sent as JSON and clients can't send requests
 type SSEConnection struct {
 	messageSendChannel chan []byte
-	ws                 *websocket.Conn
+	writer             http.ResponseWriter
+	flusher            http.Flusher
 	onCloseCB          func()
-	onMessage          func([]byte)
-	isClosed           bool
+	onHB               func()
+	isClosed           atomic.Bool
+	closed             chan struct{}
+	closeOnce          sync.Once
+	closeEvent         []byte
+	lastEventIDs       map[string]uint64 // Last event ID sent of each channel, sent as the SSE id so clients can resume
 }
 
 // Init - Initialize connection channels
-func (connection *SSEConnection) Init() {
-	connection.isClosed = false
+func (connection *SSEConnection) Init(writer http.ResponseWriter, flusher http.Flusher, lastEventIDs map[string]uint64) {
+	connection.writer = writer
+	connection.flusher = flusher
 	connection.messageSendChannel = make(chan []byte, 5)
+	connection.closed = make(chan struct{})
+	connection.lastEventIDs = lastEventIDs
+}
+
+// IsConnected - If connection is still alive
+func (connection *SSEConnection) IsConnected() bool {
+	return !connection.isClosed.Load()
 }
 
 // Send - Enqueue message into channel
 func (connection *SSEConnection) Send(payload []byte) {
-	connection.messageSendChannel <- payload
+	select {
+	case connection.messageSendChannel <- payload:
+	case <-connection.closed:
+	}
 }
 
 // SendText - Enqueue message into channel
 func (connection *SSEConnection) SendText(payload []byte) {
-	connection.messageSendChannel <- payload
+	connection.Send(payload)
 }
 
 // SetOnMessage - In this type of connection the callback won't do anything
@@ -34,10 +70,212 @@ func (connection *SSEConnection) SetOnMessage(cb func([]byte)) {
 
 // SetOnClose - Callback for when connection closes
 func (connection *SSEConnection) SetOnClose(cb func()) {
+	connection.onCloseCB = cb
+}
 
+// SetOnHeartBeat - Callback for when a heartbeat was sent
+func (connection *SSEConnection) SetOnHeartBeat(cb func()) {
+	connection.onHB = cb
 }
 
 // Close - Close the current connection
 func (connection *SSEConnection) Close() {
-	connection.isClosed = true
+	connection.close(nil)
+}
+
+// CloseWithStatus - Send a close event with the given status, then close the connection
+func (connection *SSEConnection) CloseWithStatus(code uint16, reason string) {
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+	data, err := json.Marshal(map[string]interface{}{
+		"code":   code,
+		"reason": reason,
+	})
+
+	if err != nil {
+		core.Logger().WithError(err).Error("SSE Connection: failed to marshal close event")
+		connection.Close()
+		return
+	}
+
+	connection.close(formatSSEvent("", "close", data))
+}
+
+// close - Close the connection only once, the close event is written by Serve
+func (connection *SSEConnection) close(closeEvent []byte) {
+	connection.closeOnce.Do(func() {
+		connection.closeEvent = closeEvent
+		connection.isClosed.Store(true)
+		close(connection.closed)
+
+		if connection.onCloseCB != nil {
+			connection.onCloseCB()
+		}
+	})
+}
+
+// Serve - Write events and heartbeats into the stream until the client leaves or the connection is closed
+// It must be called from the request handler, since the response can't be written after it returns
+func (connection *SSEConnection) Serve(done <-chan struct{}) {
+	ticker := time.NewTicker(sseHeartBeatPeriod)
+
+	defer func() {
+		ticker.Stop()
+		connection.Close()
+	}()
+
+	for {
+		select {
+		case data := <-connection.messageSendChannel:
+			if err := connection.writeEvent(data); err != nil {
+				core.Logger().WithError(err).Error("SSE Connection: error writing event")
+				return
+			}
+		case <-ticker.C:
+			if err := connection.write(sseHeartBeat); err != nil {
+				core.Logger().WithError(err).Error("SSE Connection: error writing heartbeat")
+				return
+			}
+
+			if connection.onHB != nil {
+				connection.onHB()
+			}
+		case <-connection.closed:
+			if connection.closeEvent != nil {
+				if err := connection.write(connection.closeEvent); err != nil {
+					core.Logger().WithError(err).Error("SSE Connection: error writing close event")
+				}
+			}
+
+			return
+		case <-done:
+			return
+		}
+	}
+}
+
+func (connection *SSEConnection) write(data []byte) error {
+	if _, err := connection.writer.Write(data); err != nil {
+		return err
+	}
+
+	connection.flusher.Flush()
+
+	return nil
+}
+
+// writeEvent - Convert the session event into an SSE event and write it
+// Events that only make sense for bidirectional connections are ignored
+func (connection *SSEConnection) writeEvent(data []byte) error {
+	var newEvent core.NewEvent
+
+	if err := newEvent.Unmarshal(data); err != nil {
+		core.Logger().WithError(err).Error("SSE Connection: failed to unmarshal event")
+		return nil
+	}
+
+	payload, err := core.DecodeNewEventPayload(&newEvent)
+
+	if err != nil {
+		core.Logger().Errorf("SSE Connection: failed to decode %s event: %v", newEvent.Type, err)
+		return nil
+	}
+
+	var id string
+
+	switch newEvent.Type {
+	case core.NewEvent_ACK:
+		return nil
+	case core.NewEvent_NEW_CHANNEL, core.NewEvent_REMOVE_CHANNEL:
+		payload = map[string]interface{}{
+			"channelID": payload,
+		}
+	}
+
+	// Events without ID aren't stored, so they can't be resumed from
+	// Edited events keep their ID, so they don't move the resume position
+	if channelEvent, isOK := payload.(*core.ChannelEvent); isOK && channelEvent.ID != 0 && newEvent.Type == core.NewEvent_PUBLISH {
+		connection.lastEventIDs[channelEvent.ChannelID] = channelEvent.ID
+		id = formatLastEventIDs(connection.lastEventIDs)
+	}
+
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+	eventData, err := json.Marshal(payload)
+
+	if err != nil {
+		core.Logger().WithError(err).Errorf("SSE Connection: failed to marshal %s event", newEvent.Type)
+		return nil
+	}
+
+	return connection.write(formatSSEvent(id, strings.ToLower(newEvent.Type.String()), eventData))
+}
+
+// formatSSEvent - Format event in the text/event-stream format, data must not contain new lines
+func formatSSEvent(id string, event string, data []byte) []byte {
+	var buffer bytes.Buffer
+
+	if id != "" {
+		buffer.WriteString("id: ")
+		buffer.WriteString(id)
+		buffer.WriteByte('\n')
+	}
+
+	buffer.WriteString("event: ")
+	buffer.WriteString(event)
+	buffer.WriteString("\ndata: ")
+	buffer.Write(data)
+	buffer.WriteString("\n\n")
+
+	return buffer.Bytes()
+}
+
+// formatLastEventIDs - Format the last event ID of each channel as "channelID:eventID,channelID:eventID"
+func formatLastEventIDs(lastEventIDs map[string]uint64) string {
+	ids := make([]string, 0, len(lastEventIDs))
+
+	for channelID, eventID := range lastEventIDs {
+		if eventID != 0 {
+			ids = append(ids, channelID+":"+strconv.FormatUint(eventID, 10))
+		}
+	}
+
+	sort.Strings(ids)
+
+	return strings.Join(ids, ",")
+}
+
+// parseLastEventIDs - Parse the Last-Event-ID sent by the client for the given channels
+// A single number is used as the last event ID of every channel
+func parseLastEventIDs(value string, channelIDs []string) map[string]uint64 {
+	lastEventIDs := make(map[string]uint64, len(channelIDs))
+
+	if value == "" {
+		return lastEventIDs
+	}
+
+	if eventID, err := strconv.ParseUint(value, 10, 64); err == nil {
+		for _, channelID := range channelIDs {
+			lastEventIDs[channelID] = eventID
+		}
+
+		return lastEventIDs
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		index := strings.LastIndex(pair, ":")
+
+		if index <= 0 {
+			continue
+		}
+
+		eventID, err := strconv.ParseUint(pair[index+1:], 10, 64)
+
+		if err != nil {
+			continue
+		}
+
+		lastEventIDs[pair[:index]] = eventID
+	}
+
+	return lastEventIDs
 }
@@ -1,76 +1,128 @@
 package connection
 
-/*
-// SseHandler - Handle for SSE subscribe
-func SseHandler(context *gin.Context) {
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lisomatrix/channels/channels/core"
+)
+
+// SSEHandler - Server Sent Events handler, for read only clients that can't use WebSockets
+// Channels to subscribe are given as query params, e.g. /sse?channel=123&channel=321
+// GET /sse
+func SSEHandler(context *gin.Context) {
 	request := context.Request
-	rw := context.Writer
+	writer := context.Writer
+
+	// Server is shutting down, client should reconnect to another one
+	if core.GetEngine().IsShuttingDown() {
+		writer.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
 
-	channel := context.Params.ByName("channel")
+	// Get token and AppID headers
+	token := request.Header.Get("Authorization")
 	appID := request.Header.Get("AppID")
-	userID := request.Header.Get("UserID")
+	deviceID := request.Header.Get("DeviceID")
+	lastEventID := request.Header.Get("Last-Event-ID")
 
-	if channel == "" || appID == "" || userID == "" {
-		rw.WriteHeader(http.StatusBadRequest)
-		return
+	queryValues := request.URL.Query()
+
+	// EventSource can't set headers, so they come as query params
+	if token == "" {
+		token = queryValues.Get("Authorization")
 	}
 
-	// Check if client supports streaming data
-	_, ok := rw.(http.Flusher)
+	if appID == "" {
+		appID = queryValues.Get("AppID")
+	}
 
-	if !ok {
-		http.Error(rw, "Streaming unsupported!", http.StatusInternalServerError)
-		return
+	if deviceID == "" {
+		deviceID = queryValues.Get("DeviceID")
 	}
 
-	rw.Header().Set("Content-Type", "text/event-stream")
-	rw.Header().Set("Cache-Control", "no-cache")
-	rw.Header().Set("Connection", "keep-alive")
+	// The header is only sent by the browser when reconnecting
+	if lastEventID == "" {
+		lastEventID = queryValues.Get("lastEventID")
+	}
 
-	// Initialize connection and session handler
-	var connection *SSEConnection = new(SSEConnection)
-	var session *core.Session = new(core.Session)
+	// Single use ticket from POST /ticket, used in place of the token
+	ticket := queryValues.Get("ticket")
 
-	client := core.GetAppStorage().LoadClient(userID)
+	identity, deviceID, isOK := authenticateConnection(token, ticket, appID, deviceID, request)
 
-	identity := &auth.Identity{
-		AppID:    appID,
-		ClientID: userID,
+	if !isOK {
+		writer.WriteHeader(http.StatusUnauthorized)
+		return
 	}
 
-	//client.accountID = tokenData.accountID
-	//client.deviceID = tokenData.deviceID
+	// With a ticket the AppID is only known now
+	if !core.IsOriginAllowed(request.Header.Get("Origin"), identity.AppID) {
+		writer.WriteHeader(http.StatusForbidden)
+		return
+	}
 
-	hub := core.GetHubsHandler().GetHub(identity.AppID)
+	channelIDs := queryValues["channel"]
 
-	connection.Init()
-	session.Init(connection, identity, client, hub)
+	if len(channelIDs) == 0 {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
 
-	hub.AddClient(session)
+	hub := core.GetEngine().GetHubsHandler().GetHub(identity.AppID)
 
-	// Subscribe to channel
-	session.CanSubscribe(channel)
+	// Client has too many connections open
+	if !hub.CanAddClient(identity.ClientID, deviceID) {
+		writer.WriteHeader(http.StatusTooManyRequests)
+		return
+	}
 
-	// Listen for when clients closes
-	notify := request.Context().Done()
+	// Check if client supports streaming data
+	flusher, isOK := writer.(http.Flusher)
 
-	go func() {
-		<-notify
+	if !isOK {
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	lastEventIDs := parseLastEventIDs(lastEventID, channelIDs)
+
+	// Start session handler
+	var connection = new(SSEConnection)
+	var session = new(core.Session)
+
+	connection.Init(writer, flusher, lastEventIDs)
+	session.Init(connection, deviceID, identity, identity.ClientID, hub)
+
+	// Another connection of the client may have taken the last slot since CanAddClient
+	if !hub.AddClient(session) {
 		session.Close()
-	}()
+		writer.WriteHeader(http.StatusTooManyRequests)
+		return
+	}
 
-	// Stream data into client
-	context.Stream(func(w io.Writer) bool {
+	writer.Header().Set("Content-Type", "text/event-stream")
+	writer.Header().Set("Cache-Control", "no-cache")
+	writer.Header().Set("Connection", "keep-alive")
+	// Don't let nginx buffer the stream
+	writer.Header().Set("X-Accel-Buffering", "no")
+	writer.WriteHeader(http.StatusOK)
+	flusher.Flush()
 
-		if msg, ok := <-connection.messageSendChannel; ok {
-			context.SSEvent("message", msg)
+	// Subscribe while the stream is being served, so missed events being replayed don't block
+	go func() {
+		subscribed := 0
 
-			if !connection.isClosed {
-				return true
+		for _, channelID := range channelIDs {
+			if didSubscribe, _ := session.CanSubscribe(channelID, lastEventIDs[channelID]); didSubscribe {
+				subscribed++
 			}
 		}
 
-		return false
-	})
+		if subscribed == 0 {
+			session.CloseWithStatus(core.CloseStatusNormal, "no channel could be subscribed")
+		}
+	}()
+
+	connection.Serve(request.Context().Done())
 }
-*/
@@ -0,0 +1,84 @@
+package connection
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/lisomatrix/channels/channels/auth"
+	"github.com/lisomatrix/channels/channels/core"
+	log "github.com/sirupsen/logrus"
+)
+
+type ticketResponse struct {
+	Ticket    string `json:"ticket"`
+	ExpiresAt int64  `json:"expiresAt"`
+}
+
+// PostTicketHandler - Issue a single use connection ticket for the token client, AppID and DeviceID
+// Browsers can connect with ?ticket= so the token doesn't end up in URLs and access logs
+// POST /ticket
+func PostTicketHandler(context *gin.Context) {
+	request := context.Request
+	writer := context.Writer
+
+	// Check for required headers
+	token, appID, isOK := auth.GetAuthData(request)
+
+	if !isOK {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	deviceID := request.Header.Get("DeviceID")
+
+	identity, isOK := authenticate(token, appID, deviceID, request)
+
+	if !isOK {
+		writer.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	ticketID, ticket, isOK := core.CreateConnectionTicket(identity, appID, deviceID)
+
+	if !isOK {
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+	data, err := json.Marshal(ticketResponse{Ticket: ticketID, ExpiresAt: ticket.ExpiresAt})
+
+	if err != nil {
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ClientID": identity.ClientID}).WithError(err).Error("HTTP Post ticket: failed to marshal response")
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	_, _ = writer.Write(data)
+}
+
+// authenticateConnection - Authenticate with the connection ticket if there is one, otherwise with the token
+// Returns the identity and the device ID, taken from the ticket when not given
+func authenticateConnection(token string, ticket string, appID string, deviceID string, request *http.Request) (*auth.Identity, string, bool) {
+	if ticket != "" {
+		identity, deviceID, isOK := core.UseConnectionTicket(ticket, appID, deviceID)
+
+		// The client may have been revoked after the ticket was issued
+		if !isOK || core.IsRevoked(identity, identity.AppID, deviceID) {
+			return nil, "", false
+		}
+
+		return identity, deviceID, true
+	}
+
+	// Without ticket the AppID and token are required
+	if appID == "" || token == "" {
+		return nil, "", false
+	}
+
+	identity, isOK := authenticate(token, appID, deviceID, request)
+
+	return identity, deviceID, isOK
+}
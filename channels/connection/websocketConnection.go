@@ -1,12 +1,10 @@
 package connection
 
 import (
-	"fmt"
-	"log"
-	"os"
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/lisomatrix/channels/channels/core"
 )
 
 const (
@@ -96,6 +94,21 @@ func (connection *WebSocketConnection) Close() {
 	}
 }
 
+// CloseWithStatus - Send a close message with the given status, then close the connection
+func (connection *WebSocketConnection) CloseWithStatus(code uint16, reason string) {
+	if connection.isClosed {
+		return
+	}
+
+	message := websocket.FormatCloseMessage(int(code), reason)
+
+	if err := connection.ws.WriteControl(websocket.CloseMessage, message, time.Now().Add(writeWait)); err != nil {
+		core.Logger().WithError(err).Error("Error writing close message")
+	}
+
+	connection.Close()
+}
+
 func (connection *WebSocketConnection) write(mt int, payload []byte) error {
 	_ = connection.ws.SetWriteDeadline(time.Now().Add(writeWait))
 	return connection.ws.WriteMessage(mt, payload)
@@ -124,9 +137,10 @@ func (connection *WebSocketConnection) readMessages() {
 
 		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway) {
-				log.Printf("error: %v", err)
+				core.Logger().WithError(err).Error("WebSocket Connection: unexpected close")
+			} else {
+				core.Logger().WithError(err).Debug("WebSocket Connection: read error")
 			}
-			log.Printf("error: %v", err)
 			break
 		}
 
@@ -160,7 +174,7 @@ func (connection *WebSocketConnection) writeMessages() {
 				// If send channel has closed then close websocket connection
 				if !ok {
 					if err := connection.write(websocket.CloseMessage, []byte{}); err != nil {
-						_, _ = fmt.Fprintf(os.Stderr, "Error writing close message: %v\n", err)
+						core.Logger().WithError(err).Error("Error writing close message")
 					}
 
 					return
@@ -169,12 +183,12 @@ func (connection *WebSocketConnection) writeMessages() {
 				w, err := connection.ws.NextWriter(websocket.TextMessage)
 
 				if err != nil {
-					_, _ = fmt.Fprintf(os.Stderr, "Preparing to write message: %v\n", err)
+					core.Logger().WithError(err).Error("Preparing to write message")
 					return
 				}
 
 				if _, err = w.Write(message.payload); err != nil {
-					_, _ = fmt.Fprintf(os.Stderr, "Error writing payload: %v\n", err)
+					core.Logger().WithError(err).Error("Error writing payload")
 				}
 
 				n := len(connection.messageSendChannel)
@@ -183,12 +197,12 @@ func (connection *WebSocketConnection) writeMessages() {
 					_, err = w.Write((<-connection.messageSendChannel).payload)
 
 					if err != nil {
-						_, _ = fmt.Fprintf(os.Stderr, "Error writing payload: %v\n", err)
+						core.Logger().WithError(err).Error("Error writing payload")
 					}
 				}
 
 				if err := w.Close(); err != nil {
-					_, _ = fmt.Fprintf(os.Stderr, "Error closing connection: %v\n", err)
+					core.Logger().WithError(err).Error("Error closing connection")
 					return
 				}
 
@@ -208,12 +222,12 @@ func (connection *WebSocketConnection) writeMessages() {
 				err := connection.ws.SetWriteDeadline(time.Now().Add(writeWait))
 
 				if err != nil {
-					_, _ = fmt.Fprintf(os.Stderr, "Error setting ping deadline: %v\n", err)
+					core.Logger().WithError(err).Error("Error setting ping deadline")
 				}
 
 				// If we can't send a ping then it probably is closed
 				if err := connection.write(websocket.PingMessage, []byte{}); err != nil {
-					_, _ = fmt.Fprintf(os.Stderr, "Error writing ping message: %v\n", err)
+					core.Logger().WithError(err).Error("Error writing ping message")
 					return
 				}
 			}
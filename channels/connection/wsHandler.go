@@ -1,7 +1,6 @@
 package connection
 
 import (
-	"log"
 	"net/http"
 
 	"github.com/lisomatrix/channels/channels/auth"
@@ -11,14 +10,38 @@ import (
 	"github.com/gorilla/websocket"
 
 	"github.com/gobwas/ws"
+	log "github.com/sirupsen/logrus"
 )
 
+const (
+	// ProtobufSubprotocol - Events are sent as protobuf binary frames, the default
+	ProtobufSubprotocol = "channels.protobuf"
+	// JSONSubprotocol - Events are sent as JSON text frames
+	JSONSubprotocol = "channels.json"
+)
+
+// optimizedUpgrader - Accepts the encoding subprotocols, clients that can't set them can use the encoding query param
+var optimizedUpgrader = ws.HTTPUpgrader{
+	Protocol: func(protocol string) bool {
+		return protocol == ProtobufSubprotocol || protocol == JSONSubprotocol
+	},
+}
+
 var upgrader = websocket.Upgrader{
 	ReadBufferSize:  1024,
 	WriteBufferSize: 1024,
-	CheckOrigin: func(r *http.Request) bool {
-		return true
-	},
+	CheckOrigin:     checkOrigin,
+}
+
+// checkOrigin - Only browsers from the allowed origins of the AppID can open WebSockets
+func checkOrigin(request *http.Request) bool {
+	appID := request.Header.Get("AppID")
+
+	if appID == "" {
+		appID = request.URL.Query().Get("AppID")
+	}
+
+	return core.IsOriginAllowed(request.Header.Get("Origin"), appID)
 }
 
 // RequestHandler - Default WebSocket handler
@@ -26,6 +49,12 @@ func RequestHandler(context *gin.Context) {
 	request := context.Request
 	writer := context.Writer
 
+	// Server is shutting down, client should reconnect to another one
+	if core.GetEngine().IsShuttingDown() {
+		writer.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
 	// Get token and AppID headers
 	token := request.Header.Get("Authorization")
 	appID := request.Header.Get("AppID")
@@ -55,17 +84,25 @@ func RequestHandler(context *gin.Context) {
 
 	identity, isOK := auth.VerifyToken(token)
 
-	if !isOK || !identity.CanUseAppID(appID) {
+	if !isOK || !identity.CanUseAppID(appID) || core.IsRevoked(&identity, appID, deviceID) {
 		writer.WriteHeader(http.StatusUnauthorized)
 		return
 	}
 
+	hub := core.GetEngine().GetHubsHandler().GetHub(identity.AppID)
+
+	// Client has too many connections open
+	if !hub.CanAddClient(identity.ClientID, deviceID) {
+		writer.WriteHeader(http.StatusTooManyRequests)
+		return
+	}
+
 	// Upgrade to WebSocket
 	conn, err := upgrader.Upgrade(writer, request, nil)
 	//conn, _, _, err := ws.UpgradeHTTP(request, writer)
 
 	if err != nil {
-		log.Println("upgrade:", err)
+		core.Logger().WithFields(log.Fields{"AppID": identity.AppID, "ClientID": identity.ClientID, "DeviceID": deviceID}).WithError(err).Error("WS Handler: upgrade failed")
 		return
 	}
 
@@ -81,7 +118,7 @@ func RequestHandler(context *gin.Context) {
 			c, err := core.GetEngine().GetClientRepository().GetAppClient(appID, identity.ClientID)
 
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "WS Handler: Failed to get client data: %v\n", err)
+				core.Logger().WithFields(log.Fields{"AppID": appID, "DeviceID": deviceID}).WithError(err).Error("WS Handler: Failed to get client data")
 				writer.WriteHeader(http.StatusInternalServerError)
 				return
 			}
@@ -89,12 +126,13 @@ func RequestHandler(context *gin.Context) {
 			client = c
 		}*/
 
-	hub := core.GetEngine().GetHubsHandler().GetHub(identity.AppID)
-
 	connection.Init(conn)
 	session.Init(connection, deviceID, &identity, identity.ClientID, hub)
 
-	hub.AddClient(session)
+	// Another connection of the client may have taken the last slot since CanAddClient
+	if !hub.AddClient(session) {
+		session.CloseWithStatus(core.CloseStatusPolicy, "too many connections")
+	}
 }
 
 // OptimizedRequestHandler - Optimized version of WebSocket handshake
@@ -102,6 +140,12 @@ func OptimizedRequestHandler(context *gin.Context) {
 	request := context.Request
 	writer := context.Writer
 
+	// Server is shutting down, client should reconnect to another one
+	if core.GetEngine().IsShuttingDown() {
+		writer.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
 	// Get token and AppID headers
 	token := request.Header.Get("Authorization")
 	appID := request.Header.Get("AppID")
@@ -122,53 +166,69 @@ func OptimizedRequestHandler(context *gin.Context) {
 		deviceID = queryValues.Get("DeviceID")
 	}
 
-	// If in neither the headers or query params it was found
-	// Then the request is malformed
-	if appID == "" || token == "" {
+	// Single use ticket from POST /ticket, used in place of the token
+	ticket := queryValues.Get("ticket")
+
+	identity, deviceID, isOK := authenticateConnection(token, ticket, appID, deviceID, request)
+
+	if !isOK {
 		writer.WriteHeader(http.StatusUnauthorized)
 		return
 	}
 
-	authHook := core.GetEngine().GetAuthHook()
-
-	var identity *auth.Identity = nil
-
-	if authHook != nil {
-		iden := authHook.Authenticate(token, appID, deviceID, request)
-
-		if iden != nil {
-			identity = iden
-		}
+	// The upgrader doesn't check origins, and with a ticket the AppID is only known now
+	if !core.IsOriginAllowed(request.Header.Get("Origin"), identity.AppID) {
+		writer.WriteHeader(http.StatusForbidden)
+		return
 	}
 
-	if identity == nil {
-		iden, isOK := auth.VerifyToken(token)
+	hub := core.GetEngine().GetHubsHandler().GetHub(identity.AppID)
 
-		if !isOK || !iden.CanUseAppID(appID) {
-			writer.WriteHeader(http.StatusUnauthorized)
-			return
-		} else {
-			identity = &iden
-		}
+	// Client has too many connections open
+	if !hub.CanAddClient(identity.ClientID, deviceID) {
+		writer.WriteHeader(http.StatusTooManyRequests)
+		return
 	}
 
 	// Start session handler
 	var connection = new(OWebSocketConnection)
 	var session = new(core.Session)
 
-	hub := core.GetEngine().GetHubsHandler().GetHub(identity.AppID)
-
-	session.Init(connection, deviceID, identity, identity.ClientID, hub)
-
 	// Upgrade to WebSocket
-	conn, _, _, err := ws.UpgradeHTTP(request, writer)
+	conn, _, handshake, err := optimizedUpgrader.Upgrade(request, writer)
 
 	if err != nil {
-		log.Println("upgrade:", err)
+		core.Logger().WithFields(log.Fields{"AppID": identity.AppID, "ClientID": identity.ClientID, "DeviceID": deviceID}).WithError(err).Error("WS Handler: upgrade failed")
 		return
 	}
 
-	connection.Init(conn)
+	isJSON := handshake.Protocol == JSONSubprotocol || queryValues.Get("encoding") == "json"
+
+	connection.Init(conn, isJSON)
+	session.Init(connection, deviceID, identity, identity.ClientID, hub)
+
+	// Another connection of the client may have taken the last slot since CanAddClient
+	if !hub.AddClient(session) {
+		session.CloseWithStatus(core.CloseStatusPolicy, "too many connections")
+	}
+}
+
+// authenticate - Ask the auth hook for the identity, if there isn't one or it doesn't know the token verify it
+// Revoked clients are refused, hooks should set the identity IssuedAt so tokens issued after a revocation are accepted
+func authenticate(token string, appID string, deviceID string, request *http.Request) (*auth.Identity, bool) {
+	authHook := core.GetEngine().GetAuthHook()
+
+	if authHook != nil {
+		if identity := authHook.Authenticate(token, appID, deviceID, request); identity != nil {
+			return identity, !core.IsRevoked(identity, appID, deviceID)
+		}
+	}
+
+	identity, isOK := auth.VerifyToken(token)
+
+	if !isOK || !identity.CanUseAppID(appID) || core.IsRevoked(&identity, appID, deviceID) {
+		return nil, false
+	}
 
-	hub.AddClient(session)
+	return &identity, true
 }
@@ -0,0 +1,248 @@
+package core
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lisomatrix/channels/channels/auth"
+	"github.com/rs/xid"
+	log "github.com/sirupsen/logrus"
+)
+
+// API key scopes, what the handlers accept the key for
+const (
+	ScopePublish        = "publish"         // Publish, edit and delete events
+	ScopeManageChannels = "manage-channels" // Create, update, close, delete channels, their members and event rules
+	ScopeManageClients  = "manage-clients"  // Create, update, delete and get clients
+	ScopeReadHistory    = "read-history"    // Get channel events, read receipts and members
+)
+
+// APIKeyPrefix - Start of every API key, to tell them apart from tokens
+const APIKeyPrefix = "chk_"
+
+// MaxAPIKeyNameLength - Max length of an API key name, as stored in the database
+const MaxAPIKeyNameLength = 100
+
+// ErrInvalidAPIKey - The API key has no scopes, an unknown one or a too long name
+var ErrInvalidAPIKey = errors.New("invalid API key")
+
+var apiKeyScopes = []string{ScopePublish, ScopeManageChannels, ScopeManageClients, ScopeReadHistory}
+
+var apiKeyCache sync.Map //[string(keyHash)]cachedAPIKey
+
+// cachedAPIKey - Key found by its hash, used until expiresAt
+type cachedAPIKey struct {
+	key       *APIKey
+	expiresAt time.Time
+}
+
+// HasScope - Check if the key can be used for the scope
+func (key *APIKey) HasScope(scope string) bool {
+	for _, keyScope := range key.Scopes {
+		if keyScope == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsAPIKey - Check if the Authorization value is an API key instead of a token
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, APIKeyPrefix)
+}
+
+func hashAPIKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+// validateAPIKeyScopes - Check the scopes are known and remove repeated ones
+func validateAPIKeyScopes(scopes []string) ([]string, error) {
+	if len(scopes) == 0 {
+		return nil, fmt.Errorf("%w: at least one scope is required", ErrInvalidAPIKey)
+	}
+
+	valid := make([]string, 0, len(scopes))
+
+	for _, scope := range scopes {
+		isKnown := false
+
+		for _, known := range apiKeyScopes {
+			if scope == known {
+				isKnown = true
+				break
+			}
+		}
+
+		if !isKnown {
+			return nil, fmt.Errorf("%w: unknown scope %s", ErrInvalidAPIKey, scope)
+		}
+
+		isRepeated := false
+
+		for _, added := range valid {
+			if added == scope {
+				isRepeated = true
+				break
+			}
+		}
+
+		if !isRepeated {
+			valid = append(valid, scope)
+		}
+	}
+
+	return valid, nil
+}
+
+// CreateAPIKey - Create an API key for the app with the given scopes
+// Returns the stored key and the key itself, which can't be retrieved again since only its hash is stored
+// Returns a ErrInvalidAPIKey error if the scopes or name aren't valid
+func CreateAPIKey(appID string, name string, scopes []string) (*APIKey, string, error) {
+	scopes, err := validateAPIKeyScopes(scopes)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(name) > MaxAPIKeyNameLength {
+		return nil, "", fmt.Errorf("%w: name must have up to %d characters", ErrInvalidAPIKey, MaxAPIKeyNameLength)
+	}
+
+	secret := make([]byte, 32)
+
+	if _, err := rand.Read(secret); err != nil {
+		logger.WithField("AppID", appID).WithError(err).Error("Create API key: failed to generate key")
+		return nil, "", err
+	}
+
+	key := APIKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	apiKey := &APIKey{
+		ID:        xid.New().String(),
+		AppID:     appID,
+		Name:      name,
+		KeyHash:   hashAPIKey(key),
+		Scopes:    scopes,
+		CreatedAt: time.Now().Unix(),
+	}
+
+	if err := GetEngine().GetAPIKeyRepository().CreateAPIKey(apiKey); err != nil {
+		logger.WithField("AppID", appID).WithError(err).Error("Create API key: failed to store key")
+		return nil, "", err
+	}
+
+	return apiKey, key, nil
+}
+
+// GetAppAPIKeys - Get the keys of the app
+func GetAppAPIKeys(appID string) ([]*APIKey, error) {
+	keys, err := GetEngine().GetAPIKeyRepository().GetAppAPIKeys(appID)
+
+	if err != nil {
+		logger.WithField("AppID", appID).WithError(err).Error("Get API keys: failed to get keys")
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// DeleteAPIKey - Revoke the app key, returns false if the app doesn't have it
+func DeleteAPIKey(appID string, keyID string) (bool, error) {
+	deleted, err := GetEngine().GetAPIKeyRepository().DeleteAPIKey(appID, keyID)
+
+	if err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "KeyID": keyID}).WithError(err).Error("Delete API key: failed to delete key")
+		return false, err
+	}
+
+	forgetAPIKey(keyID)
+
+	return deleted, nil
+}
+
+// getAPIKeyByHash - Get the key from the local cache, or from database caching it for APIKeyCacheTTL
+// Keys that don't exist aren't cached, so random keys can't fill the cache
+func getAPIKeyByHash(keyHash string) (*APIKey, error) {
+	if value, isOK := apiKeyCache.Load(keyHash); isOK {
+		cached := value.(cachedAPIKey)
+
+		if time.Now().Before(cached.expiresAt) {
+			return cached.key, nil
+		}
+
+		apiKeyCache.Delete(keyHash)
+	}
+
+	apiKey, err := GetEngine().GetAPIKeyRepository().GetAPIKeyByHash(keyHash)
+
+	if err != nil || apiKey == nil {
+		return nil, err
+	}
+
+	apiKeyCache.Store(keyHash, cachedAPIKey{key: apiKey, expiresAt: time.Now().Add(APIKeyCacheTTL)})
+
+	return apiKey, nil
+}
+
+// forgetAPIKey - Drop the key from the local cache, other servers drop it once it expires
+func forgetAPIKey(keyID string) {
+	apiKeyCache.Range(func(key interface{}, value interface{}) bool {
+		if value.(cachedAPIKey).key.ID == keyID {
+			apiKeyCache.Delete(key)
+		}
+
+		return true
+	})
+}
+
+// AuthenticateAPIKey - Check the key exists and has the scope
+// Keys are cached for APIKeyCacheTTL, so a deleted key can still be used on other servers until then
+// Returns an Admin identity of the key app, with the key ID as ClientID
+func AuthenticateAPIKey(key string, scope string) (*auth.Identity, bool) {
+	apiKey, err := getAPIKeyByHash(hashAPIKey(key))
+
+	if err != nil {
+		logger.WithError(err).Error("Authenticate API key: failed to get key")
+		return nil, false
+	}
+
+	if apiKey == nil || !apiKey.HasScope(scope) {
+		return nil, false
+	}
+
+	return &auth.Identity{Role: auth.AdminRole, AppID: apiKey.AppID, ClientID: apiKey.ID}, true
+}
+
+// authenticateAdmin - Check the token is of an admin kind, or is an API key with the scope
+// An empty scope only accepts tokens
+func authenticateAdmin(token string, scope string) (*auth.Identity, bool) {
+	if IsAPIKey(token) {
+		return AuthenticateAPIKey(token, scope)
+	}
+
+	return auth.AuthenticateAdmin(token)
+}
+
+// verifyToken - Check the token, or that it is an API key with the scope
+func verifyToken(token string, scope string) (auth.Identity, bool) {
+	if !IsAPIKey(token) {
+		return auth.VerifyToken(token)
+	}
+
+	identity, isOK := AuthenticateAPIKey(token, scope)
+
+	if !isOK {
+		return auth.Identity{}, false
+	}
+
+	return *identity, true
+}
@@ -0,0 +1,160 @@
+package core
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	jsoniter "github.com/json-iterator/go"
+)
+
+type createAPIKeyRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+type apiKeyJSON struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Scopes    []string `json:"scopes"`
+	CreatedAt int64    `json:"createdAt"`
+	Key       string   `json:"key,omitempty"` // Only sent when the key is created
+}
+
+type getAPIKeysResponse struct {
+	Keys []*apiKeyJSON `json:"keys"`
+}
+
+func newAPIKeyJSON(key *APIKey) *apiKeyJSON {
+	return &apiKeyJSON{
+		ID:        key.ID,
+		Name:      key.Name,
+		Scopes:    key.Scopes,
+		CreatedAt: key.CreatedAt,
+	}
+}
+
+// PostAPIKeyHandler - Create an API key for the app, the key is only sent in this response
+// API keys can't manage API keys, so a token is required
+// POST /app/:appID/keys
+func PostAPIKeyHandler(context *gin.Context) {
+	writer := context.Writer
+
+	appID, isOK := authorizeAppAdmin(context, "")
+
+	if !isOK {
+		return
+	}
+
+	body, err := ioutil.ReadAll(context.Request.Body)
+
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var keyRequest createAPIKeyRequest
+
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+	if err := json.Unmarshal(body, &keyRequest); err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	apiKey, key, err := CreateAPIKey(appID, keyRequest.Name, keyRequest.Scopes)
+
+	if errors.Is(err, ErrInvalidAPIKey) {
+		writeRejected(writer, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	response := newAPIKeyJSON(apiKey)
+	response.Key = key
+
+	data, err := json.Marshal(response)
+
+	if err != nil {
+		logger.WithField("AppID", appID).WithError(err).Error("HTTP Post API key: failed to marshal response")
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	writer.WriteHeader(http.StatusCreated)
+	_, _ = writer.Write(data)
+}
+
+// GetAPIKeysHandler - Get the API keys of the app, without the keys themselves
+// GET /app/:appID/keys
+func GetAPIKeysHandler(context *gin.Context) {
+	writer := context.Writer
+
+	appID, isOK := authorizeAppAdmin(context, "")
+
+	if !isOK {
+		return
+	}
+
+	keys, err := GetAppAPIKeys(appID)
+
+	if err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	response := getAPIKeysResponse{Keys: make([]*apiKeyJSON, 0, len(keys))}
+
+	for _, key := range keys {
+		response.Keys = append(response.Keys, newAPIKeyJSON(key))
+	}
+
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+	data, err := json.Marshal(response)
+
+	if err != nil {
+		logger.WithField("AppID", appID).WithError(err).Error("HTTP Get API keys: failed to marshal response")
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	_, _ = writer.Write(data)
+}
+
+// DeleteAPIKeyHandler - Revoke an API key of the app
+// DELETE /app/:appID/keys/:keyID
+func DeleteAPIKeyHandler(context *gin.Context) {
+	writer := context.Writer
+
+	appID, isOK := authorizeAppAdmin(context, "")
+
+	if !isOK {
+		return
+	}
+
+	keyID := context.Params.ByName("keyID")
+
+	if keyID == "" {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	deleted, err := DeleteAPIKey(appID, keyID)
+
+	if err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !deleted {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	writer.WriteHeader(http.StatusOK)
+}
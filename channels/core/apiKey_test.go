@@ -0,0 +1,51 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestValidateAPIKeyScopes(t *testing.T) {
+	scopes, err := validateAPIKeyScopes([]string{ScopePublish, ScopeReadHistory, ScopePublish})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(scopes) != 2 {
+		t.Errorf("Expected repeated scopes to be removed got %v", scopes)
+	}
+
+	key := &APIKey{Scopes: scopes}
+
+	if !key.HasScope(ScopePublish) || key.HasScope(ScopeManageClients) || key.HasScope("") {
+		t.Error("Unexpected key scopes")
+	}
+
+	if _, err := validateAPIKeyScopes(nil); !errors.Is(err, ErrInvalidAPIKey) {
+		t.Errorf("Expected invalid key error without scopes got %v", err)
+	}
+
+	if _, err := validateAPIKeyScopes([]string{"manage-apps"}); !errors.Is(err, ErrInvalidAPIKey) {
+		t.Errorf("Expected invalid key error with unknown scope got %v", err)
+	}
+}
+
+func TestForgetAPIKey(t *testing.T) {
+	expiresAt := time.Now().Add(time.Minute)
+
+	apiKeyCache.Store("hash", cachedAPIKey{key: &APIKey{ID: "key"}, expiresAt: expiresAt})
+	apiKeyCache.Store("other", cachedAPIKey{key: &APIKey{ID: "otherKey"}, expiresAt: expiresAt})
+	defer apiKeyCache.Delete("other")
+
+	forgetAPIKey("key")
+
+	if _, isOK := apiKeyCache.Load("hash"); isOK {
+		t.Error("Expected deleted key to be dropped from cache")
+	}
+
+	if _, isOK := apiKeyCache.Load("other"); !isOK {
+		t.Error("Expected other keys to stay cached")
+	}
+}
@@ -2,15 +2,11 @@
 package core
 
 import (
-	"fmt"
 	"io/ioutil"
 	"net/http"
-	"os"
 
 	jsoniter "github.com/json-iterator/go"
 
-	"github.com/lisomatrix/channels/channels/auth"
-
 	"github.com/gin-gonic/gin"
 )
 
@@ -24,7 +20,8 @@ type updateAppRequest struct {
 }
 
 type getAppsResponse struct {
-	Apps []*App
+	Apps       []*App
+	NextCursor string `json:"nextCursor,omitempty"`
 }
 
 // CreateApp - Create a new app
@@ -43,7 +40,8 @@ func CreateApp(context *gin.Context) {
 	}
 
 	// Check if is admin, and validate token
-	identity, isOK := auth.AuthenticateAdmin(token)
+	// API keys belong to a single app, so only tokens can manage apps
+	identity, isOK := authenticateAdmin(token, "")
 
 	// If not valid return
 	if !isOK || !identity.IsSuperAdmin() {
@@ -78,7 +76,7 @@ func CreateApp(context *gin.Context) {
 		app, err := GetEngine().GetAppRepository().GetApp(createAppRequest.AppID)
 
 		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "HTTP Create App: failed to check app existence %v\n", err)
+			logger.WithError(err).Error("HTTP Create App: failed to check app existence")
 			writer.WriteHeader(http.StatusInternalServerError)
 			return
 		}
@@ -96,7 +94,7 @@ func CreateApp(context *gin.Context) {
 	GetEngine().GetCacheStorage().StoreApp(createAppRequest.AppID, createAppRequest.Name)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Create App: failed to create app %v\n", err)
+		logger.WithError(err).Error("HTTP Create App: failed to create app")
 		writer.WriteHeader(http.StatusConflict)
 		return
 	}
@@ -120,7 +118,8 @@ func DeleteApp(context *gin.Context) {
 	}
 
 	// Check if is admin, and validate token
-	identity, isOK := auth.AuthenticateAdmin(token)
+	// API keys belong to a single app, so only tokens can manage apps
+	identity, isOK := authenticateAdmin(token, "")
 
 	// If not valid return
 	if !isOK || !identity.IsSuperAdmin() {
@@ -138,7 +137,7 @@ func DeleteApp(context *gin.Context) {
 	err := GetEngine().GetAppRepository().DeleteApp(appID)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Delete App: failed to delete app %v\n", err)
+		logger.WithField("AppID", appID).WithError(err).Error("HTTP Delete App: failed to delete app")
 		writer.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -164,7 +163,8 @@ func UpdateApp(context *gin.Context) {
 	}
 
 	// Check if is admin, and validate token
-	identity, isOK := auth.AuthenticateAdmin(token)
+	// API keys belong to a single app, so only tokens can manage apps
+	identity, isOK := authenticateAdmin(token, "")
 
 	// If not valid return
 	if !isOK {
@@ -213,7 +213,7 @@ func UpdateApp(context *gin.Context) {
 		app, err := GetEngine().GetAppRepository().GetApp(appID)
 
 		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "HTTP Update App: failed to check app existence %v\n", err)
+			logger.WithField("AppID", appID).WithError(err).Error("HTTP Update App: failed to check app existence")
 			writer.WriteHeader(http.StatusInternalServerError)
 			return
 		}
@@ -229,7 +229,7 @@ func UpdateApp(context *gin.Context) {
 	err = GetEngine().GetAppRepository().UpdateApp(appID, updateAppRequest.Name)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Update App: failed to update app %v\n", err)
+		logger.WithField("AppID", appID).WithError(err).Error("HTTP Update App: failed to update app")
 		writer.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -256,7 +256,8 @@ func GetApps(context *gin.Context) {
 	}
 
 	// Check if is admin, and validate token
-	identity, isOK := auth.AuthenticateAdmin(token)
+	// API keys belong to a single app, so only tokens can manage apps
+	identity, isOK := authenticateAdmin(token, "")
 
 	// If not valid return
 	if !isOK || !identity.IsSuperAdmin() {
@@ -264,15 +265,35 @@ func GetApps(context *gin.Context) {
 		return
 	}
 
-	// Get alls apps
-	apps, err := GetEngine().GetAppRepository().GetApps()
+	options, err := ParseListOptions(request.URL.Query())
+
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Fetch one more app to know if there is a next page
+	limit := options.Limit
+	options.Limit++
+
+	apps, err := GetEngine().GetAppRepository().GetAppsPage(options)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Get Apps: failed to get apps %v\n", err)
+		logger.WithError(err).Error("HTTP Get Apps: failed to get apps")
 		writer.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
+	response := getAppsResponse{Apps: apps}
+
+	if len(apps) > limit {
+		apps = apps[:limit]
+		last := apps[limit-1]
+
+		response.Apps = apps
+		response.NextCursor = EncodeListCursor(&ListCursor{ID: last.AppID, Name: last.Name})
+	}
+
 	// Since we fetched the apps, let use the opportunity to cache them
 	go func() {
 		for _, app := range apps {
@@ -280,13 +301,11 @@ func GetApps(context *gin.Context) {
 		}
 	}()
 
-	response := getAppsResponse{Apps: apps}
-
 	var json = jsoniter.ConfigCompatibleWithStandardLibrary
 	data, err := json.Marshal(response)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Get Apps: failed to marshal response %v\n", err)
+		logger.WithError(err).Error("HTTP Get Apps: failed to marshal response")
 		writer.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -2,14 +2,13 @@ package core
 
 import (
 	"errors"
-	"log"
 )
 
 func GetApplications() ([]*App, error) {
 	apps, err := GetEngine().GetAppRepository().GetApps()
 
 	if err != nil {
-		log.Println(err)
+		logger.Error(err)
 		return nil, err
 	}
 
@@ -47,7 +46,7 @@ func CreateApplication(appID, name string) error {
 	app, err := GetApplication(appID)
 
 	if err != nil {
-		log.Println(err)
+		logger.WithField("AppID", appID).Error(err)
 		return err
 	}
 
@@ -56,7 +55,7 @@ func CreateApplication(appID, name string) error {
 	}
 
 	if err := GetEngine().GetAppRepository().CreateApp(appID, name); err != nil {
-		log.Println(err)
+		logger.WithField("AppID", appID).Error(err)
 		return err
 	}
 
@@ -69,7 +68,7 @@ func CreateApplication(appID, name string) error {
 func DeleteApplication(appID string) error {
 
 	if err := GetEngine().GetAppRepository().DeleteApp(appID); err != nil {
-		log.Println(err)
+		logger.WithField("AppID", appID).Error(err)
 		return err
 	}
 
@@ -82,7 +81,7 @@ func UpdateApplication(appID, name string) error {
 	app, err := GetApplication(appID)
 
 	if err != nil {
-		log.Println(err)
+		logger.WithField("AppID", appID).Error(err)
 		return err
 	}
 
@@ -91,7 +90,7 @@ func UpdateApplication(appID, name string) error {
 	}
 
 	if err := GetEngine().GetAppRepository().UpdateApp(appID, name); err != nil {
-		log.Println(err)
+		logger.WithField("AppID", appID).Error(err)
 		return err
 	}
 
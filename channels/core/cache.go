@@ -1,5 +1,7 @@
 package core
 
+import "time"
+
 // CacheQueueSize - How much the cache of a channel queue can grow, the bigger the less database request we make
 var CacheQueueSize int64 = 50
 
@@ -35,6 +37,17 @@ type CacheStorage interface {
 	GetOldestChannelEvent(channelID string, appID string) *ChannelEvent
 	GetChannelEventsSize(channelID string, appID string) uint64
 	GetChannelEvents(channelID string, appID string, amount int64) []*ChannelEvent
+	UpdateChannelEvent(channelID string, appID string, event *ChannelEvent) // Replace the cached event with the same ID, if it is cached
+	RemoveChannelEvent(channelID string, appID string, eventID uint64)
+	// Channel Event ID
+	InitChannelEventID(channelID string, appID string, lastID uint64)
+	NextChannelEventID(channelID string, appID string) (uint64, bool)
+	// Connection Ticket
+	StoreConnectionTicket(ticketID string, ticket *ConnectionTicket) bool // Store until the ticket expires, returns false if it failed
+	TakeConnectionTicket(ticketID string) *ConnectionTicket               // Get and remove the ticket, so it is only used once
+	// Revocation
+	StoreRevocation(appID string, clientID string, deviceID string, revokedAt int64, ttl time.Duration) bool // An empty deviceID is for every device of the client, returns false if it failed
+	GetRevocation(appID string, clientID string, deviceID string) int64                                      // Unix seconds of the last revocation, 0 if there is none
 }
 
 // REDIS APP
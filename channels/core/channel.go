@@ -1,23 +1,25 @@
 package core
 
 import (
-	"fmt"
-	"os"
 	"sync"
 	"time"
 
+	log "github.com/sirupsen/logrus"
 	"go.uber.org/atomic"
 )
 
 // HubChannel - Handler for topic
 type HubChannel struct {
-	Data                   *Channel
+	// *Channel, swapped on updates
+	data                   atomic.Value
 	connectedUsers         sync.Map //[string(session_identifier)]*Session
 	connectedClientsStatus sync.Map //[string(clientID)]TimeStamp
+	clientRoles            sync.Map //[string(clientID)]ChannelRole
 	isClosing              bool
 	connectedCounter       atomic.Int32
 	hub                    *Hub
 	inactivityTimer        *time.Timer
+	usersLock              sync.Mutex // Keeps a replaced session from removing the new one
 }
 
 // DeleteChannel - Unsubscribe all clients and stop accepting subscriptions
@@ -29,9 +31,9 @@ func (channel *HubChannel) DeleteChannel() {
 
 		session := value.(*Session)
 
-		session.RemoveChannel(channel.Data.ID)
+		session.RemoveChannel(channel.Data().ID)
 
-		GetEngine().GetPublisher().Unsubscribe(channel.Data.ID, channel.Data.AppID)
+		GetEngine().GetPublisher().Unsubscribe(channel.Data().ID, channel.Data().AppID)
 
 		return true
 	})
@@ -51,7 +53,7 @@ func (channel *HubChannel) PublishJoinLeave(eventType NewEvent_NewEventType, pay
 	eventData, err := newEvent.Marshal()
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Session Publish: failed to marhal NewEvent: %v\n", err)
+		channel.logger().WithError(err).Error("Session Publish: failed to marhal NewEvent")
 		return
 	}
 
@@ -65,6 +67,85 @@ func (channel *HubChannel) PublishJoinLeave(eventType NewEvent_NewEventType, pay
 	})
 }
 
+// Data - Get the channel settings, they must not be modified, use UpdateData instead
+func (channel *HubChannel) Data() *Channel {
+	return channel.data.Load().(*Channel)
+}
+
+// UpdateData - Replace the channel settings with the updated ones
+// Data is swapped instead of modified, so readers see either the old or the new settings
+// If the channel became private the sessions of clients that aren't members are unsubscribed
+func (channel *HubChannel) UpdateData(updated *Channel) {
+	current := channel.Data()
+	data := *current
+
+	data.Name = updated.Name
+	data.Extra = updated.Extra
+	data.Persistent = updated.Persistent
+	data.Private = updated.Private
+	data.Presence = updated.Presence
+	data.Push = updated.Push
+
+	channel.data.Store(&data)
+
+	if !current.Private && data.Private {
+		channel.removeNonMembers()
+	}
+}
+
+// removeNonMembers - Unsubscribe the sessions of clients that aren't members of the channel, admins stay
+func (channel *HubChannel) removeNonMembers() {
+	channelID := channel.Data().ID
+
+	channel.connectedUsers.Range(func(key interface{}, value interface{}) bool {
+		session := value.(*Session)
+
+		if !session.identity.IsAdminKind() && !session.IsAllowedChannel(channelID) {
+			session.notifyChannelRemoved(channelID)
+			session.Unsubscribe(channelID)
+		}
+
+		return true
+	})
+}
+
+// ClientRole - Get the client role in the channel, loaded from database on first use
+// Returns empty if the client isn't in the channel
+func (channel *HubChannel) ClientRole(clientID string) ChannelRole {
+	if role, found := channel.clientRoles.Load(clientID); found {
+		return role.(ChannelRole)
+	}
+
+	role, err := GetEngine().GetChannelRepository().GetChannelClientRole(channel.Data().AppID, channel.Data().ID, clientID)
+
+	if err != nil {
+		channel.logger().WithField("ClientID", clientID).WithError(err).Error("Channel client role: failed to load role")
+		return ""
+	}
+
+	channel.clientRoles.Store(clientID, role)
+
+	return role
+}
+
+// SetClientRole - Update the cached client role
+func (channel *HubChannel) SetClientRole(clientID string, role ChannelRole) {
+	channel.clientRoles.Store(clientID, role)
+}
+
+// ForgetClientRole - Drop the cached client role, so it is loaded again on next use
+func (channel *HubChannel) ForgetClientRole(clientID string) {
+	channel.clientRoles.Delete(clientID)
+}
+
+// logger - Logger with the channel fields
+func (channel *HubChannel) logger() *log.Entry {
+	return logger.WithFields(log.Fields{
+		"AppID":     channel.Data().AppID,
+		"ChannelID": channel.Data().ID,
+	})
+}
+
 // ExternalPublish - Publish to be used by HTTP and Publisher so we don't republish nor store in db/cache
 func (channel *HubChannel) ExternalPublish(channelEvent *ChannelEvent) bool {
 	if channel.isClosing {
@@ -77,7 +158,7 @@ func (channel *HubChannel) ExternalPublish(channelEvent *ChannelEvent) bool {
 	data, err := channelEvent.Marshal()
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Session Publish: failed to marhal channel event: %v\n", err)
+		channel.logger().WithError(err).Error("Session Publish: failed to marhal channel event")
 		return false
 	}
 
@@ -89,7 +170,76 @@ func (channel *HubChannel) ExternalPublish(channelEvent *ChannelEvent) bool {
 	eventData, err := newEvent.Marshal()
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Session Publish: failed to marhal NewEvent: %v\n", err)
+		channel.logger().WithError(err).Error("Session Publish: failed to marhal NewEvent")
+		return false
+	}
+
+	channel.connectedUsers.Range(func(key interface{}, value interface{}) bool {
+
+		session := value.(*Session)
+
+		session.PublishChannelEvent(channel.Data().ID, channelEvent.ID, eventData)
+
+		return true
+	})
+
+	eventsPublished.Inc(channel.Data().AppID, "external")
+
+	return true
+}
+
+// PublishEdit - Send the edited event to the channel subscribers of this server
+func (channel *HubChannel) PublishEdit(channelEvent *ChannelEvent) bool {
+	data, err := channelEvent.Marshal()
+
+	if err != nil {
+		channel.logger().WithError(err).Error("Channel Edit: failed to marshal channel event")
+		return false
+	}
+
+	return channel.publishChange(NewEvent_EDIT, data)
+}
+
+// PublishDelete - Send the deleted event ID to the channel subscribers of this server
+func (channel *HubChannel) PublishDelete(eventID uint64) bool {
+	eventDelete := ChannelEventDelete{
+		ChannelID: channel.Data().ID,
+		ID:        eventID,
+	}
+
+	data, err := eventDelete.Marshal()
+
+	if err != nil {
+		channel.logger().WithError(err).Error("Channel Delete: failed to marshal channel event delete")
+		return false
+	}
+
+	return channel.publishChange(NewEvent_DELETE, data)
+}
+
+// PublishReadReceipt - Send the read marker of a client to the channel subscribers of this server
+// The client's own sessions get it too, so its other devices can update their unread state
+func (channel *HubChannel) PublishReadReceipt(receipt *ReadReceipt) bool {
+	if channel.isClosing {
+		return false
+	}
+
+	data, err := receipt.Marshal()
+
+	if err != nil {
+		channel.logger().WithError(err).Error("Channel Read: failed to marshal read receipt")
+		return false
+	}
+
+	newEvent := NewEvent{
+		Type:    NewEvent_READ,
+		Payload: data,
+	}
+
+	eventData, err := newEvent.Marshal()
+
+	if err != nil {
+		channel.logger().WithError(err).Error("Channel Read: failed to marshal NewEvent")
 		return false
 	}
 
@@ -105,6 +255,89 @@ func (channel *HubChannel) ExternalPublish(channelEvent *ChannelEvent) bool {
 	return true
 }
 
+// PublishSignal - Send an ephemeral signal to the channel subscribers of this server, except the sender session
+// Signals are never stored nor pushed, and aren't held back while missed events are replayed
+func (channel *HubChannel) PublishSignal(signal *ChannelSignal, sender *Session) bool {
+	if channel.isClosing {
+		return false
+	}
+
+	data, err := signal.Marshal()
+
+	if err != nil {
+		channel.logger().WithError(err).Error("Channel Signal: failed to marshal signal")
+		return false
+	}
+
+	newEvent := NewEvent{
+		Type:    NewEvent_SIGNAL,
+		Payload: data,
+	}
+
+	eventData, err := newEvent.Marshal()
+
+	if err != nil {
+		channel.logger().WithError(err).Error("Channel Signal: failed to marshal NewEvent")
+		return false
+	}
+
+	channel.connectedUsers.Range(func(key interface{}, value interface{}) bool {
+
+		session := value.(*Session)
+
+		if session != sender {
+			session.Publish(eventData)
+		}
+
+		return true
+	})
+
+	return true
+}
+
+// ExternalPublishSignal - Send a signal received from another server to the channel subscribers of this server
+func (channel *HubChannel) ExternalPublishSignal(signal *ChannelSignal) bool {
+	if !channel.PublishSignal(signal, nil) {
+		return false
+	}
+
+	signalsSent.Inc(channel.Data().AppID, "external")
+
+	return true
+}
+
+// publishChange - Send an edit or delete to subscribers
+// Sessions replaying missed events get it after the replay, so the changed event is sent first
+func (channel *HubChannel) publishChange(eventType NewEvent_NewEventType, payload []byte) bool {
+	if channel.isClosing {
+		return false
+	}
+
+	newEvent := NewEvent{
+		Type:    eventType,
+		Payload: payload,
+	}
+
+	eventData, err := newEvent.Marshal()
+
+	if err != nil {
+		channel.logger().WithError(err).Error("Channel Change: failed to marshal NewEvent")
+		return false
+	}
+
+	channel.connectedUsers.Range(func(key interface{}, value interface{}) bool {
+
+		session := value.(*Session)
+
+		// Events without ID are always sent after the replay
+		session.PublishChannelEvent(channel.Data().ID, 0, eventData)
+
+		return true
+	})
+
+	return true
+}
+
 // Publish - Send message to all connected clients
 func (channel *HubChannel) Publish(channelEvent *ChannelEvent, shouldStore bool) bool {
 
@@ -112,12 +345,26 @@ func (channel *HubChannel) Publish(channelEvent *ChannelEvent, shouldStore bool)
 		return false
 	}
 
+	shouldStore = channel.Data().Persistent && shouldStore
+
+	// Stored events get a sequence ID, so clients can sync after it
+	if shouldStore {
+		ID, err := NextChannelEventID(channel.Data().AppID, channel.Data().ID)
+
+		if err != nil {
+			channel.logger().WithError(err).Error("Session Publish: failed to get event ID")
+			return false
+		}
+
+		channelEvent.ID = ID
+	}
+
 	// * We parse the message here, so
 	// * we avoid parsing for each connection
 	data, err := channelEvent.Marshal()
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Session Publish: failed to marhal channel event: %v\n", err)
+		channel.logger().WithError(err).Error("Session Publish: failed to marhal channel event")
 		return false
 	}
 
@@ -129,18 +376,18 @@ func (channel *HubChannel) Publish(channelEvent *ChannelEvent, shouldStore bool)
 	newEventData, err := newEvent.Marshal()
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Session Publish: failed to marhal new channel event: %v\n", err)
+		channel.logger().WithError(err).Error("Session Publish: failed to marhal new channel event")
 		return false
 	}
 
 	// If it is a persistent channel store message in DB and cache
-	if channel.Data.Persistent && shouldStore {
-		GetEngine().StoreEvent(channel.Data.AppID, channelEvent)
-		GetEngine().GetCacheStorage().StoreChannelEvent(channel.Data.ID, channel.Data.AppID, channelEvent)
+	if shouldStore {
+		GetEngine().StoreEvent(channel.Data().AppID, channelEvent)
+		GetEngine().GetCacheStorage().StoreChannelEvent(channel.Data().ID, channel.Data().AppID, channelEvent)
 	}
 
 	// If has presence and push activated then send push notification to offline users
-	if channel.Data.Presence && channel.Data.Push {
+	if channel.Data().Presence && channel.Data().Push {
 
 		clientIDs := make([]string, 0)
 		channel.connectedClientsStatus.Range(func(key interface{}, value interface{}) bool {
@@ -155,7 +402,7 @@ func (channel *HubChannel) Publish(channelEvent *ChannelEvent, shouldStore bool)
 		})
 
 		request := PushRequestItem{
-			ChannelID: channel.Data.ID,
+			ChannelID: channel.Data().ID,
 			EventType: channelEvent.EventType,
 			Timestamp: channelEvent.Timestamp,
 			ClientIDs: clientIDs,
@@ -165,13 +412,15 @@ func (channel *HubChannel) Publish(channelEvent *ChannelEvent, shouldStore bool)
 		GetEngine().GetPushHandler().EnqueueRequest(&request)
 	}
 
-	GetEngine().GetPublisher().PublishChannelEvent(channel.Data.AppID, channel.Data.ID, channelEvent)
+	GetEngine().GetPublisher().PublishChannelEvent(channel.Data().AppID, channel.Data().ID, channelEvent)
+
+	eventsPublished.Inc(channel.Data().AppID, "local")
 
 	channel.connectedUsers.Range(func(key interface{}, value interface{}) bool {
 
 		session := value.(*Session)
 
-		session.Publish(newEventData)
+		session.PublishChannelEvent(channel.Data().ID, channelEvent.ID, newEventData)
 
 		return true
 	})
@@ -197,7 +446,7 @@ func (channel *HubChannel) ExternalPublishStatusChange(statusUpdate *OnlineStatu
 	statusUpdateData, err := statusUpdate.Marshal()
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Session Publish: failed to marhal status update: %v\n", err)
+		channel.logger().WithError(err).Error("Session Publish: failed to marhal status update")
 	}
 
 	newEvent := NewEvent{
@@ -208,7 +457,7 @@ func (channel *HubChannel) ExternalPublishStatusChange(statusUpdate *OnlineStatu
 	newEventData, err := newEvent.Marshal()
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Session Publish: failed to marhal new event on status update: %v\n", err)
+		channel.logger().WithError(err).Error("Session Publish: failed to marhal new event on status update")
 	}
 
 	channel.connectedUsers.Range(func(key interface{}, value interface{}) bool {
@@ -244,7 +493,7 @@ func (channel *HubChannel) PublishStatusChange(statusUpdate *OnlineStatusUpdate)
 	statusUpdateData, err := statusUpdate.Marshal()
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Session Publish: failed to marhal status update: %v\n", err)
+		channel.logger().WithError(err).Error("Session Publish: failed to marhal status update")
 	}
 
 	newEvent := NewEvent{
@@ -255,11 +504,11 @@ func (channel *HubChannel) PublishStatusChange(statusUpdate *OnlineStatusUpdate)
 	newEventData, err := newEvent.Marshal()
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Session Publish: failed to marhal new event on status update: %v\n", err)
+		channel.logger().WithError(err).Error("Session Publish: failed to marhal new event on status update")
 	}
 
 	// Update other servers about this change
-	GetEngine().GetPublisher().PublishChannelOnlineChange(channel.Data.AppID, channel.Data.ID, statusUpdate)
+	GetEngine().GetPublisher().PublishChannelOnlineChange(channel.Data().AppID, channel.Data().ID, statusUpdate)
 
 	channel.connectedUsers.Range(func(key interface{}, value interface{}) bool {
 
@@ -291,13 +540,15 @@ func (channel *HubChannel) NewClient(session *Session) {
 	// Add connected counter
 	channel.connectedCounter.Inc()
 
+	channel.usersLock.Lock()
 	channel.connectedUsers.Store(session.GetIdentifier(), session)
+	channel.usersLock.Unlock()
 
-	if channel.Data.Presence {
+	if channel.Data().Presence {
 		channel.shouldNotifyOnlinePresenceChange(session)
 		// Prepare initial state
 		initialPresenceState := InitialPresenceStatus{
-			ChannelID:    channel.Data.ID,
+			ChannelID:    channel.Data().ID,
 			ClientStatus: make(map[string]*ClientStatus),
 		}
 
@@ -321,7 +572,7 @@ func (channel *HubChannel) NewClient(session *Session) {
 		data, err := initialPresenceState.Marshal()
 
 		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "Channel Initial presence: failed to marshal initial presence status %v\n", err)
+			channel.logger().WithField("ClientID", session.clientID).WithError(err).Error("Channel Initial presence: failed to marshal initial presence status")
 			return
 		}
 
@@ -334,7 +585,7 @@ func (channel *HubChannel) NewClient(session *Session) {
 		eventData, err := newEvent.Marshal()
 
 		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "Channel Initial presence: failed to marshal initial presence status of new event %v\n", err)
+			channel.logger().WithField("ClientID", session.clientID).WithError(err).Error("Channel Initial presence: failed to marshal initial presence status of new event")
 			return
 		}
 
@@ -359,7 +610,7 @@ func (channel *HubChannel) shouldNotifyOnlinePresenceChange(session *Session) {
 
 	if lastTimestamp.Before(timeStamp) {
 		statusUpdate := OnlineStatusUpdate{
-			ChannelID: channel.Data.ID,
+			ChannelID: channel.Data().ID,
 			ClientID:  session.clientID,
 			Status:    true,
 			Timestamp: timeStamp.Unix(),
@@ -368,14 +619,14 @@ func (channel *HubChannel) shouldNotifyOnlinePresenceChange(session *Session) {
 		channel.PublishStatusChange(&statusUpdate)
 	}
 	/*
-		GetEngine().GetPresence().AddOnlineChannelDevice(channel.Data.AppID, channel.Data.ID, session.clientID, session.deviceID)
+		GetEngine().GetPresence().AddOnlineChannelDevice(channel.Data().AppID, channel.Data().ID, session.clientID, session.deviceID)
 
 		// Get how many are left
-		amount := GetEngine().GetPresence().GetChannelAmountOfClientDevices(channel.Data.AppID, channel.Data.ID, session.clientID)
+		amount := GetEngine().GetPresence().GetChannelAmountOfClientDevices(channel.Data().AppID, channel.Data().ID, session.clientID)
 
 		if amount == 1 {
 			statusUpdate := OnlineStatusUpdate{
-				ChannelID: channel.Data.ID,
+				ChannelID: channel.Data().ID,
 				ClientID:  session.clientID,
 				Status:    true,
 				Timestamp: timeStamp,
@@ -402,7 +653,7 @@ func (channel *HubChannel) shouldNotifyOfflinePresenceChange(session *Session) {
 
 		if lastTimeStamp.Before(now) || lastTimeStamp.Equal(now) {
 			statusUpdate := OnlineStatusUpdate{
-				ChannelID: channel.Data.ID,
+				ChannelID: channel.Data().ID,
 				ClientID:  session.clientID,
 				Status:    false, // If not remove is online
 				Timestamp: time.Now().Unix(),
@@ -422,11 +673,11 @@ func (channel *HubChannel) shouldNotifyOfflinePresenceChange(session *Session) {
 		/*
 			// Delay the deletion of the device in the channel, otherwise on shouldNotifyOnline detects 0 devices and sets as online
 			// Remove this devices from channel online devices
-			GetEngine().GetPresence().RemoveOnlineChannelDevice(channel.Data.AppID, channel.Data.ID, session.clientID, session.deviceID)
+			GetEngine().GetPresence().RemoveOnlineChannelDevice(channel.Data().AppID, channel.Data().ID, session.clientID, session.deviceID)
 
 			// Check if the remove device is connected
 			// If so then it reconnected and there is no need to publish the status update
-			if GetEngine().GetPresence().IsClientDeviceConnectToChannel(channel.Data.AppID, channel.Data.ID, session.clientID, session.deviceID) {
+			if GetEngine().GetPresence().IsClientDeviceConnectToChannel(channel.Data().AppID, channel.Data().ID, session.clientID, session.deviceID) {
 				timer.Stop()
 				return
 			}
@@ -434,7 +685,7 @@ func (channel *HubChannel) shouldNotifyOfflinePresenceChange(session *Session) {
 			// If the device is not back online
 			// We must check if client is not connected with another device
 			// If the he is, then ignore the status update
-			amount := GetEngine().GetPresence().GetChannelAmountOfClientDevices(channel.Data.AppID, channel.Data.ID, session.clientID)
+			amount := GetEngine().GetPresence().GetChannelAmountOfClientDevices(channel.Data().AppID, channel.Data().ID, session.clientID)
 
 			if amount < 0 {
 				amount = 0
@@ -446,7 +697,7 @@ func (channel *HubChannel) shouldNotifyOfflinePresenceChange(session *Session) {
 			}
 
 			statusUpdate := OnlineStatusUpdate{
-				ChannelID: channel.Data.ID,
+				ChannelID: channel.Data().ID,
 				ClientID:  session.clientID,
 				Status:    false, // If not remove is online
 				Timestamp: time.Now().Unix(),
@@ -474,9 +725,16 @@ func (channel *HubChannel) RemoveClient(session *Session) {
 
 	channel.connectedCounter.Dec()
 
-	channel.connectedUsers.LoadAndDelete(session.GetIdentifier())
+	// A new session of the same device may have replaced it
+	channel.usersLock.Lock()
 
-	if channel.Data.Presence {
+	if value, isOK := channel.connectedUsers.Load(session.GetIdentifier()); isOK && value.(*Session) == session {
+		channel.connectedUsers.Delete(session.GetIdentifier())
+	}
+
+	channel.usersLock.Unlock()
+
+	if channel.Data().Presence {
 		channel.shouldNotifyOfflinePresenceChange(session)
 	}
 
@@ -494,7 +752,7 @@ func (channel *HubChannel) shouldCloseChannel() {
 	go func() {
 		defer func() {
 			if err := recover(); err != nil {
-				fmt.Println(err)
+				channel.logger().Error(err)
 			}
 		}()
 
@@ -505,8 +763,8 @@ func (channel *HubChannel) shouldCloseChannel() {
 		channel.inactivityTimer.Stop()
 
 		if channel.connectedCounter.Load() == 0 {
-			fmt.Printf("No subscribers on channel %s for the last 15 mins, closing channel...\n", channel.Data.ID)
-			channel.hub.DeleteChannel(channel.Data.ID)
+			channel.logger().Info("No subscribers on channel for the last 15 mins, closing channel")
+			channel.hub.DeleteChannel(channel.Data().ID)
 		}
 
 	}()
@@ -521,14 +779,14 @@ func NewChannel(ID string, AppID string, hub *Hub) *HubChannel {
 		c, err := GetEngine().GetChannelRepository().GetAppChannel(AppID, ID)
 
 		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "NewChannel: failed to fetch channel: %v\n", err)
+			logger.WithError(err).Error("NewChannel: failed to fetch channel")
 			return nil
 		}
 
 		chann = c
 
 		if chann == nil {
-			_, _ = fmt.Fprintf(os.Stderr, "NewChannel: attempting to create unexistent channel")
+			logger.WithFields(log.Fields{"AppID": AppID, "ChannelID": ID}).Error("NewChannel: attempting to create unexistent channel")
 			return nil
 		}
 
@@ -537,10 +795,11 @@ func NewChannel(ID string, AppID string, hub *Hub) *HubChannel {
 	}
 
 	hubChannel := &HubChannel{
-		Data: chann,
-		hub:  hub,
+		hub: hub,
 	}
 
+	hubChannel.data.Store(chann)
+
 	if chann.Presence {
 
 		clients, err := GetEngine().GetChannelRepository().GetChannelClients(chann.AppID, chann.ID)
@@ -1,17 +1,17 @@
 package core
 
 import (
-	"fmt"
-	"log"
-	"os"
+	"errors"
 	"time"
+
+	log "github.com/sirupsen/logrus"
 )
 
 func SendPushNotification(appID string, channelEvent *ChannelEvent) bool {
 	clientIDs, err := GetEngine().GetChannelRepository().GetChannelClients(appID, channelEvent.ChannelID)
 
 	if err != nil {
-		log.Println(err)
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelEvent.ChannelID}).Error(err)
 		return false
 	}
 
@@ -51,7 +51,7 @@ func CreateChannel(appID string, channel *Channel) (bool, error) {
 	)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Create Channel failed %v\n", err)
+		logger.WithField("AppID", appID).WithError(err).Error("HTTP Create Channel failed")
 		return false, nil
 	}
 
@@ -93,7 +93,7 @@ func GetChannel(appID string, channelID string) (*Channel, error) {
 
 	if err != nil {
 
-		fmt.Fprintf(os.Stderr, "Get channel: failed to get app channel %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Get channel: failed to get app channel")
 		return nil, err
 	}
 
@@ -107,13 +107,295 @@ func GetChannel(appID string, channelID string) (*Channel, error) {
 	return channel, nil
 }
 
+// ErrEventIDUnavailable - Returned when the channel event ID counter couldn't be incremented
+var ErrEventIDUnavailable = errors.New("channel event ID counter unavailable")
+
+// NextChannelEventID - Get the next event ID of a channel, shared between all servers through the cache
+// If the counter is missing from cache, it is seeded with the last event ID stored, or deleted, in the database
+// or the last one still cached or waiting in the insert queue, whichever is higher
+func NextChannelEventID(appID string, channelID string) (uint64, error) {
+
+	if ID, isOK := GetEngine().GetCacheStorage().NextChannelEventID(channelID, appID); isOK {
+		return ID, nil
+	}
+
+	lastID, err := GetEngine().GetChannelRepository().GetChannelLastEventID(appID, channelID)
+
+	if err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Next channel event ID: failed to get last event ID")
+		return 0, err
+	}
+
+	for _, event := range GetEngine().GetCacheStorage().GetChannelEvents(channelID, appID, CacheQueueSize) {
+		if event.ID > lastID {
+			lastID = event.ID
+		}
+	}
+
+	if queuedID := GetEngine().LastQueuedEventID(appID, channelID); queuedID > lastID {
+		lastID = queuedID
+	}
+
+	// Only sets the counter if no other server did it meanwhile
+	GetEngine().GetCacheStorage().InitChannelEventID(channelID, appID, lastID)
+
+	ID, isOK := GetEngine().GetCacheStorage().NextChannelEventID(channelID, appID)
+
+	if !isOK {
+		return 0, ErrEventIDUnavailable
+	}
+
+	return ID, nil
+}
+
+// GetChannelEventsAfterID - Get up to amount channel events after the given event ID, oldest first, all of them if amount is 0
+// Cached events are used when they cover the whole range, otherwise they complete the ones not yet in the database
+func GetChannelEventsAfterID(appID string, channelID string, eventID uint64, amount int64) ([]*ChannelEvent, error) {
+
+	// Cached events are ordered from newest to oldest
+	cached := GetEngine().GetCacheStorage().GetChannelEvents(channelID, appID, CacheQueueSize)
+
+	events := make([]*ChannelEvent, 0)
+
+	if len(cached) == 0 || cached[len(cached)-1].ID == 0 || cached[len(cached)-1].ID > eventID+1 {
+		var stored []*ChannelEvent
+		var err error
+
+		if amount > 0 {
+			stored, err = GetEngine().GetChannelRepository().GetChannelLastEventsAfterID(appID, channelID, amount, eventID)
+		} else {
+			stored, err = GetEngine().GetChannelRepository().GetChannelEventsAfterID(appID, channelID, eventID)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, stored...)
+
+		if len(events) != 0 {
+			eventID = events[len(events)-1].ID
+		}
+	}
+
+	// Events may still be waiting to be inserted in the database
+	for i := len(cached) - 1; i >= 0; i-- {
+		if amount > 0 && int64(len(events)) >= amount {
+			break
+		}
+
+		if cached[i].ID > eventID {
+			events = append(events, cached[i])
+			eventID = cached[i].ID
+		}
+	}
+
+	return events, nil
+}
+
+// GetChannelEvent - Get a stored channel event, from cache if it is still there, otherwise from database
+// Returns nil if the event doesn't exist
+func GetChannelEvent(appID string, channelID string, eventID uint64) (*ChannelEvent, error) {
+
+	// Events stored without ID can't be told apart
+	if eventID == 0 {
+		return nil, nil
+	}
+
+	// Events waiting to be inserted in the database are only found in cache
+	for _, event := range GetEngine().GetCacheStorage().GetChannelEvents(channelID, appID, CacheQueueSize) {
+		if event.ID == eventID {
+			return event, nil
+		}
+	}
+
+	event, err := GetEngine().GetChannelRepository().GetChannelEvent(appID, channelID, eventID)
+
+	if err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "EventID": eventID}).WithError(err).Error("Get channel event: failed to get event")
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// EditChannelEvent - Replace the payload of a stored event
+// The cache is patched, the database update is queued after pending inserts and subscribers of all servers are notified
+func EditChannelEvent(appID string, event *ChannelEvent, payload string) {
+	event.Payload = payload
+
+	GetEngine().GetCacheStorage().UpdateChannelEvent(event.ChannelID, appID, event)
+	GetEngine().EditStoredEvent(appID, event)
+
+	GetEngine().GetPublisher().PublishChannelEventEdit(appID, event.ChannelID, event)
+
+	if hubChannel := containsHubChannel(appID, event.ChannelID); hubChannel != nil {
+		hubChannel.PublishEdit(event)
+	}
+}
+
+// DeleteChannelEvent - Remove a stored event
+// The cache is patched, the database removal is queued after pending inserts and subscribers of all servers are notified
+func DeleteChannelEvent(appID string, channelID string, eventID uint64) {
+	GetEngine().GetCacheStorage().RemoveChannelEvent(channelID, appID, eventID)
+	GetEngine().DeleteStoredEvent(appID, channelID, eventID)
+
+	GetEngine().GetPublisher().PublishChannelEventDelete(appID, channelID, eventID)
+
+	if hubChannel := containsHubChannel(appID, channelID); hubChannel != nil {
+		hubChannel.PublishDelete(eventID)
+	}
+}
+
+// SetReadMarker - Store the last event read by the client and notify the channel subscribers of all servers
+func SetReadMarker(appID string, channelID string, clientID string, eventID uint64) error {
+	receipt := ReadReceipt{
+		ChannelID: channelID,
+		ClientID:  clientID,
+		EventID:   eventID,
+		Timestamp: time.Now().Unix(),
+	}
+
+	err := GetEngine().GetChannelRepository().SetReadMarker(appID, channelID, clientID, eventID, receipt.Timestamp)
+
+	if err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "ClientID": clientID, "EventID": eventID}).WithError(err).Error("Set read marker: failed to store read marker")
+		return err
+	}
+
+	GetEngine().GetPublisher().PublishChannelRead(appID, channelID, &receipt)
+
+	if hubChannel := containsHubChannel(appID, channelID); hubChannel != nil {
+		hubChannel.PublishReadReceipt(&receipt)
+	}
+
+	return nil
+}
+
+// CountUnreadEvents - Count the channel events after the given event ID not sent by the client
+// Cached events complete the ones not yet in the database, they are matched by ID since the queue may insert them in any order
+func CountUnreadEvents(appID string, channelID string, clientID string, eventID uint64) (uint64, error) {
+	count, err := GetEngine().GetChannelRepository().CountUnreadChannelEvents(appID, channelID, clientID, eventID)
+
+	if err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "ClientID": clientID}).WithError(err).Error("Count unread events: failed to count stored events")
+		return 0, err
+	}
+
+	// Events may still be waiting to be inserted in the database
+	unread := make([]*ChannelEvent, 0)
+
+	var firstID, lastID uint64
+
+	for _, event := range GetEngine().GetCacheStorage().GetChannelEvents(channelID, appID, CacheQueueSize) {
+		if event.ID > eventID && event.SenderID != clientID {
+			unread = append(unread, event)
+
+			if firstID == 0 || event.ID < firstID {
+				firstID = event.ID
+			}
+
+			if event.ID > lastID {
+				lastID = event.ID
+			}
+		}
+	}
+
+	if len(unread) == 0 {
+		return count, nil
+	}
+
+	stored, err := GetEngine().GetChannelRepository().GetChannelLastEventsAfterID(appID, channelID, int64(lastID-firstID+1), firstID-1)
+
+	if err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "ClientID": clientID}).WithError(err).Error("Count unread events: failed to get stored cached events")
+		return 0, err
+	}
+
+	storedIDs := make(map[uint64]struct{}, len(stored))
+
+	for _, event := range stored {
+		storedIDs[event.ID] = struct{}{}
+	}
+
+	for _, event := range unread {
+		if _, isStored := storedIDs[event.ID]; !isStored {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// PublishChannelSignal - Send an ephemeral signal to the channel subscribers of all servers
+// The sender session, if any, doesn't get its own signal back
+func PublishChannelSignal(appID string, signal *ChannelSignal, sender *Session) {
+	GetEngine().GetPublisher().PublishChannelSignal(appID, signal.ChannelID, signal)
+
+	signalsSent.Inc(appID, "local")
+
+	if hubChannel := containsHubChannel(appID, signal.ChannelID); hubChannel != nil {
+		hubChannel.PublishSignal(signal, sender)
+	}
+}
+
+// IsChannelMember - Check if the client joined the channel, using the cached client channels when available
+func IsChannelMember(appID string, channelID string, clientID string) (bool, error) {
+	channelIDs, found := GetEngine().GetCacheStorage().GetClientChannels(clientID)
+
+	if !found {
+		ids, err := GetEngine().GetChannelRepository().GetClientAllowedChannels(clientID)
+
+		if err != nil {
+			logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "ClientID": clientID}).WithError(err).Error("Is channel member: failed to load client allowed channels")
+			return false, err
+		}
+
+		channelIDs = ids
+	}
+
+	for _, ID := range channelIDs {
+		if ID == channelID {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// containsHubChannel - Get the channel if this server has clients listening to it
+func containsHubChannel(appID string, channelID string) *HubChannel {
+	hub := GetEngine().GetHubsHandler().ContainsHub(appID)
+
+	if hub == nil {
+		return nil
+	}
+
+	return hub.ContainsChannel(channelID)
+}
+
+// storePresenceEvent - Give a join or leave event the next channel event ID, then store and cache it
+func storePresenceEvent(appID string, event *ChannelEvent) {
+	ID, err := NextChannelEventID(appID, event.ChannelID)
+
+	if err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": event.ChannelID, "ClientID": event.SenderID}).WithError(err).Error("Store presence event: failed to get event ID")
+		return
+	}
+
+	event.ID = ID
+
+	GetEngine().StoreEvent(appID, event)
+	GetEngine().GetCacheStorage().StoreChannelEvent(event.ChannelID, appID, event)
+}
+
 // JoinChannel - Join client to a given channel, and update cache and current connected and affected clients
 func JoinChannel(appID string, channelID string, clientID string) (bool, error) {
 
 	client, err := GetClient(appID, clientID)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Join channel: failed to get app client %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "ClientID": clientID}).WithError(err).Error("Join channel: failed to get app client")
 		return false, nil
 	}
 
@@ -134,10 +416,24 @@ func JoinChannel(appID string, channelID string, clientID string) (bool, error)
 	err = GetEngine().GetChannelRepository().JoinClient(appID, channelID, clientID)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Join channel: failed to join client to channel %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "ClientID": clientID}).WithError(err).Error("Join channel: failed to join client to channel")
 		return false, err
 	}
 
+	notifyChannelJoin(appID, channel, clientID)
+
+	return true, nil
+}
+
+// notifyChannelJoin - Update cache and notify the client and channel, on this and other servers, that the client joined
+func notifyChannelJoin(appID string, channel *Channel, clientID string) {
+	channelID := channel.ID
+
+	// Role is loaded again on next use
+	if hubChannel := containsHubChannel(appID, channelID); hubChannel != nil {
+		hubChannel.ForgetClientRole(clientID)
+	}
+
 	// Clear cache
 	GetEngine().GetCacheStorage().RemoveClientChannels(clientID)
 	//GetEngine().GetCacheStorage().AddClientChannel(clientID, channelID)
@@ -165,8 +461,7 @@ func JoinChannel(appID string, channelID string, clientID string) (bool, error)
 		}
 
 		// Store and cache new event
-		GetEngine().StoreEvent(channel.AppID, newChannelEvent)
-		GetEngine().GetCacheStorage().StoreChannelEvent(channelID, appID, newChannelEvent)
+		storePresenceEvent(appID, newChannelEvent)
 
 		// If there are clients connected to hub and channel
 		// Then publish to them, otherwise there is no point
@@ -180,7 +475,7 @@ func JoinChannel(appID string, channelID string, clientID string) (bool, error)
 					// Publish to local clients only, we send to other servers after
 					channel.PublishJoinLeave(NewEvent_JOIN_CHANNEL, data)
 				} else {
-					_, _ = fmt.Fprintf(os.Stderr, "Join channel: failed to marshal join client event %v\n", err)
+					logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "ClientID": clientID}).WithError(err).Error("Join channel: failed to marshal join client event")
 				}
 			}
 
@@ -197,8 +492,6 @@ func JoinChannel(appID string, channelID string, clientID string) (bool, error)
 
 	// Notify clientID in other servers that he received access to channel
 	GetEngine().GetPublisher().PublishChannelAccessChange(appID, channelID, clientID, true)
-
-	return true, err
 }
 
 // LeaveChannel - Remove client from a given channel, and update cache
@@ -207,7 +500,7 @@ func LeaveChannel(appID string, channelID string, clientID string) (bool, error)
 	client, err := GetClient(appID, clientID)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Leave channel: failed to get app client %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "ClientID": clientID}).WithError(err).Error("Leave channel: failed to get app client")
 		return false, nil
 	}
 
@@ -229,10 +522,23 @@ func LeaveChannel(appID string, channelID string, clientID string) (bool, error)
 	err = GetEngine().GetChannelRepository().LeaveClient(appID, channelID, clientID)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Leave channel: failed to remove client from channel %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "ClientID": clientID}).WithError(err).Error("Leave channel: failed to remove client from channel")
 		return false, err
 	}
 
+	notifyChannelLeave(appID, channel, clientID)
+
+	return true, nil
+}
+
+// notifyChannelLeave - Update cache and notify the client and channel, on this and other servers, that the client left
+func notifyChannelLeave(appID string, channel *Channel, clientID string) {
+	channelID := channel.ID
+
+	if hubChannel := containsHubChannel(appID, channelID); hubChannel != nil {
+		hubChannel.ForgetClientRole(clientID)
+	}
+
 	// Clear cache
 	GetEngine().GetCacheStorage().RemoveClientChannels(clientID)
 
@@ -255,8 +561,7 @@ func LeaveChannel(appID string, channelID string, clientID string) (bool, error)
 		}
 
 		// Store and cache new event
-		GetEngine().StoreEvent(channel.AppID, newChannelEvent)
-		GetEngine().GetCacheStorage().StoreChannelEvent(channelID, appID, newChannelEvent)
+		storePresenceEvent(appID, newChannelEvent)
 
 		clientLeave := &ClientLeave{
 			ChannelID: channelID,
@@ -269,11 +574,14 @@ func LeaveChannel(appID string, channelID string, clientID string) (bool, error)
 		if hub != nil {
 			channel := hub.ContainsChannel(channelID)
 
-			if data, err := clientLeave.Marshal(); err == nil {
-				// Publish to local clients only, we send to other servers after
-				channel.PublishJoinLeave(NewEvent_LEAVE_CHANNEL, data)
-			} else {
-				_, _ = fmt.Fprintf(os.Stderr, "Leave channel: failed to marshal leave client event %v\n", err)
+			if channel != nil {
+
+				if data, err := clientLeave.Marshal(); err == nil {
+					// Publish to local clients only, we send to other servers after
+					channel.PublishJoinLeave(NewEvent_LEAVE_CHANNEL, data)
+				} else {
+					logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "ClientID": clientID}).WithError(err).Error("Leave channel: failed to marshal leave client event")
+				}
 			}
 
 		}
@@ -289,8 +597,133 @@ func LeaveChannel(appID string, channelID string, clientID string) (bool, error)
 
 	// Notify clientID in other servers that he lost access to channel
 	GetEngine().GetPublisher().PublishChannelAccessChange(appID, channelID, clientID, false)
+}
+
+// MaxBulkClients - Max clients joined or removed from a channel in one request
+const MaxBulkClients = 1000
+
+// JoinChannelClients - Join clients to a given channel in one transaction, then notify them like JoinChannel
+// Unknown clients and clients already in the channel are skipped, returns the ones that joined, or false if there is no channel
+func JoinChannelClients(appID string, channelID string, clientIDs []string) ([]string, bool, error) {
 
-	return true, err
+	channel, err := GetChannel(appID, channelID)
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	if channel == nil {
+		return nil, false, nil
+	}
+
+	members, err := channelClientsSet(appID, channelID)
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	joining := make([]string, 0, len(clientIDs))
+
+	for _, clientID := range clientIDs {
+		if _, isMember := members[clientID]; isMember {
+			continue
+		}
+
+		client, err := GetClient(appID, clientID)
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		if client == nil {
+			continue
+		}
+
+		// Skip duplicates of the request
+		members[clientID] = struct{}{}
+		joining = append(joining, clientID)
+	}
+
+	if len(joining) == 0 {
+		return joining, true, nil
+	}
+
+	if err := GetEngine().GetChannelRepository().JoinClients(appID, channelID, joining); err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Join channel clients: failed to join clients to channel")
+		return nil, false, err
+	}
+
+	for _, clientID := range joining {
+		notifyChannelJoin(appID, channel, clientID)
+	}
+
+	return joining, true, nil
+}
+
+// LeaveChannelClients - Remove clients from a given channel in one transaction, then notify them like LeaveChannel
+// Clients not in the channel are skipped, returns the ones that left, or false if there is no channel
+func LeaveChannelClients(appID string, channelID string, clientIDs []string) ([]string, bool, error) {
+
+	channel, err := GetChannel(appID, channelID)
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	if channel == nil {
+		return nil, false, nil
+	}
+
+	members, err := channelClientsSet(appID, channelID)
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	leaving := make([]string, 0, len(clientIDs))
+
+	for _, clientID := range clientIDs {
+		if _, isMember := members[clientID]; !isMember {
+			continue
+		}
+
+		// Skip duplicates of the request
+		delete(members, clientID)
+		leaving = append(leaving, clientID)
+	}
+
+	if len(leaving) == 0 {
+		return leaving, true, nil
+	}
+
+	if err := GetEngine().GetChannelRepository().LeaveClients(appID, channelID, leaving); err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Leave channel clients: failed to remove clients from channel")
+		return nil, false, err
+	}
+
+	for _, clientID := range leaving {
+		notifyChannelLeave(appID, channel, clientID)
+	}
+
+	return leaving, true, nil
+}
+
+// channelClientsSet - IDs of the clients in the channel
+func channelClientsSet(appID string, channelID string) (map[string]struct{}, error) {
+	clientIDs, err := GetEngine().GetChannelRepository().GetChannelClients(appID, channelID)
+
+	if err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Channel clients: failed to get channel clients")
+		return nil, err
+	}
+
+	members := make(map[string]struct{}, len(clientIDs))
+
+	for _, clientID := range clientIDs {
+		members[clientID] = struct{}{}
+	}
+
+	return members, nil
 }
 
 // DeleteChannel - Delete channel from database and cache, and notify all connected clients
@@ -307,10 +740,13 @@ func DeleteChannel(appID string, channelID string) (bool, error) {
 	}
 
 	if err := GetEngine().GetChannelRepository().DeleteChannel(appID, channelID); err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Delete channel: failed to delete channel %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Delete channel: failed to delete channel")
 		return false, err
 	}
 
+	// Rules aren't removed with the channel row, a failure is logged but won't stop the delete
+	_ = SetEventTypeRules(appID, channelID, nil)
+
 	// Update cache
 	GetEngine().GetCacheStorage().RemoveChannel(appID, channelID)
 
@@ -336,7 +772,7 @@ func SetChannelCloseStatus(appID string, channelID string, closed bool) (bool, e
 	channel.IsClosed = closed
 
 	if err := GetEngine().GetChannelRepository().SetChannelCloseStatus(appID, channelID, closed); err != nil {
-		fmt.Fprintf(os.Stderr, "Set channel close status: failed to save %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Set channel close status: failed to save")
 		return false, err
 	}
 
@@ -352,3 +788,63 @@ func SetChannelCloseStatus(appID string, channelID string, closed bool) (bool, e
 
 	return true, nil
 }
+
+// UpdateChannel - Save channel settings, and update them in cache and on every server with clients listening to it
+func UpdateChannel(appID string, channel *Channel) error {
+
+	if err := GetEngine().GetChannelRepository().UpdateChannel(appID, channel); err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channel.ID}).WithError(err).Error("Update channel: failed to save")
+		return err
+	}
+
+	// Next read fetches it from database
+	GetEngine().GetCacheStorage().RemoveChannel(appID, channel.ID)
+
+	if hubChannel := containsHubChannel(appID, channel.ID); hubChannel != nil {
+		hubChannel.UpdateData(channel)
+	}
+
+	GetEngine().GetPublisher().PublishChannelUpdate(appID, channel)
+
+	return nil
+}
+
+// GetChannelClientRole - Get the client role in the channel, empty if the client isn't in it
+// Roles of channels with clients listening on this server are cached by the channel
+func GetChannelClientRole(appID string, channelID string, clientID string) (ChannelRole, error) {
+
+	if hubChannel := containsHubChannel(appID, channelID); hubChannel != nil {
+		return hubChannel.ClientRole(clientID), nil
+	}
+
+	return GetEngine().GetChannelRepository().GetChannelClientRole(appID, channelID, clientID)
+}
+
+// SetChannelClientRole - Change the client role in the channel, on this and other servers
+// Returns false if the client isn't in the channel
+func SetChannelClientRole(appID string, channelID string, clientID string, role ChannelRole) (bool, error) {
+
+	current, err := GetEngine().GetChannelRepository().GetChannelClientRole(appID, channelID, clientID)
+
+	if err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "ClientID": clientID}).WithError(err).Error("Set channel client role: failed to get current role")
+		return false, err
+	}
+
+	if current == "" {
+		return false, nil
+	}
+
+	if err := GetEngine().GetChannelRepository().SetChannelClientRole(appID, channelID, clientID, role); err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "ClientID": clientID}).WithError(err).Error("Set channel client role: failed to save")
+		return false, err
+	}
+
+	if hubChannel := containsHubChannel(appID, channelID); hubChannel != nil {
+		hubChannel.SetClientRole(clientID, role)
+	}
+
+	GetEngine().GetPublisher().PublishChannelRoleChange(appID, channelID, clientID, role)
+
+	return true, nil
+}
@@ -0,0 +1,69 @@
+package core
+
+import (
+	"testing"
+)
+
+// presenceCache - Cache that only keeps the channel event ID counter
+type presenceCache struct {
+	CacheStorage
+	lastID uint64
+}
+
+func (cache *presenceCache) RemoveClientChannels(clientID string) {}
+
+func (cache *presenceCache) StoreChannelEvent(channelID string, appID string, event *ChannelEvent) {}
+
+func (cache *presenceCache) NextChannelEventID(channelID string, appID string) (uint64, bool) {
+	cache.lastID++
+	return cache.lastID, true
+}
+
+// presenceInsertQueue - Insert queue that keeps the stored events
+type presenceInsertQueue struct {
+	StorageInsert
+	events []*ChannelEvent
+}
+
+func (queue *presenceInsertQueue) StoreEvent(appID string, event *ChannelEvent) {
+	queue.events = append(queue.events, event)
+}
+
+// presencePublisher - Publisher that drops everything
+type presencePublisher struct {
+	PublishHandler
+}
+
+func (publisher *presencePublisher) PublishChannelPresenceChange(appID string, channelID string, clientID string, isJoin bool) {
+}
+
+func (publisher *presencePublisher) PublishChannelAccessChange(appID string, channelID string, clientID string, isAdd bool) {
+}
+
+func TestNotifyChannelJoinStoresEventIDs(t *testing.T) {
+	defer func(previous *Engine) { engine = previous }(engine)
+
+	insertQueue := &presenceInsertQueue{}
+
+	engine = &Engine{
+		hubsHandler:   NewHubsHandler(nil),
+		cacheStorage:  &presenceCache{},
+		publisher:     &presencePublisher{},
+		storageInsert: insertQueue,
+	}
+
+	channel := &Channel{ID: "channel", AppID: "app", Persistent: true, Presence: true}
+
+	notifyChannelJoin("app", channel, "alice")
+	notifyChannelJoin("app", channel, "bob")
+
+	if len(insertQueue.events) != 2 {
+		t.Fatalf("Expected 2 stored events, got %d", len(insertQueue.events))
+	}
+
+	for i, event := range insertQueue.events {
+		if event.EventType != "Join" || event.ID != uint64(i+1) {
+			t.Errorf("Expected Join event with ID %d, got %s with ID %d", i+1, event.EventType, event.ID)
+		}
+	}
+}
@@ -1,16 +1,16 @@
 package core
 
 import (
-	"fmt"
 	"io/ioutil"
 	"net/http"
-	"os"
+	"strconv"
 	"time"
 
 	jsoniter "github.com/json-iterator/go"
 	"github.com/lisomatrix/channels/channels/auth"
 
 	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
 )
 
 // type getChannelMessagesRequest struct {
@@ -28,6 +28,10 @@ type channelPublishRequest struct {
 	EventType string `json:"eventType"`
 }
 
+type channelEditRequest struct {
+	Payload string `json:"payload"`
+}
+
 // CreateChannelRequest - Create channel with given ID and settings
 type CreateChannelRequest struct {
 	ChannelID  string   `json:"channelID"`
@@ -40,6 +44,43 @@ type CreateChannelRequest struct {
 	Push       bool     `json:"push"`
 }
 
+// UpdateChannelRequest - Channel fields to update, nil fields are kept
+type UpdateChannelRequest struct {
+	Name       *string `json:"name"`
+	Extra      *string `json:"extra"`
+	Persistent *bool   `json:"persistent"`
+	Private    *bool   `json:"private"`
+	Presence   *bool   `json:"presence"`
+	Push       *bool   `json:"push"`
+}
+
+// apply - Set the given fields on the channel
+func (updateRequest *UpdateChannelRequest) apply(channel *Channel) {
+	if updateRequest.Name != nil {
+		channel.Name = *updateRequest.Name
+	}
+
+	if updateRequest.Extra != nil {
+		channel.Extra = *updateRequest.Extra
+	}
+
+	if updateRequest.Persistent != nil {
+		channel.Persistent = *updateRequest.Persistent
+	}
+
+	if updateRequest.Private != nil {
+		channel.Private = *updateRequest.Private
+	}
+
+	if updateRequest.Presence != nil {
+		channel.Presence = *updateRequest.Presence
+	}
+
+	if updateRequest.Push != nil {
+		channel.Push = *updateRequest.Push
+	}
+}
+
 // type outEvent struct {
 // 	Timestamp int64  `json:"timestamp"`
 // 	Data      []byte `json:"data"`
@@ -61,7 +102,7 @@ func PostEventHandler(context *gin.Context) {
 	}
 
 	// Check if is admin, and validate token
-	identity, isOK := auth.AuthenticateAdmin(token)
+	identity, isOK := authenticateAdmin(token, ScopePublish)
 
 	// If not valid return
 	if !isOK || !identity.CanUseAppID(appID) {
@@ -105,7 +146,7 @@ func PostEventHandler(context *gin.Context) {
 		channel, err = GetEngine().GetChannelRepository().GetAppChannel(appID, channelID)
 
 		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "HTTP Publish Channel; failed to get app channel %v\n", err)
+			logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Publish Channel; failed to get app channel")
 			writer.WriteHeader(http.StatusInternalServerError)
 			return
 		}
@@ -123,6 +164,20 @@ func PostEventHandler(context *gin.Context) {
 		return
 	}
 
+	if !GetAppLimits(appID).IsPayloadAllowed(channelPublishRequest.Payload) {
+		writeRejected(writer, http.StatusRequestEntityTooLarge, ReasonPayloadTooLarge)
+		return
+	}
+
+	if !AllowChannelPublish(appID, channelID) {
+		writeRejected(writer, http.StatusTooManyRequests, ReasonChannelRateLimited)
+		return
+	}
+
+	if !checkChannelEvent(writer, appID, channelID, channelPublishRequest.EventType, channelPublishRequest.Payload) {
+		return
+	}
+
 	event := &ChannelEvent{
 		SenderID:  identity.ClientID,
 		EventType: channelPublishRequest.EventType,
@@ -132,6 +187,14 @@ func PostEventHandler(context *gin.Context) {
 	}
 
 	if channel.Persistent {
+		event.ID, err = NextChannelEventID(appID, channelID)
+
+		if err != nil {
+			logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Publish Channel; failed to get event ID")
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
 		GetEngine().GetCacheStorage().StoreChannelEvent(channelID, appID, event)
 		GetEngine().StoreEvent(channel.AppID, event)
 	}
@@ -162,6 +225,133 @@ func PostEventHandler(context *gin.Context) {
 	writer.WriteHeader(http.StatusOK)
 }
 
+// PutEventHandler - Edit the payload of a stored channel event
+// PUT /channel/:channelID/event/:eventID
+func PutEventHandler(context *gin.Context) {
+
+	request := context.Request
+	writer := context.Writer
+
+	appID, event, isOK := getChangeableEvent(context)
+
+	if !isOK {
+		return
+	}
+
+	// Get request body
+	body, err := ioutil.ReadAll(request.Body)
+
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Parse body
+	var channelEditRequest channelEditRequest
+
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+	err = json.Unmarshal(body, &channelEditRequest)
+
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if !GetAppLimits(appID).IsPayloadAllowed(channelEditRequest.Payload) {
+		writeRejected(writer, http.StatusRequestEntityTooLarge, ReasonPayloadTooLarge)
+		return
+	}
+
+	if !checkChannelEvent(writer, appID, context.Params.ByName("channelID"), event.EventType, channelEditRequest.Payload) {
+		return
+	}
+
+	EditChannelEvent(appID, event, channelEditRequest.Payload)
+
+	writer.WriteHeader(http.StatusOK)
+}
+
+// DeleteEventHandler - Delete a stored channel event
+// DELETE /channel/:channelID/event/:eventID
+func DeleteEventHandler(context *gin.Context) {
+
+	appID, event, isOK := getChangeableEvent(context)
+
+	if !isOK {
+		return
+	}
+
+	DeleteChannelEvent(appID, event.ChannelID, event.ID)
+
+	context.Writer.WriteHeader(http.StatusOK)
+}
+
+// getChangeableEvent - Authenticate admin and get the requested event of an open persistent channel
+// If it fails the response status is written and false is returned
+func getChangeableEvent(context *gin.Context) (string, *ChannelEvent, bool) {
+
+	request := context.Request
+	writer := context.Writer
+
+	// Check for required headers
+	token, appID, isOK := auth.GetAuthData(request)
+
+	if !isOK {
+		writer.WriteHeader(http.StatusBadRequest)
+		return "", nil, false
+	}
+
+	// Check if is admin, and validate token
+	identity, isOK := authenticateAdmin(token, ScopePublish)
+
+	// If not valid return
+	if !isOK || !identity.CanUseAppID(appID) {
+		writer.WriteHeader(http.StatusUnauthorized)
+		return "", nil, false
+	}
+
+	// Get URL params
+	channelID := context.Params.ByName("channelID")
+	eventID, err := strconv.ParseUint(context.Params.ByName("eventID"), 10, 64)
+
+	if channelID == "" || err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return "", nil, false
+	}
+
+	channel, err := GetChannel(appID, channelID)
+
+	if err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		return "", nil, false
+	}
+
+	if channel == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return "", nil, false
+	}
+
+	// Only persistent channels store events
+	if channel.IsClosed || !channel.Persistent {
+		writer.WriteHeader(http.StatusBadRequest)
+		return "", nil, false
+	}
+
+	event, err := GetChannelEvent(appID, channelID, eventID)
+
+	if err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		return "", nil, false
+	}
+
+	if event == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return "", nil, false
+	}
+
+	return appID, event, true
+}
+
 // CreateChannelHandler - Create channel with given info
 // POST /channel
 func CreateChannelHandler(context *gin.Context) {
@@ -177,7 +367,7 @@ func CreateChannelHandler(context *gin.Context) {
 	}
 
 	// Check if is admin, and validate token
-	identity, isOK := auth.AuthenticateAdmin(token)
+	identity, isOK := authenticateAdmin(token, ScopeManageChannels)
 
 	// If not valid return
 	if !isOK || !identity.CanUseAppID(appID) {
@@ -220,7 +410,7 @@ func CreateChannelHandler(context *gin.Context) {
 	}
 
 	if isOK, err := CreateChannel(appID, &newChannel); err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Create Channel failed %v\n", err)
+		logger.WithField("AppID", appID).WithError(err).Error("HTTP Create Channel failed")
 		writer.WriteHeader(http.StatusInternalServerError)
 	} else if isOK {
 		writer.WriteHeader(http.StatusOK)
@@ -244,7 +434,7 @@ func PostJoinChannel(context *gin.Context) {
 	}
 
 	// Check if is admin, and validate token
-	identity, isOK := auth.AuthenticateAdmin(token)
+	identity, isOK := authenticateAdmin(token, ScopeManageChannels)
 
 	// If not valid return
 	if !isOK || !identity.CanUseAppID(appID) {
@@ -278,13 +468,13 @@ func PostJoinChannel(context *gin.Context) {
 	}
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Joun channel: failed to join client to channel %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "ClientID": clientID}).WithError(err).Error("Joun channel: failed to join client to channel")
 		writer.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
 	if isOK, err := JoinChannel(appID, channelID, clientID); err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Join channel: failed to join client %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "ClientID": clientID}).WithError(err).Error("HTTP Join channel: failed to join client")
 		writer.WriteHeader(http.StatusInternalServerError)
 	} else if isOK {
 		writer.WriteHeader(http.StatusOK)
@@ -309,7 +499,7 @@ func PostLeaveChannel(context *gin.Context) {
 	}
 
 	// Check if is admin, and validate token
-	identity, isOK := auth.AuthenticateAdmin(token)
+	identity, isOK := authenticateAdmin(token, ScopeManageChannels)
 
 	// If not valid return
 	if !isOK || !identity.CanUseAppID(appID) {
@@ -350,13 +540,13 @@ func PostLeaveChannel(context *gin.Context) {
 	}
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Leave channel: failed to remove client from channel %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "ClientID": clientID}).WithError(err).Error("Leave channel: failed to remove client from channel")
 		writer.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
 	if isOK, err := LeaveChannel(appID, channelID, clientID); err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Leave channel: remove client from channel %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "ClientID": clientID}).WithError(err).Error("HTTP Leave channel: remove client from channel")
 		writer.WriteHeader(http.StatusInternalServerError)
 	} else if isOK {
 		writer.WriteHeader(http.StatusOK)
@@ -381,7 +571,7 @@ func DeleteChannelHandler(context *gin.Context) {
 	}
 
 	// Check if is admin, and validate token
-	identity, isOK := auth.AuthenticateAdmin(token)
+	identity, isOK := authenticateAdmin(token, ScopeManageChannels)
 
 	// If not valid return
 	if !isOK || !identity.CanUseAppID(appID) {
@@ -398,7 +588,7 @@ func DeleteChannelHandler(context *gin.Context) {
 	}
 
 	if isOK, err := DeleteChannel(appID, channelID); err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Delete channel: failed to delete channel %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Delete channel: failed to delete channel")
 		writer.WriteHeader(http.StatusInternalServerError)
 	} else if isOK {
 		writer.WriteHeader(http.StatusOK)
@@ -422,7 +612,7 @@ func PostCloseChannel(context *gin.Context) {
 	}
 
 	// Check if is admin, and validate token
-	identity, isOK := auth.AuthenticateAdmin(token)
+	identity, isOK := authenticateAdmin(token, ScopeManageChannels)
 
 	// If not valid return
 	if !isOK || !identity.CanUseAppID(appID) {
@@ -439,7 +629,7 @@ func PostCloseChannel(context *gin.Context) {
 	}
 
 	if isOK, err := SetChannelCloseStatus(appID, channelID, true); err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Close channel: failed to close channel %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Close channel: failed to close channel")
 		writer.WriteHeader(http.StatusInternalServerError)
 	} else if isOK {
 		writer.WriteHeader(http.StatusOK)
@@ -463,7 +653,7 @@ func PostOpenChannel(context *gin.Context) {
 	}
 
 	// Check if is admin, and validate token
-	identity, isOK := auth.AuthenticateAdmin(token)
+	identity, isOK := authenticateAdmin(token, ScopeManageChannels)
 
 	// If not valid return
 	if !isOK || !identity.CanUseAppID(appID) {
@@ -480,7 +670,7 @@ func PostOpenChannel(context *gin.Context) {
 	}
 
 	if isOK, err := SetChannelCloseStatus(appID, channelID, false); err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Open channel: failed to open channel %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Open channel: failed to open channel")
 		writer.WriteHeader(http.StatusInternalServerError)
 	} else if isOK {
 		writer.WriteHeader(http.StatusOK)
@@ -490,9 +680,100 @@ func PostOpenChannel(context *gin.Context) {
 
 }
 
+// UpdateChannelHandler - Update channel name, extra and settings, missing fields are kept
+// PUT /channel/:channelID
+func UpdateChannelHandler(context *gin.Context) {
+	request := context.Request
+	writer := context.Writer
+
+	// Check for required headers
+	token, appID, isOK := auth.GetAuthData(request)
+
+	if !isOK {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Check if is admin, and validate token
+	identity, isOK := authenticateAdmin(token, ScopeManageChannels)
+
+	// If not valid return
+	if !isOK || !identity.CanUseAppID(appID) {
+		writer.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	channelID := context.Params.ByName("channelID")
+
+	if channelID == "" {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	body, err := ioutil.ReadAll(request.Body)
+
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var updateChannelRequest UpdateChannelRequest
+
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+	if err := json.Unmarshal(body, &updateChannelRequest); err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	channel, err := GetChannel(appID, channelID)
+
+	if err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Update channel: failed to get channel")
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if channel == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	// Don't change the cached channel in place
+	updated := *channel
+	updateChannelRequest.apply(&updated)
+
+	if err := UpdateChannel(appID, &updated); err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(&updated)
+
+	if err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Update channel: failed to marshal response")
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	_, _ = writer.Write(data)
+}
+
 // GetChannelsResponse - Channels response data holder
 type GetChannelsResponse struct {
-	Channels []*Channel `json:"channels"`
+	Channels   []*Channel `json:"channels"`
+	NextCursor string     `json:"nextCursor,omitempty"`
+}
+
+// setChannelsPage - Set the response channels fetched with one more than the limit, and the cursor of the next page if there is one
+func (response *GetChannelsResponse) setChannelsPage(channels []*Channel, limit int) {
+	response.Channels = channels
+
+	if len(channels) > limit {
+		response.Channels = channels[:limit]
+		last := channels[limit-1]
+		response.NextCursor = EncodeListCursor(&ListCursor{ID: last.ID, Name: last.Name})
+	}
 }
 
 //GetOpenChannels - Get all public channels
@@ -527,17 +808,29 @@ func GetOpenChannels(context *gin.Context) {
 		}
 	}
 
+	options, err := ParseListOptions(request.URL.Query())
+
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Fetch one more channel to know if there is a next page
+	limit := options.Limit
+	options.Limit++
+
 	var response GetChannelsResponse
 
 	// If AppID given, and user is authorized to use AppID
 	// Then fetch App specific channels
 	if appID != "" && identity.CanUseAppID(appID) {
 
-		if channels, err := GetEngine().GetChannelRepository().GetAppPublicChannels(appID); err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "HTTP Get open channels: failed to get app open channels %v\n", err)
+		if channels, err := GetEngine().GetChannelRepository().GetAppChannelsPage(appID, false, options); err != nil {
+			logger.WithField("AppID", appID).WithError(err).Error("HTTP Get open channels: failed to get app open channels")
 			writer.WriteHeader(http.StatusInternalServerError)
+			return
 		} else {
-			response.Channels = channels
+			response.setChannelsPage(channels, limit)
 		}
 
 		// Else if no given appID and user is SuperAdmin
@@ -551,7 +844,7 @@ func GetOpenChannels(context *gin.Context) {
 	data, err := json.Marshal(response)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Get open channels: failed to marshal response %v\n", err)
+		logger.WithField("AppID", appID).WithError(err).Error("HTTP Get open channels: failed to marshal response")
 		writer.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -583,28 +876,41 @@ func GetPrivateChannels(context *gin.Context) {
 		return
 	}
 
+	options, err := ParseListOptions(request.URL.Query())
+
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Fetch one more channel to know if there is a next page
+	limit := options.Limit
+	options.Limit++
+
 	var response GetChannelsResponse
 
 	// If AppID given, and user is authorized to use AppID, then check if user is admin
 	// Then fetch App specific channels
 	if appID != "" && identity.CanUseAppID(appID) && identity.IsAdminKind() {
 
-		if channels, err := GetEngine().GetChannelRepository().GetAppPrivateChannels(appID); err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "HTTP Get open channels: failed to get app open channels %v\n", err)
+		if channels, err := GetEngine().GetChannelRepository().GetAppChannelsPage(appID, true, options); err != nil {
+			logger.WithField("AppID", appID).WithError(err).Error("HTTP Get open channels: failed to get app open channels")
 			writer.WriteHeader(http.StatusInternalServerError)
+			return
 		} else {
-			response.Channels = channels
+			response.setChannelsPage(channels, limit)
 		}
 
 		// If AppID is given, and user is authorized to use AppID
 		// Then check if is client
 	} else if appID != "" && identity.CanUseAppID(appID) && identity.IsClient() {
 
-		if channels, err := GetEngine().GetChannelRepository().GetClientPrivateChannels(identity.ClientID); err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "HTTP Get open channels: failed to get app open channels %v\n", err)
+		if channels, err := GetEngine().GetChannelRepository().GetClientChannelsPage(identity.ClientID, true, options); err != nil {
+			logger.WithField("AppID", appID).WithError(err).Error("HTTP Get open channels: failed to get app open channels")
 			writer.WriteHeader(http.StatusInternalServerError)
+			return
 		} else {
-			response.Channels = channels
+			response.setChannelsPage(channels, limit)
 		}
 
 		// Else if no given appID and user is SuperAdmin
@@ -618,7 +924,7 @@ func GetPrivateChannels(context *gin.Context) {
 	data, err := json.Marshal(response)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Get open channels: failed to marshal response %v\n", err)
+		logger.WithField("AppID", appID).WithError(err).Error("HTTP Get open channels: failed to marshal response")
 		writer.WriteHeader(http.StatusInternalServerError)
 		return
 	}
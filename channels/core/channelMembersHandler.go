@@ -0,0 +1,261 @@
+package core
+
+import (
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/lisomatrix/channels/channels/auth"
+	log "github.com/sirupsen/logrus"
+)
+
+// ChannelMember - Channel client with its role and online status
+type ChannelMember struct {
+	ID       string      `json:"id"`
+	Username string      `json:"username"`
+	Extra    string      `json:"extra"`
+	Role     ChannelRole `json:"role"`
+	Online   bool        `json:"online"`
+	LastSeen int64       `json:"lastSeen,omitempty"` // Last presence in the channel
+}
+
+type getChannelMembersResponse struct {
+	Clients    []*ChannelMember `json:"clients"`
+	NextCursor string           `json:"nextCursor,omitempty"`
+}
+
+type channelClientsRequest struct {
+	Clients []string `json:"clients"`
+}
+
+type channelClientsResponse struct {
+	Clients []string `json:"clients"` // Clients that joined or left
+}
+
+type channelRoleRequest struct {
+	Role ChannelRole `json:"role"`
+}
+
+// GetChannelMembersHandler - Get a page of the channel clients with their online status
+// GET /c/:channelID/clients
+func GetChannelMembersHandler(context *gin.Context) {
+	request := context.Request
+	writer := context.Writer
+
+	_, appID, channelID, isOK := authorizeChannelRead(context)
+
+	if !isOK {
+		return
+	}
+
+	options, err := ParseListOptions(request.URL.Query())
+
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Fetch one more client to know if there is a next page
+	limit := options.Limit
+	options.Limit++
+
+	clients, err := GetEngine().GetChannelRepository().GetChannelClientsPage(appID, channelID, options)
+
+	if err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Get channel clients: failed to get channel clients")
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	var response getChannelMembersResponse
+
+	if len(clients) > limit {
+		clients = clients[:limit]
+		last := clients[limit-1]
+		response.NextCursor = EncodeListCursor(&ListCursor{ID: last.Client.ID, Name: last.Client.Username})
+	}
+
+	presences := GetEngine().GetPresence().GetChannelClientsPresence(appID, channelID)
+	response.Clients = make([]*ChannelMember, 0, len(clients))
+
+	for _, member := range clients {
+		response.Clients = append(response.Clients, &ChannelMember{
+			ID:       member.Client.ID,
+			Username: member.Client.Username,
+			Extra:    member.Client.Extra,
+			Role:     member.Role,
+			Online:   GetEngine().GetPresence().IsOnline(member.Client.ID),
+			LastSeen: presences[member.Client.ID],
+		})
+	}
+
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+	data, err := json.Marshal(response)
+
+	if err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Get channel clients: failed to marshal response")
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	_, _ = writer.Write(data)
+}
+
+// PostJoinChannelClients - Add a list of clients to channel in one transaction
+// POST /channel/:channelID/join
+func PostJoinChannelClients(context *gin.Context) {
+	handleChannelClients(context, "HTTP Join channel clients", JoinChannelClients)
+}
+
+// PostLeaveChannelClients - Remove a list of clients from channel in one transaction
+// POST /channel/:channelID/leave
+func PostLeaveChannelClients(context *gin.Context) {
+	handleChannelClients(context, "HTTP Leave channel clients", LeaveChannelClients)
+}
+
+// handleChannelClients - Authenticate admin, read the clients list and respond with the affected clients
+func handleChannelClients(context *gin.Context, logContext string, change func(appID string, channelID string, clientIDs []string) ([]string, bool, error)) {
+	request := context.Request
+	writer := context.Writer
+
+	// Check for required headers
+	token, appID, isOK := auth.GetAuthData(request)
+
+	if !isOK {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Check if is admin, and validate token
+	identity, isOK := authenticateAdmin(token, ScopeManageChannels)
+
+	// If not valid return
+	if !isOK || !identity.CanUseAppID(appID) {
+		writer.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	channelID := context.Params.ByName("channelID")
+
+	if channelID == "" {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	body, err := ioutil.ReadAll(request.Body)
+
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var clientsRequest channelClientsRequest
+
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+	if err := json.Unmarshal(body, &clientsRequest); err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if len(clientsRequest.Clients) == 0 || len(clientsRequest.Clients) > MaxBulkClients {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	clientIDs, found, err := change(appID, channelID, clientsRequest.Clients)
+
+	if err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error(logContext + ": failed to update channel clients")
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	data, err := json.Marshal(channelClientsResponse{Clients: clientIDs})
+
+	if err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error(logContext + ": failed to marshal response")
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	_, _ = writer.Write(data)
+}
+
+// PutChannelClientRole - Change the role of a client in the channel, by admins or the channel owners
+// PUT /channel/:channelID/role/:clientID
+func PutChannelClientRole(context *gin.Context) {
+	request := context.Request
+	writer := context.Writer
+
+	// Check for required headers
+	token, appID, isOK := auth.GetAuthData(request)
+
+	if !isOK {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Validate token
+	identity, isOK := verifyToken(token, ScopeManageChannels)
+
+	// If not valid return
+	if !isOK || !identity.CanUseAppID(appID) {
+		writer.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	channelID := context.Params.ByName("channelID")
+	clientID := context.Params.ByName("clientID")
+
+	if channelID == "" || clientID == "" {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	body, err := ioutil.ReadAll(request.Body)
+
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var roleRequest channelRoleRequest
+
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+	if err := json.Unmarshal(body, &roleRequest); err != nil || !roleRequest.Role.IsValid() {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if !identity.IsAdminKind() {
+		role, err := GetChannelClientRole(appID, channelID, identity.ClientID)
+
+		if err != nil {
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !role.CanManageRoles() {
+			writer.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+	}
+
+	isOK, err = SetChannelClientRole(appID, channelID, clientID, roleRequest.Role)
+
+	if err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+	} else if isOK {
+		writer.WriteHeader(http.StatusOK)
+	} else {
+		writer.WriteHeader(http.StatusNotFound)
+	}
+}
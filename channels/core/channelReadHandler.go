@@ -0,0 +1,183 @@
+package core
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/lisomatrix/channels/channels/auth"
+	log "github.com/sirupsen/logrus"
+)
+
+type getReadMarkerResponse struct {
+	ChannelID string `json:"channelID"`
+	ClientID  string `json:"clientID"`
+	EventID   uint64 `json:"eventID"`
+	Timestamp int64  `json:"timestamp"`
+	Unread    uint64 `json:"unread"`
+}
+
+type getReadMarkersResponse struct {
+	Receipts []*ReadMarker `json:"receipts"`
+}
+
+// GetReadMarkerHandler - Fetch the last event read by the client and how many events it didn't read yet
+// Admins can give the client with the clientID query param
+// GET /c/:channelID/read
+func GetReadMarkerHandler(context *gin.Context) {
+	writer := context.Writer
+
+	identity, appID, channelID, isOK := authorizeChannelRead(context)
+
+	if !isOK {
+		return
+	}
+
+	clientID := identity.ClientID
+
+	if identity.IsAdminKind() {
+		clientID = context.Query("clientID")
+	}
+
+	if clientID == "" {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	marker, err := GetEngine().GetChannelRepository().GetReadMarker(appID, channelID, clientID)
+
+	if err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "ClientID": clientID}).WithError(err).Error("HTTP Get read marker: failed to fetch read marker")
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	// Clients that never read the channel have every event unread
+	if marker == nil {
+		marker = &ReadMarker{ClientID: clientID}
+	}
+
+	unread, err := CountUnreadEvents(appID, channelID, clientID, marker.EventID)
+
+	if err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	response := getReadMarkerResponse{
+		ChannelID: channelID,
+		ClientID:  clientID,
+		EventID:   marker.EventID,
+		Timestamp: marker.Timestamp,
+		Unread:    unread,
+	}
+
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+	data, err := json.Marshal(response)
+
+	if err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "ClientID": clientID}).WithError(err).Error("HTTP Get read marker: failed to marshal response")
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(data)
+}
+
+// GetReadMarkersHandler - Fetch the last event read by each client of the channel
+// GET /c/:channelID/receipts
+func GetReadMarkersHandler(context *gin.Context) {
+	writer := context.Writer
+
+	_, appID, channelID, isOK := authorizeChannelRead(context)
+
+	if !isOK {
+		return
+	}
+
+	markers, err := GetEngine().GetChannelRepository().GetReadMarkers(appID, channelID)
+
+	if err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Get read markers: failed to fetch read markers")
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	response := getReadMarkersResponse{Receipts: markers}
+
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+	data, err := json.Marshal(response)
+
+	if err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Get read markers: failed to marshal response")
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(data)
+}
+
+// authorizeChannelRead - Validate the request and check the channel exists and the client is a member of it
+// Admins can read any channel of their app, the response is written when it fails
+func authorizeChannelRead(context *gin.Context) (*auth.Identity, string, string, bool) {
+	request := context.Request
+	writer := context.Writer
+
+	// Check for required headers
+	token, appID, isOK := auth.GetAuthData(request)
+
+	if !isOK {
+		writer.WriteHeader(http.StatusBadRequest)
+		return nil, "", "", false
+	}
+
+	// Validate token
+	identity, isOK := verifyToken(token, ScopeReadHistory)
+
+	// If not valid return
+	if !isOK || !identity.CanUseAppID(appID) {
+		writer.WriteHeader(http.StatusUnauthorized)
+		return nil, "", "", false
+	}
+
+	channelID := context.Params.ByName("channelID")
+
+	if channelID == "" {
+		writer.WriteHeader(http.StatusBadRequest)
+		return nil, "", "", false
+	}
+
+	// Check if channel exists
+	exists, err := GetEngine().GetChannelRepository().ExistsAppChannel(appID, channelID)
+
+	if err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Channel read: failed to check app channel existence")
+		writer.WriteHeader(http.StatusInternalServerError)
+		return nil, "", "", false
+	}
+
+	if !exists {
+		writer.WriteHeader(http.StatusNotFound)
+		return nil, "", "", false
+	}
+
+	if identity.IsAdminKind() {
+		return &identity, appID, channelID, true
+	}
+
+	isMember, err := IsChannelMember(appID, channelID, identity.ClientID)
+
+	if err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		return nil, "", "", false
+	}
+
+	if !isMember {
+		writer.WriteHeader(http.StatusUnauthorized)
+		return nil, "", "", false
+	}
+
+	return &identity, appID, channelID, true
+}
@@ -0,0 +1,36 @@
+package core
+
+// ChannelRole - Role of a client in a channel, admins can always do everything
+type ChannelRole string
+
+const (
+	ChannelRoleOwner     ChannelRole = "owner"     // Manages the channel members roles
+	ChannelRoleModerator ChannelRole = "moderator" // Edits and deletes events of others
+	ChannelRoleMember    ChannelRole = "member"    // Publishes, and edits and deletes own events, the default
+	ChannelRoleReadOnly  ChannelRole = "readonly"  // Only subscribes and reads
+)
+
+// IsValid - Check if it is one of the known roles
+func (role ChannelRole) IsValid() bool {
+	switch role {
+	case ChannelRoleOwner, ChannelRoleModerator, ChannelRoleMember, ChannelRoleReadOnly:
+		return true
+	}
+
+	return false
+}
+
+// CanPublish - Check if the role allows publishing, signaling and changing own events
+func (role ChannelRole) CanPublish() bool {
+	return role == ChannelRoleOwner || role == ChannelRoleModerator || role == ChannelRoleMember
+}
+
+// CanModerate - Check if the role allows editing and deleting events of other clients
+func (role ChannelRole) CanModerate() bool {
+	return role == ChannelRoleOwner || role == ChannelRoleModerator
+}
+
+// CanManageRoles - Check if the role allows changing the roles of the channel members
+func (role ChannelRole) CanManageRoles() bool {
+	return role == ChannelRoleOwner
+}
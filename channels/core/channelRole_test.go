@@ -0,0 +1,28 @@
+package core
+
+import "testing"
+
+func TestChannelRolePermissions(t *testing.T) {
+	cases := []struct {
+		role        ChannelRole
+		publish     bool
+		moderate    bool
+		manageRoles bool
+	}{
+		{ChannelRoleOwner, true, true, true},
+		{ChannelRoleModerator, true, true, false},
+		{ChannelRoleMember, true, false, false},
+		{ChannelRoleReadOnly, false, false, false},
+		{"", false, false, false},
+	}
+
+	for _, c := range cases {
+		if c.role.CanPublish() != c.publish || c.role.CanModerate() != c.moderate || c.role.CanManageRoles() != c.manageRoles {
+			t.Errorf("Unexpected permissions for role %q", c.role)
+		}
+	}
+
+	if ChannelRole("admin").IsValid() || ChannelRole("").IsValid() || !ChannelRoleReadOnly.IsValid() {
+		t.Error("Unexpected role validation")
+	}
+}
@@ -2,13 +2,12 @@ package core
 
 import (
     jsoniter "github.com/json-iterator/go"
-	"fmt"
 	"github.com/lisomatrix/channels/channels/auth"
 	"net/http"
-	"os"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
 )
 
 type getChannelEventsResponse struct {
@@ -30,7 +29,7 @@ func GetMessagesBetweenTimeStamps(context *gin.Context) {
 	}
 
 	// Validate token
-	identity, isOK := auth.VerifyToken(token)
+	identity, isOK := verifyToken(token, ScopeReadHistory)
 
 	// If not valid return
 	if !isOK || !identity.CanUseAppID(appID) {
@@ -57,14 +56,14 @@ func GetMessagesBetweenTimeStamps(context *gin.Context) {
 	secondTimeStamp, err := strconv.ParseInt(secondTimeStampStr, 10, 64)
 
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "HTTP Get messages between timestamps: failed convert timestamp %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Get messages between timestamps: failed convert timestamp")
 	}
 
 	// Check if channel exists
 	exists, err := GetEngine().GetChannelRepository().ExistsAppChannel(appID, channelID)
 
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "HTTP Get messages between timestamps: failed to check app channel existence %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Get messages between timestamps: failed to check app channel existence")
 		writer.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -78,7 +77,7 @@ func GetMessagesBetweenTimeStamps(context *gin.Context) {
 	events, err := GetEngine().GetChannelRepository().GetChannelEventsAfterAndBefore(appID, channelID, firstTimeStamp, secondTimeStamp)
 
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "HTTP Get messages between timestamps: failed fetch events %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Get messages between timestamps: failed fetch events")
 		writer.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -90,7 +89,7 @@ func GetMessagesBetweenTimeStamps(context *gin.Context) {
 	data, err := json.Marshal(response)
 
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "HTTP Get messages since timestamp: failed to marshal response %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Get messages since timestamp: failed to marshal response")
 		writer.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -114,7 +113,7 @@ func GetMessagesSinceTimeStamp(context *gin.Context) {
 	}
 
 	// Validate token
-	identity, isOK := auth.VerifyToken(token)
+	identity, isOK := verifyToken(token, ScopeReadHistory)
 
 	// If not valid return
 	if !isOK || !identity.CanUseAppID(appID) {
@@ -139,14 +138,14 @@ func GetMessagesSinceTimeStamp(context *gin.Context) {
 	lastTimeStamp, err := strconv.ParseInt(lastTimeStampStr, 10, 64)
 
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "HTTP Get messages since timestamp: failed convert timestamp %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Get messages since timestamp: failed convert timestamp")
 	}
 
 	// Check if channel exists
 	exists, err := GetEngine().GetChannelRepository().ExistsAppChannel(appID, channelID)
 
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "HTTP Get messages since timestamp: failed to check app channel existence %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Get messages since timestamp: failed to check app channel existence")
 		writer.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -160,7 +159,7 @@ func GetMessagesSinceTimeStamp(context *gin.Context) {
 	events, err := GetEngine().GetChannelRepository().GetChannelEventsAfter(appID, channelID, lastTimeStamp)
 
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "HTTP Get messages since timestamp: failed fetch events %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Get messages since timestamp: failed fetch events")
 		writer.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -172,7 +171,7 @@ func GetMessagesSinceTimeStamp(context *gin.Context) {
 	data, err := json.Marshal(response)
 
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "HTTP Get messages since timestamp: failed to marshal response %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Get messages since timestamp: failed to marshal response")
 		writer.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -196,7 +195,7 @@ func GetLastMessagesBeforeTimeStamp(context *gin.Context) {
 	}
 
 	// Validate token
-	identity, isOK := auth.VerifyToken(token)
+	identity, isOK := verifyToken(token, ScopeReadHistory)
 
 	// If not valid return
 	if !isOK || !identity.CanUseAppID(appID) {
@@ -221,7 +220,7 @@ func GetLastMessagesBeforeTimeStamp(context *gin.Context) {
 	amount, err := strconv.ParseInt(amountStr, 10, 64)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Get last messages since timestamp: failed convert amount %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Get last messages since timestamp: failed convert amount")
 	}
 
 	lastTimeStampStr := context.Params.ByName("lastTimeStamp")
@@ -234,14 +233,14 @@ func GetLastMessagesBeforeTimeStamp(context *gin.Context) {
 	lastTimeStamp, err := strconv.ParseInt(lastTimeStampStr, 10, 64)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Get last messages since timestamp: failed convert timestamp %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Get last messages since timestamp: failed convert timestamp")
 	}
 
 	// Check if channel exists
 	exists, err := GetEngine().GetChannelRepository().ExistsAppChannel(appID, channelID)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Get last messages since timestamp: failed to check app channel existence %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Get last messages since timestamp: failed to check app channel existence")
 		writer.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -254,7 +253,7 @@ func GetLastMessagesBeforeTimeStamp(context *gin.Context) {
 	events, err := GetEngine().GetChannelRepository().GetChannelLastEventsBefore(appID, channelID, amount, lastTimeStamp)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Get last messages since timestamp: failed fetch events %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Get last messages since timestamp: failed fetch events")
 		writer.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -266,7 +265,7 @@ func GetLastMessagesBeforeTimeStamp(context *gin.Context) {
 	data, err := json.Marshal(response)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Get last messages since timestamp: failed to marshal response %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Get last messages since timestamp: failed to marshal response")
 		writer.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -290,7 +289,7 @@ func GetLastMessagesSinceTimeStamp(context *gin.Context) {
 	}
 
 	// Validate token
-	identity, isOK := auth.VerifyToken(token)
+	identity, isOK := verifyToken(token, ScopeReadHistory)
 
 	// If not valid return
 	if !isOK || !identity.CanUseAppID(appID) {
@@ -315,7 +314,7 @@ func GetLastMessagesSinceTimeStamp(context *gin.Context) {
 	amount, err := strconv.ParseInt(amountStr, 10, 64)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Get last messages since timestamp: failed convert amount %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Get last messages since timestamp: failed convert amount")
 	}
 
 	lastTimeStampStr := context.Params.ByName("lastTimeStamp")
@@ -328,14 +327,14 @@ func GetLastMessagesSinceTimeStamp(context *gin.Context) {
 	lastTimeStamp, err := strconv.ParseInt(lastTimeStampStr, 10, 64)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Get last messages since timestamp: failed convert timestamp %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Get last messages since timestamp: failed convert timestamp")
 	}
 
 	// Check if channel exists
 	exists, err := GetEngine().GetChannelRepository().ExistsAppChannel(appID, channelID)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Get last messages since timestamp: failed to check app channel existence %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Get last messages since timestamp: failed to check app channel existence")
 		writer.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -348,7 +347,7 @@ func GetLastMessagesSinceTimeStamp(context *gin.Context) {
 	events, err := GetEngine().GetChannelRepository().GetChannelLastEventsAfter(appID, channelID, amount, lastTimeStamp)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Get last messages since timestamp: failed fetch events %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Get last messages since timestamp: failed fetch events")
 		writer.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -360,7 +359,7 @@ func GetLastMessagesSinceTimeStamp(context *gin.Context) {
 	data, err := json.Marshal(response)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Get last messages since timestamp: failed to marshal response %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Get last messages since timestamp: failed to marshal response")
 		writer.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -384,7 +383,7 @@ func GetLastMessages(context *gin.Context) {
 	}
 
 	// Validate token
-	identity, isOK := auth.VerifyToken(token)
+	identity, isOK := verifyToken(token, ScopeReadHistory)
 
 	// If not valid return
 	if !isOK || !identity.CanUseAppID(appID) {
@@ -409,7 +408,7 @@ func GetLastMessages(context *gin.Context) {
 	amount, err := strconv.ParseInt(amountStr, 10, 64)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Get last messages: failed convert amount %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Get last messages: failed convert amount")
 		writer.WriteHeader(http.StatusBadRequest)
 		return
 	}
@@ -418,7 +417,7 @@ func GetLastMessages(context *gin.Context) {
 	exists, err := GetEngine().GetChannelRepository().ExistsAppChannel(appID, channelID)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Get last messages: failed to check app channel existence %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Get last messages: failed to check app channel existence")
 		writer.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -439,7 +438,7 @@ func GetLastMessages(context *gin.Context) {
 			events, err = GetEngine().GetChannelRepository().GetChannelLastEvents(appID, channelID, amount)
 
 			if err != nil {
-				_, _ = fmt.Fprintf(os.Stderr, "HTTP Get last messages: failed fetch events %v\n", err)
+				logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Get last messages: failed fetch events")
 				writer.WriteHeader(http.StatusInternalServerError)
 				return
 			}
@@ -450,7 +449,7 @@ func GetLastMessages(context *gin.Context) {
 		events, err = GetEngine().GetChannelRepository().GetChannelLastEvents(appID, channelID, amount)
 
 		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "HTTP Get last messages: failed fetch events %v\n", err)
+			logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Get last messages: failed fetch events")
 			writer.WriteHeader(http.StatusInternalServerError)
 			return
 		}
@@ -463,7 +462,165 @@ func GetLastMessages(context *gin.Context) {
 	data, err := json.Marshal(response)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Get last messages: failed to marshal response %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Get last messages: failed to marshal response")
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	_, _ = writer.Write(data)
+}
+
+// GetMessagesAfterEventID - Fetch all messages after an event ID
+// GET /c/:channelID/after/:eventID
+func GetMessagesAfterEventID(context *gin.Context) {
+	request := context.Request
+	writer := context.Writer
+
+	// Check for required headers
+	token, appID, isOK := auth.GetAuthData(request)
+
+	if !isOK {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Validate token
+	identity, isOK := verifyToken(token, ScopeReadHistory)
+
+	// If not valid return
+	if !isOK || !identity.CanUseAppID(appID) {
+		writer.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	channelID := context.Params.ByName("channelID")
+
+	if channelID == "" {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	eventID, err := strconv.ParseUint(context.Params.ByName("eventID"), 10, 64)
+
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Check if channel exists
+	exists, err := GetEngine().GetChannelRepository().ExistsAppChannel(appID, channelID)
+
+	if err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Get messages after event ID: failed to check app channel existence")
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !exists {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	// Get events
+	events, err := GetEngine().GetChannelRepository().GetChannelEventsAfterID(appID, channelID, eventID)
+
+	if err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Get messages after event ID: failed fetch events")
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	// Prepare response
+	response := getChannelEventsResponse{Events: events}
+
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+	data, err := json.Marshal(response)
+
+	if err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Get messages after event ID: failed to marshal response")
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	_, _ = writer.Write(data)
+}
+
+// GetLastMessagesAfterEventID - Fetch a given amount of messages after an event ID, oldest first
+// GET /last/:channelID/:amount/after/:eventID
+func GetLastMessagesAfterEventID(context *gin.Context) {
+	request := context.Request
+	writer := context.Writer
+
+	// Check for required headers
+	token, appID, isOK := auth.GetAuthData(request)
+
+	if !isOK {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Validate token
+	identity, isOK := verifyToken(token, ScopeReadHistory)
+
+	// If not valid return
+	if !isOK || !identity.CanUseAppID(appID) {
+		writer.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	channelID := context.Params.ByName("channelID")
+
+	if channelID == "" {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	amount, err := strconv.ParseInt(context.Params.ByName("amount"), 10, 64)
+
+	if err != nil || amount <= 0 {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	eventID, err := strconv.ParseUint(context.Params.ByName("eventID"), 10, 64)
+
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Check if channel exists
+	exists, err := GetEngine().GetChannelRepository().ExistsAppChannel(appID, channelID)
+
+	if err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Get last messages after event ID: failed to check app channel existence")
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !exists {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	events, err := GetEngine().GetChannelRepository().GetChannelLastEventsAfterID(appID, channelID, amount, eventID)
+
+	if err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Get last messages after event ID: failed fetch events")
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	// Prepare response
+	response := getChannelEventsResponse{Events: events}
+
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+	data, err := json.Marshal(response)
+
+	if err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Get last messages after event ID: failed to marshal response")
 		writer.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -34,18 +34,30 @@ const (
 	NewEvent_ACK                   NewEvent_NewEventType = 6
 	NewEvent_ONLINE_STATUS         NewEvent_NewEventType = 7
 	NewEvent_INITIAL_ONLINE_STATUS NewEvent_NewEventType = 8
+	NewEvent_UNSUBSCRIBE           NewEvent_NewEventType = 9
+	NewEvent_EDIT                  NewEvent_NewEventType = 10
+	NewEvent_DELETE                NewEvent_NewEventType = 11
+	NewEvent_READ                  NewEvent_NewEventType = 12
+	NewEvent_SIGNAL                NewEvent_NewEventType = 13
+	NewEvent_DIRECT                NewEvent_NewEventType = 14
 )
 
 var NewEvent_NewEventType_name = map[int32]string{
-	0: "JOIN_CHANNEL",
-	1: "LEAVE_CHANNEL",
-	2: "NEW_CHANNEL",
-	3: "REMOVE_CHANNEL",
-	4: "SUBSCRIBE",
-	5: "PUBLISH",
-	6: "ACK",
-	7: "ONLINE_STATUS",
-	8: "INITIAL_ONLINE_STATUS",
+	0:  "JOIN_CHANNEL",
+	1:  "LEAVE_CHANNEL",
+	2:  "NEW_CHANNEL",
+	3:  "REMOVE_CHANNEL",
+	4:  "SUBSCRIBE",
+	5:  "PUBLISH",
+	6:  "ACK",
+	7:  "ONLINE_STATUS",
+	8:  "INITIAL_ONLINE_STATUS",
+	9:  "UNSUBSCRIBE",
+	10: "EDIT",
+	11: "DELETE",
+	12: "READ",
+	13: "SIGNAL",
+	14: "DIRECT",
 }
 
 var NewEvent_NewEventType_value = map[string]int32{
@@ -58,6 +70,12 @@ var NewEvent_NewEventType_value = map[string]int32{
 	"ACK":                   6,
 	"ONLINE_STATUS":         7,
 	"INITIAL_ONLINE_STATUS": 8,
+	"UNSUBSCRIBE":           9,
+	"EDIT":                  10,
+	"DELETE":                11,
+	"READ":                  12,
+	"SIGNAL":                13,
+	"DIRECT":                14,
 }
 
 func (x NewEvent_NewEventType) String() string {
@@ -65,7 +83,7 @@ func (x NewEvent_NewEventType) String() string {
 }
 
 func (NewEvent_NewEventType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_6eb5b11d5b15e5ec, []int{9, 0}
+	return fileDescriptor_6eb5b11d5b15e5ec, []int{17, 0}
 }
 
 type PublishRequest struct {
@@ -142,6 +160,7 @@ func (m *PublishRequest) GetPayload() string {
 type SubscribeRequest struct {
 	ChannelID            string   `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
 	ID                   uint32   `protobuf:"varint,2,opt,name=ID,proto3" json:"ID,omitempty"`
+	LastEventID          uint64   `protobuf:"varint,3,opt,name=lastEventID,proto3" json:"lastEventID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -194,26 +213,35 @@ func (m *SubscribeRequest) GetID() uint32 {
 	return 0
 }
 
-type PublishAck struct {
-	ReplyTo              uint32   `protobuf:"varint,1,opt,name=replyTo,proto3" json:"replyTo,omitempty"`
-	Status               bool     `protobuf:"varint,2,opt,name=status,proto3" json:"status,omitempty"`
+func (m *SubscribeRequest) GetLastEventID() uint64 {
+	if m != nil {
+		return m.LastEventID
+	}
+	return 0
+}
+
+type EditRequest struct {
+	ID                   uint32   `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	ChannelID            string   `protobuf:"bytes,2,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	EventID              uint64   `protobuf:"varint,3,opt,name=eventID,proto3" json:"eventID,omitempty"`
+	Payload              string   `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PublishAck) Reset()         { *m = PublishAck{} }
-func (m *PublishAck) String() string { return proto.CompactTextString(m) }
-func (*PublishAck) ProtoMessage()    {}
-func (*PublishAck) Descriptor() ([]byte, []int) {
+func (m *EditRequest) Reset()         { *m = EditRequest{} }
+func (m *EditRequest) String() string { return proto.CompactTextString(m) }
+func (*EditRequest) ProtoMessage()    {}
+func (*EditRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_6eb5b11d5b15e5ec, []int{2}
 }
-func (m *PublishAck) XXX_Unmarshal(b []byte) error {
+func (m *EditRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *PublishAck) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *EditRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_PublishAck.Marshal(b, m, deterministic)
+		return xxx_messageInfo_EditRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -223,55 +251,67 @@ func (m *PublishAck) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *PublishAck) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PublishAck.Merge(m, src)
+func (m *EditRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EditRequest.Merge(m, src)
 }
-func (m *PublishAck) XXX_Size() int {
+func (m *EditRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *PublishAck) XXX_DiscardUnknown() {
-	xxx_messageInfo_PublishAck.DiscardUnknown(m)
+func (m *EditRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EditRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PublishAck proto.InternalMessageInfo
+var xxx_messageInfo_EditRequest proto.InternalMessageInfo
 
-func (m *PublishAck) GetReplyTo() uint32 {
+func (m *EditRequest) GetID() uint32 {
 	if m != nil {
-		return m.ReplyTo
+		return m.ID
 	}
 	return 0
 }
 
-func (m *PublishAck) GetStatus() bool {
+func (m *EditRequest) GetChannelID() string {
 	if m != nil {
-		return m.Status
+		return m.ChannelID
 	}
-	return false
+	return ""
 }
 
-type ChannelEvent struct {
-	SenderID             string   `protobuf:"bytes,1,opt,name=senderID,proto3" json:"senderID,omitempty"`
-	EventType            string   `protobuf:"bytes,2,opt,name=eventType,proto3" json:"eventType,omitempty"`
-	Payload              string   `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
-	ChannelID            string   `protobuf:"bytes,4,opt,name=channelID,proto3" json:"channelID,omitempty"`
-	Timestamp            int64    `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+func (m *EditRequest) GetEventID() uint64 {
+	if m != nil {
+		return m.EventID
+	}
+	return 0
+}
+
+func (m *EditRequest) GetPayload() string {
+	if m != nil {
+		return m.Payload
+	}
+	return ""
+}
+
+type DeleteRequest struct {
+	ID                   uint32   `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	ChannelID            string   `protobuf:"bytes,2,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	EventID              uint64   `protobuf:"varint,3,opt,name=eventID,proto3" json:"eventID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ChannelEvent) Reset()         { *m = ChannelEvent{} }
-func (m *ChannelEvent) String() string { return proto.CompactTextString(m) }
-func (*ChannelEvent) ProtoMessage()    {}
-func (*ChannelEvent) Descriptor() ([]byte, []int) {
+func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
+func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteRequest) ProtoMessage()    {}
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_6eb5b11d5b15e5ec, []int{3}
 }
-func (m *ChannelEvent) XXX_Unmarshal(b []byte) error {
+func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ChannelEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *DeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ChannelEvent.Marshal(b, m, deterministic)
+		return xxx_messageInfo_DeleteRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -281,73 +321,60 @@ func (m *ChannelEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *ChannelEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ChannelEvent.Merge(m, src)
+func (m *DeleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteRequest.Merge(m, src)
 }
-func (m *ChannelEvent) XXX_Size() int {
+func (m *DeleteRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *ChannelEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_ChannelEvent.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_ChannelEvent proto.InternalMessageInfo
-
-func (m *ChannelEvent) GetSenderID() string {
-	if m != nil {
-		return m.SenderID
-	}
-	return ""
+func (m *DeleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteRequest.DiscardUnknown(m)
 }
 
-func (m *ChannelEvent) GetEventType() string {
-	if m != nil {
-		return m.EventType
-	}
-	return ""
-}
+var xxx_messageInfo_DeleteRequest proto.InternalMessageInfo
 
-func (m *ChannelEvent) GetPayload() string {
+func (m *DeleteRequest) GetID() uint32 {
 	if m != nil {
-		return m.Payload
+		return m.ID
 	}
-	return ""
+	return 0
 }
 
-func (m *ChannelEvent) GetChannelID() string {
+func (m *DeleteRequest) GetChannelID() string {
 	if m != nil {
 		return m.ChannelID
 	}
 	return ""
 }
 
-func (m *ChannelEvent) GetTimestamp() int64 {
+func (m *DeleteRequest) GetEventID() uint64 {
 	if m != nil {
-		return m.Timestamp
+		return m.EventID
 	}
 	return 0
 }
 
-type ClientStatus struct {
-	Status               bool     `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
-	Timestamp            int64    `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+type ReadRequest struct {
+	ID                   uint32   `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	ChannelID            string   `protobuf:"bytes,2,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	EventID              uint64   `protobuf:"varint,3,opt,name=eventID,proto3" json:"eventID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ClientStatus) Reset()         { *m = ClientStatus{} }
-func (m *ClientStatus) String() string { return proto.CompactTextString(m) }
-func (*ClientStatus) ProtoMessage()    {}
-func (*ClientStatus) Descriptor() ([]byte, []int) {
+func (m *ReadRequest) Reset()         { *m = ReadRequest{} }
+func (m *ReadRequest) String() string { return proto.CompactTextString(m) }
+func (*ReadRequest) ProtoMessage()    {}
+func (*ReadRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_6eb5b11d5b15e5ec, []int{4}
 }
-func (m *ClientStatus) XXX_Unmarshal(b []byte) error {
+func (m *ReadRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ClientStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ReadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ClientStatus.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ReadRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -357,52 +384,61 @@ func (m *ClientStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *ClientStatus) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ClientStatus.Merge(m, src)
+func (m *ReadRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadRequest.Merge(m, src)
 }
-func (m *ClientStatus) XXX_Size() int {
+func (m *ReadRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *ClientStatus) XXX_DiscardUnknown() {
-	xxx_messageInfo_ClientStatus.DiscardUnknown(m)
+func (m *ReadRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ClientStatus proto.InternalMessageInfo
+var xxx_messageInfo_ReadRequest proto.InternalMessageInfo
 
-func (m *ClientStatus) GetStatus() bool {
+func (m *ReadRequest) GetID() uint32 {
 	if m != nil {
-		return m.Status
+		return m.ID
 	}
-	return false
+	return 0
 }
 
-func (m *ClientStatus) GetTimestamp() int64 {
+func (m *ReadRequest) GetChannelID() string {
 	if m != nil {
-		return m.Timestamp
+		return m.ChannelID
+	}
+	return ""
+}
+
+func (m *ReadRequest) GetEventID() uint64 {
+	if m != nil {
+		return m.EventID
 	}
 	return 0
 }
 
-type InitialPresenceStatus struct {
-	ChannelID            string                   `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
-	ClientStatus         map[string]*ClientStatus `protobuf:"bytes,2,rep,name=clientStatus,proto3" json:"clientStatus,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
-	XXX_unrecognized     []byte                   `json:"-"`
-	XXX_sizecache        int32                    `json:"-"`
+type SignalRequest struct {
+	ID                   uint32   `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	ChannelID            string   `protobuf:"bytes,2,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	SignalType           string   `protobuf:"bytes,3,opt,name=signalType,proto3" json:"signalType,omitempty"`
+	Payload              string   `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *InitialPresenceStatus) Reset()         { *m = InitialPresenceStatus{} }
-func (m *InitialPresenceStatus) String() string { return proto.CompactTextString(m) }
-func (*InitialPresenceStatus) ProtoMessage()    {}
-func (*InitialPresenceStatus) Descriptor() ([]byte, []int) {
+func (m *SignalRequest) Reset()         { *m = SignalRequest{} }
+func (m *SignalRequest) String() string { return proto.CompactTextString(m) }
+func (*SignalRequest) ProtoMessage()    {}
+func (*SignalRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_6eb5b11d5b15e5ec, []int{5}
 }
-func (m *InitialPresenceStatus) XXX_Unmarshal(b []byte) error {
+func (m *SignalRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *InitialPresenceStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *SignalRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_InitialPresenceStatus.Marshal(b, m, deterministic)
+		return xxx_messageInfo_SignalRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -412,52 +448,68 @@ func (m *InitialPresenceStatus) XXX_Marshal(b []byte, deterministic bool) ([]byt
 		return b[:n], nil
 	}
 }
-func (m *InitialPresenceStatus) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_InitialPresenceStatus.Merge(m, src)
+func (m *SignalRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignalRequest.Merge(m, src)
 }
-func (m *InitialPresenceStatus) XXX_Size() int {
+func (m *SignalRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *InitialPresenceStatus) XXX_DiscardUnknown() {
-	xxx_messageInfo_InitialPresenceStatus.DiscardUnknown(m)
+func (m *SignalRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignalRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_InitialPresenceStatus proto.InternalMessageInfo
+var xxx_messageInfo_SignalRequest proto.InternalMessageInfo
 
-func (m *InitialPresenceStatus) GetChannelID() string {
+func (m *SignalRequest) GetID() uint32 {
+	if m != nil {
+		return m.ID
+	}
+	return 0
+}
+
+func (m *SignalRequest) GetChannelID() string {
 	if m != nil {
 		return m.ChannelID
 	}
 	return ""
 }
 
-func (m *InitialPresenceStatus) GetClientStatus() map[string]*ClientStatus {
+func (m *SignalRequest) GetSignalType() string {
 	if m != nil {
-		return m.ClientStatus
+		return m.SignalType
 	}
-	return nil
+	return ""
 }
 
-type ClientJoin struct {
-	ChannelID            string   `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+func (m *SignalRequest) GetPayload() string {
+	if m != nil {
+		return m.Payload
+	}
+	return ""
+}
+
+type DirectRequest struct {
+	ID                   uint32   `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
 	ClientID             string   `protobuf:"bytes,2,opt,name=clientID,proto3" json:"clientID,omitempty"`
+	EventType            string   `protobuf:"bytes,3,opt,name=eventType,proto3" json:"eventType,omitempty"`
+	Payload              string   `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ClientJoin) Reset()         { *m = ClientJoin{} }
-func (m *ClientJoin) String() string { return proto.CompactTextString(m) }
-func (*ClientJoin) ProtoMessage()    {}
-func (*ClientJoin) Descriptor() ([]byte, []int) {
+func (m *DirectRequest) Reset()         { *m = DirectRequest{} }
+func (m *DirectRequest) String() string { return proto.CompactTextString(m) }
+func (*DirectRequest) ProtoMessage()    {}
+func (*DirectRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_6eb5b11d5b15e5ec, []int{6}
 }
-func (m *ClientJoin) XXX_Unmarshal(b []byte) error {
+func (m *DirectRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ClientJoin) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *DirectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ClientJoin.Marshal(b, m, deterministic)
+		return xxx_messageInfo_DirectRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -467,52 +519,67 @@ func (m *ClientJoin) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *ClientJoin) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ClientJoin.Merge(m, src)
+func (m *DirectRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DirectRequest.Merge(m, src)
 }
-func (m *ClientJoin) XXX_Size() int {
+func (m *DirectRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *ClientJoin) XXX_DiscardUnknown() {
-	xxx_messageInfo_ClientJoin.DiscardUnknown(m)
+func (m *DirectRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DirectRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ClientJoin proto.InternalMessageInfo
+var xxx_messageInfo_DirectRequest proto.InternalMessageInfo
 
-func (m *ClientJoin) GetChannelID() string {
+func (m *DirectRequest) GetID() uint32 {
 	if m != nil {
-		return m.ChannelID
+		return m.ID
 	}
-	return ""
+	return 0
 }
 
-func (m *ClientJoin) GetClientID() string {
+func (m *DirectRequest) GetClientID() string {
 	if m != nil {
 		return m.ClientID
 	}
 	return ""
 }
 
-type ClientLeave struct {
-	ChannelID            string   `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
-	ClientID             string   `protobuf:"bytes,2,opt,name=clientID,proto3" json:"clientID,omitempty"`
+func (m *DirectRequest) GetEventType() string {
+	if m != nil {
+		return m.EventType
+	}
+	return ""
+}
+
+func (m *DirectRequest) GetPayload() string {
+	if m != nil {
+		return m.Payload
+	}
+	return ""
+}
+
+type PublishAck struct {
+	ReplyTo              uint32   `protobuf:"varint,1,opt,name=replyTo,proto3" json:"replyTo,omitempty"`
+	Status               bool     `protobuf:"varint,2,opt,name=status,proto3" json:"status,omitempty"`
+	Reason               string   `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ClientLeave) Reset()         { *m = ClientLeave{} }
-func (m *ClientLeave) String() string { return proto.CompactTextString(m) }
-func (*ClientLeave) ProtoMessage()    {}
-func (*ClientLeave) Descriptor() ([]byte, []int) {
+func (m *PublishAck) Reset()         { *m = PublishAck{} }
+func (m *PublishAck) String() string { return proto.CompactTextString(m) }
+func (*PublishAck) ProtoMessage()    {}
+func (*PublishAck) Descriptor() ([]byte, []int) {
 	return fileDescriptor_6eb5b11d5b15e5ec, []int{7}
 }
-func (m *ClientLeave) XXX_Unmarshal(b []byte) error {
+func (m *PublishAck) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ClientLeave) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *PublishAck) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ClientLeave.Marshal(b, m, deterministic)
+		return xxx_messageInfo_PublishAck.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -522,54 +589,63 @@ func (m *ClientLeave) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *ClientLeave) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ClientLeave.Merge(m, src)
+func (m *PublishAck) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublishAck.Merge(m, src)
 }
-func (m *ClientLeave) XXX_Size() int {
+func (m *PublishAck) XXX_Size() int {
 	return m.Size()
 }
-func (m *ClientLeave) XXX_DiscardUnknown() {
-	xxx_messageInfo_ClientLeave.DiscardUnknown(m)
+func (m *PublishAck) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublishAck.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ClientLeave proto.InternalMessageInfo
+var xxx_messageInfo_PublishAck proto.InternalMessageInfo
 
-func (m *ClientLeave) GetChannelID() string {
+func (m *PublishAck) GetReplyTo() uint32 {
 	if m != nil {
-		return m.ChannelID
+		return m.ReplyTo
 	}
-	return ""
+	return 0
 }
 
-func (m *ClientLeave) GetClientID() string {
+func (m *PublishAck) GetStatus() bool {
 	if m != nil {
-		return m.ClientID
+		return m.Status
+	}
+	return false
+}
+
+func (m *PublishAck) GetReason() string {
+	if m != nil {
+		return m.Reason
 	}
 	return ""
 }
 
-type OnlineStatusUpdate struct {
-	ChannelID            string   `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
-	ClientID             string   `protobuf:"bytes,2,opt,name=clientID,proto3" json:"clientID,omitempty"`
-	Status               bool     `protobuf:"varint,3,opt,name=status,proto3" json:"status,omitempty"`
-	Timestamp            int64    `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+type ChannelEvent struct {
+	SenderID             string   `protobuf:"bytes,1,opt,name=senderID,proto3" json:"senderID,omitempty"`
+	EventType            string   `protobuf:"bytes,2,opt,name=eventType,proto3" json:"eventType,omitempty"`
+	Payload              string   `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	ChannelID            string   `protobuf:"bytes,4,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	Timestamp            int64    `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	ID                   uint64   `protobuf:"varint,6,opt,name=ID,proto3" json:"ID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *OnlineStatusUpdate) Reset()         { *m = OnlineStatusUpdate{} }
-func (m *OnlineStatusUpdate) String() string { return proto.CompactTextString(m) }
-func (*OnlineStatusUpdate) ProtoMessage()    {}
-func (*OnlineStatusUpdate) Descriptor() ([]byte, []int) {
+func (m *ChannelEvent) Reset()         { *m = ChannelEvent{} }
+func (m *ChannelEvent) String() string { return proto.CompactTextString(m) }
+func (*ChannelEvent) ProtoMessage()    {}
+func (*ChannelEvent) Descriptor() ([]byte, []int) {
 	return fileDescriptor_6eb5b11d5b15e5ec, []int{8}
 }
-func (m *OnlineStatusUpdate) XXX_Unmarshal(b []byte) error {
+func (m *ChannelEvent) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *OnlineStatusUpdate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ChannelEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_OnlineStatusUpdate.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ChannelEvent.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -579,66 +655,80 @@ func (m *OnlineStatusUpdate) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *OnlineStatusUpdate) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_OnlineStatusUpdate.Merge(m, src)
+func (m *ChannelEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelEvent.Merge(m, src)
 }
-func (m *OnlineStatusUpdate) XXX_Size() int {
+func (m *ChannelEvent) XXX_Size() int {
 	return m.Size()
 }
-func (m *OnlineStatusUpdate) XXX_DiscardUnknown() {
-	xxx_messageInfo_OnlineStatusUpdate.DiscardUnknown(m)
+func (m *ChannelEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelEvent.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_OnlineStatusUpdate proto.InternalMessageInfo
+var xxx_messageInfo_ChannelEvent proto.InternalMessageInfo
 
-func (m *OnlineStatusUpdate) GetChannelID() string {
+func (m *ChannelEvent) GetSenderID() string {
 	if m != nil {
-		return m.ChannelID
+		return m.SenderID
 	}
 	return ""
 }
 
-func (m *OnlineStatusUpdate) GetClientID() string {
+func (m *ChannelEvent) GetEventType() string {
 	if m != nil {
-		return m.ClientID
+		return m.EventType
 	}
 	return ""
 }
 
-func (m *OnlineStatusUpdate) GetStatus() bool {
+func (m *ChannelEvent) GetPayload() string {
 	if m != nil {
-		return m.Status
+		return m.Payload
 	}
-	return false
+	return ""
 }
 
-func (m *OnlineStatusUpdate) GetTimestamp() int64 {
+func (m *ChannelEvent) GetChannelID() string {
+	if m != nil {
+		return m.ChannelID
+	}
+	return ""
+}
+
+func (m *ChannelEvent) GetTimestamp() int64 {
 	if m != nil {
 		return m.Timestamp
 	}
 	return 0
 }
 
-type NewEvent struct {
-	Type                 NewEvent_NewEventType `protobuf:"varint,1,opt,name=type,proto3,enum=NewEvent_NewEventType" json:"type,omitempty"`
-	Payload              []byte                `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+func (m *ChannelEvent) GetID() uint64 {
+	if m != nil {
+		return m.ID
+	}
+	return 0
 }
 
-func (m *NewEvent) Reset()         { *m = NewEvent{} }
-func (m *NewEvent) String() string { return proto.CompactTextString(m) }
-func (*NewEvent) ProtoMessage()    {}
-func (*NewEvent) Descriptor() ([]byte, []int) {
+type ChannelEventDelete struct {
+	ChannelID            string   `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	ID                   uint64   `protobuf:"varint,2,opt,name=ID,proto3" json:"ID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChannelEventDelete) Reset()         { *m = ChannelEventDelete{} }
+func (m *ChannelEventDelete) String() string { return proto.CompactTextString(m) }
+func (*ChannelEventDelete) ProtoMessage()    {}
+func (*ChannelEventDelete) Descriptor() ([]byte, []int) {
 	return fileDescriptor_6eb5b11d5b15e5ec, []int{9}
 }
-func (m *NewEvent) XXX_Unmarshal(b []byte) error {
+func (m *ChannelEventDelete) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *NewEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ChannelEventDelete) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_NewEvent.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ChannelEventDelete.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -648,52 +738,52 @@ func (m *NewEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *NewEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_NewEvent.Merge(m, src)
+func (m *ChannelEventDelete) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelEventDelete.Merge(m, src)
 }
-func (m *NewEvent) XXX_Size() int {
+func (m *ChannelEventDelete) XXX_Size() int {
 	return m.Size()
 }
-func (m *NewEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_NewEvent.DiscardUnknown(m)
+func (m *ChannelEventDelete) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelEventDelete.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_NewEvent proto.InternalMessageInfo
+var xxx_messageInfo_ChannelEventDelete proto.InternalMessageInfo
 
-func (m *NewEvent) GetType() NewEvent_NewEventType {
+func (m *ChannelEventDelete) GetChannelID() string {
 	if m != nil {
-		return m.Type
+		return m.ChannelID
 	}
-	return NewEvent_JOIN_CHANNEL
+	return ""
 }
 
-func (m *NewEvent) GetPayload() []byte {
+func (m *ChannelEventDelete) GetID() uint64 {
 	if m != nil {
-		return m.Payload
+		return m.ID
 	}
-	return nil
+	return 0
 }
 
-type Envelope struct {
-	IsMultiple           bool        `protobuf:"varint,1,opt,name=isMultiple,proto3" json:"isMultiple,omitempty"`
-	Events               []*NewEvent `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+type ClientStatus struct {
+	Status               bool     `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	Timestamp            int64    `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Envelope) Reset()         { *m = Envelope{} }
-func (m *Envelope) String() string { return proto.CompactTextString(m) }
-func (*Envelope) ProtoMessage()    {}
-func (*Envelope) Descriptor() ([]byte, []int) {
+func (m *ClientStatus) Reset()         { *m = ClientStatus{} }
+func (m *ClientStatus) String() string { return proto.CompactTextString(m) }
+func (*ClientStatus) ProtoMessage()    {}
+func (*ClientStatus) Descriptor() ([]byte, []int) {
 	return fileDescriptor_6eb5b11d5b15e5ec, []int{10}
 }
-func (m *Envelope) XXX_Unmarshal(b []byte) error {
+func (m *ClientStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Envelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ClientStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Envelope.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ClientStatus.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -703,528 +793,611 @@ func (m *Envelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *Envelope) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Envelope.Merge(m, src)
+func (m *ClientStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClientStatus.Merge(m, src)
 }
-func (m *Envelope) XXX_Size() int {
+func (m *ClientStatus) XXX_Size() int {
 	return m.Size()
 }
-func (m *Envelope) XXX_DiscardUnknown() {
-	xxx_messageInfo_Envelope.DiscardUnknown(m)
+func (m *ClientStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClientStatus.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Envelope proto.InternalMessageInfo
+var xxx_messageInfo_ClientStatus proto.InternalMessageInfo
 
-func (m *Envelope) GetIsMultiple() bool {
+func (m *ClientStatus) GetStatus() bool {
 	if m != nil {
-		return m.IsMultiple
+		return m.Status
 	}
 	return false
 }
 
-func (m *Envelope) GetEvents() []*NewEvent {
+func (m *ClientStatus) GetTimestamp() int64 {
 	if m != nil {
-		return m.Events
+		return m.Timestamp
 	}
-	return nil
+	return 0
 }
 
-func init() {
-	proto.RegisterEnum("NewEvent_NewEventType", NewEvent_NewEventType_name, NewEvent_NewEventType_value)
-	proto.RegisterType((*PublishRequest)(nil), "PublishRequest")
-	proto.RegisterType((*SubscribeRequest)(nil), "SubscribeRequest")
-	proto.RegisterType((*PublishAck)(nil), "PublishAck")
-	proto.RegisterType((*ChannelEvent)(nil), "ChannelEvent")
-	proto.RegisterType((*ClientStatus)(nil), "ClientStatus")
-	proto.RegisterType((*InitialPresenceStatus)(nil), "InitialPresenceStatus")
-	proto.RegisterMapType((map[string]*ClientStatus)(nil), "InitialPresenceStatus.ClientStatusEntry")
-	proto.RegisterType((*ClientJoin)(nil), "ClientJoin")
-	proto.RegisterType((*ClientLeave)(nil), "ClientLeave")
-	proto.RegisterType((*OnlineStatusUpdate)(nil), "OnlineStatusUpdate")
-	proto.RegisterType((*NewEvent)(nil), "NewEvent")
-	proto.RegisterType((*Envelope)(nil), "Envelope")
+type InitialPresenceStatus struct {
+	ChannelID            string                   `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	ClientStatus         map[string]*ClientStatus `protobuf:"bytes,2,rep,name=clientStatus,proto3" json:"clientStatus,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
 }
 
-func init() { proto.RegisterFile("channels.proto", fileDescriptor_6eb5b11d5b15e5ec) }
-
-var fileDescriptor_6eb5b11d5b15e5ec = []byte{
-	// 623 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0xcb, 0x6e, 0xd3, 0x40,
-	0x14, 0xed, 0xd8, 0x69, 0x1e, 0x37, 0x0f, 0xdc, 0x91, 0x5a, 0x99, 0xaa, 0x8a, 0x82, 0xd9, 0x44,
-	0x2c, 0xb2, 0x28, 0x1b, 0xc4, 0x02, 0x91, 0x87, 0xa1, 0x2e, 0xae, 0x53, 0x8d, 0x13, 0x58, 0x56,
-	0x4e, 0x3a, 0x52, 0xad, 0xba, 0x63, 0x63, 0x8f, 0x83, 0xb2, 0xe7, 0x23, 0x58, 0xf1, 0x03, 0xfc,
-	0x08, 0x4b, 0x24, 0x7e, 0x00, 0x95, 0xff, 0x40, 0xc8, 0xcf, 0xd8, 0xe1, 0xd1, 0x45, 0x77, 0xbe,
-	0xf7, 0xcc, 0x3d, 0xf7, 0x9c, 0x3b, 0x77, 0x0c, 0x9d, 0xe5, 0x95, 0xc5, 0x18, 0x75, 0x82, 0x81,
-	0xe7, 0xbb, 0xdc, 0x55, 0x56, 0xd0, 0x39, 0x0f, 0x17, 0x8e, 0x1d, 0x5c, 0x11, 0xfa, 0x3e, 0xa4,
-	0x01, 0xc7, 0x1d, 0x10, 0xb4, 0x89, 0x8c, 0x7a, 0xa8, 0xdf, 0x26, 0x82, 0x36, 0xc1, 0x47, 0xd0,
-	0xa0, 0x2b, 0xca, 0xf8, 0x6c, 0xed, 0x51, 0x59, 0xe8, 0xa1, 0x7e, 0x83, 0x6c, 0x12, 0x11, 0x9a,
-	0x32, 0x6a, 0x13, 0x59, 0x4c, 0xd0, 0x3c, 0x81, 0x65, 0xa8, 0x79, 0xd6, 0xda, 0x71, 0xad, 0x4b,
-	0xb9, 0x12, 0x63, 0x59, 0xa8, 0xbc, 0x04, 0xc9, 0x0c, 0x17, 0xc1, 0xd2, 0xb7, 0x17, 0x34, 0xeb,
-	0x5c, 0xe2, 0x42, 0xdb, 0x5c, 0x89, 0x2e, 0x21, 0xd3, 0xa5, 0xbc, 0x00, 0x48, 0x95, 0x0f, 0x97,
-	0xd7, 0x51, 0x27, 0x9f, 0x7a, 0xce, 0x7a, 0xe6, 0xa6, 0xd2, 0xb3, 0x10, 0x1f, 0x40, 0x35, 0xe0,
-	0x16, 0x0f, 0x83, 0xb8, 0xb6, 0x4e, 0xd2, 0x48, 0xf9, 0x8c, 0xa0, 0x35, 0x4e, 0xd8, 0xd5, 0xc8,
-	0x0e, 0x3e, 0x84, 0x7a, 0x40, 0xd9, 0x25, 0xf5, 0xf3, 0xee, 0x79, 0x7c, 0xc7, 0x10, 0x0a, 0x36,
-	0xc5, 0x92, 0xcd, 0xb2, 0xa5, 0xca, 0xb6, 0xa5, 0x23, 0x68, 0x70, 0xfb, 0x86, 0x06, 0xdc, 0xba,
-	0xf1, 0xe4, 0xdd, 0x1e, 0xea, 0x8b, 0x64, 0x93, 0x50, 0x26, 0xd0, 0x1a, 0x3b, 0x36, 0x65, 0xdc,
-	0x8c, 0x05, 0x17, 0x8c, 0xa0, 0xa2, 0x91, 0x32, 0x8b, 0xb0, 0xcd, 0xf2, 0x1d, 0xc1, 0xbe, 0xc6,
-	0x6c, 0x6e, 0x5b, 0xce, 0xb9, 0x4f, 0x03, 0xca, 0x96, 0xd4, 0xcc, 0xeb, 0xfe, 0x33, 0x6e, 0x1d,
-	0x5a, 0xcb, 0x42, 0x77, 0x59, 0xe8, 0x89, 0xfd, 0xe6, 0x71, 0x7f, 0xf0, 0x57, 0xae, 0x41, 0x51,
-	0xa8, 0xca, 0xb8, 0xbf, 0x26, 0xa5, 0xea, 0x43, 0x03, 0xf6, 0xfe, 0x38, 0x82, 0x25, 0x10, 0xaf,
-	0xe9, 0x3a, 0x6d, 0x1d, 0x7d, 0xe2, 0xc7, 0xb0, 0xbb, 0xb2, 0x9c, 0x30, 0x19, 0x71, 0xf3, 0xb8,
-	0x5d, 0xe2, 0x25, 0x09, 0xf6, 0x5c, 0x78, 0x86, 0x94, 0x57, 0x00, 0x09, 0x74, 0xea, 0xda, 0xec,
-	0x0e, 0x27, 0x87, 0x50, 0x4f, 0xb4, 0xa4, 0xeb, 0xd3, 0x20, 0x79, 0xac, 0xbc, 0x86, 0x66, 0xc2,
-	0xa3, 0x53, 0x6b, 0x45, 0xef, 0x41, 0xf4, 0x11, 0x01, 0x9e, 0x32, 0xc7, 0x66, 0xe9, 0x44, 0xe6,
-	0xde, 0xa5, 0xc5, 0xef, 0x41, 0x58, 0xb8, 0x6d, 0xf1, 0xdf, 0xb7, 0x5d, 0xd9, 0xbe, 0xed, 0x5f,
-	0x08, 0xea, 0x06, 0xfd, 0x90, 0x2c, 0xf4, 0x13, 0xa8, 0xf0, 0x68, 0x5f, 0xa3, 0xbe, 0x9d, 0xe3,
-	0x83, 0x41, 0x06, 0xe4, 0x1f, 0xd1, 0xf2, 0x92, 0xf8, 0x4c, 0x71, 0x85, 0x23, 0x25, 0xad, 0xcd,
-	0x4b, 0xfd, 0x82, 0xa0, 0x55, 0x2c, 0xc0, 0x12, 0xb4, 0x4e, 0xa7, 0x9a, 0x71, 0x31, 0x3e, 0x19,
-	0x1a, 0x86, 0xaa, 0x4b, 0x3b, 0x78, 0x0f, 0xda, 0xba, 0x3a, 0x7c, 0xab, 0xe6, 0x29, 0x84, 0x1f,
-	0x40, 0xd3, 0x50, 0xdf, 0xe5, 0x09, 0x01, 0x63, 0xe8, 0x10, 0xf5, 0x6c, 0x5a, 0x38, 0x24, 0xe2,
-	0x36, 0x34, 0xcc, 0xf9, 0xc8, 0x1c, 0x13, 0x6d, 0xa4, 0x4a, 0x15, 0xdc, 0x84, 0xda, 0xf9, 0x7c,
-	0xa4, 0x6b, 0xe6, 0x89, 0xb4, 0x8b, 0x6b, 0x20, 0x0e, 0xc7, 0x6f, 0xa4, 0x6a, 0x44, 0x3e, 0x35,
-	0x74, 0xcd, 0x50, 0x2f, 0xcc, 0xd9, 0x70, 0x36, 0x37, 0xa5, 0x1a, 0x7e, 0x08, 0xfb, 0x9a, 0xa1,
-	0xcd, 0xb4, 0xa1, 0x7e, 0x51, 0x86, 0xea, 0xca, 0x19, 0xd4, 0x55, 0xb6, 0xa2, 0x8e, 0xeb, 0x51,
-	0xdc, 0x05, 0xb0, 0x83, 0xb3, 0xd0, 0xe1, 0xb6, 0xe7, 0xd0, 0xf4, 0xd1, 0x14, 0x32, 0xf8, 0x11,
-	0x54, 0xe3, 0x37, 0x9c, 0x2d, 0x77, 0x23, 0x1f, 0x0c, 0x49, 0x81, 0x91, 0xf4, 0xf5, 0xb6, 0x8b,
-	0xbe, 0xdd, 0x76, 0xd1, 0x8f, 0xdb, 0x2e, 0xfa, 0xf4, 0xb3, 0xbb, 0xb3, 0xa8, 0xc6, 0xff, 0xcd,
-	0xa7, 0xbf, 0x03, 0x00, 0x00, 0xff, 0xff, 0x6b, 0x96, 0x6b, 0xd7, 0x49, 0x05, 0x00, 0x00,
+func (m *InitialPresenceStatus) Reset()         { *m = InitialPresenceStatus{} }
+func (m *InitialPresenceStatus) String() string { return proto.CompactTextString(m) }
+func (*InitialPresenceStatus) ProtoMessage()    {}
+func (*InitialPresenceStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6eb5b11d5b15e5ec, []int{11}
 }
-
-func (m *PublishRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *InitialPresenceStatus) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *InitialPresenceStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_InitialPresenceStatus.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
 }
-
-func (m *PublishRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *InitialPresenceStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InitialPresenceStatus.Merge(m, src)
+}
+func (m *InitialPresenceStatus) XXX_Size() int {
+	return m.Size()
+}
+func (m *InitialPresenceStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_InitialPresenceStatus.DiscardUnknown(m)
 }
 
-func (m *PublishRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.Payload) > 0 {
-		i -= len(m.Payload)
-		copy(dAtA[i:], m.Payload)
-		i = encodeVarintChannels(dAtA, i, uint64(len(m.Payload)))
-		i--
-		dAtA[i] = 0x22
-	}
-	if len(m.ChannelID) > 0 {
-		i -= len(m.ChannelID)
-		copy(dAtA[i:], m.ChannelID)
-		i = encodeVarintChannels(dAtA, i, uint64(len(m.ChannelID)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.EventType) > 0 {
-		i -= len(m.EventType)
-		copy(dAtA[i:], m.EventType)
-		i = encodeVarintChannels(dAtA, i, uint64(len(m.EventType)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.ID != 0 {
-		i = encodeVarintChannels(dAtA, i, uint64(m.ID))
-		i--
-		dAtA[i] = 0x8
+var xxx_messageInfo_InitialPresenceStatus proto.InternalMessageInfo
+
+func (m *InitialPresenceStatus) GetChannelID() string {
+	if m != nil {
+		return m.ChannelID
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
 
-func (m *SubscribeRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *InitialPresenceStatus) GetClientStatus() map[string]*ClientStatus {
+	if m != nil {
+		return m.ClientStatus
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *SubscribeRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type ClientJoin struct {
+	ChannelID            string   `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	ClientID             string   `protobuf:"bytes,2,opt,name=clientID,proto3" json:"clientID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *SubscribeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.ID != 0 {
-		i = encodeVarintChannels(dAtA, i, uint64(m.ID))
-		i--
-		dAtA[i] = 0x10
+func (m *ClientJoin) Reset()         { *m = ClientJoin{} }
+func (m *ClientJoin) String() string { return proto.CompactTextString(m) }
+func (*ClientJoin) ProtoMessage()    {}
+func (*ClientJoin) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6eb5b11d5b15e5ec, []int{12}
+}
+func (m *ClientJoin) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ClientJoin) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ClientJoin.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if len(m.ChannelID) > 0 {
-		i -= len(m.ChannelID)
-		copy(dAtA[i:], m.ChannelID)
-		i = encodeVarintChannels(dAtA, i, uint64(len(m.ChannelID)))
-		i--
-		dAtA[i] = 0xa
+}
+func (m *ClientJoin) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClientJoin.Merge(m, src)
+}
+func (m *ClientJoin) XXX_Size() int {
+	return m.Size()
+}
+func (m *ClientJoin) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClientJoin.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClientJoin proto.InternalMessageInfo
+
+func (m *ClientJoin) GetChannelID() string {
+	if m != nil {
+		return m.ChannelID
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
 
-func (m *PublishAck) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ClientJoin) GetClientID() string {
+	if m != nil {
+		return m.ClientID
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *PublishAck) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type ClientLeave struct {
+	ChannelID            string   `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	ClientID             string   `protobuf:"bytes,2,opt,name=clientID,proto3" json:"clientID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PublishAck) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Status {
-		i--
-		if m.Status {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
+func (m *ClientLeave) Reset()         { *m = ClientLeave{} }
+func (m *ClientLeave) String() string { return proto.CompactTextString(m) }
+func (*ClientLeave) ProtoMessage()    {}
+func (*ClientLeave) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6eb5b11d5b15e5ec, []int{13}
+}
+func (m *ClientLeave) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ClientLeave) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ClientLeave.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x10
+		return b[:n], nil
 	}
-	if m.ReplyTo != 0 {
-		i = encodeVarintChannels(dAtA, i, uint64(m.ReplyTo))
-		i--
-		dAtA[i] = 0x8
+}
+func (m *ClientLeave) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClientLeave.Merge(m, src)
+}
+func (m *ClientLeave) XXX_Size() int {
+	return m.Size()
+}
+func (m *ClientLeave) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClientLeave.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClientLeave proto.InternalMessageInfo
+
+func (m *ClientLeave) GetChannelID() string {
+	if m != nil {
+		return m.ChannelID
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
 
-func (m *ChannelEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ClientLeave) GetClientID() string {
+	if m != nil {
+		return m.ClientID
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *ChannelEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type OnlineStatusUpdate struct {
+	ChannelID            string   `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	ClientID             string   `protobuf:"bytes,2,opt,name=clientID,proto3" json:"clientID,omitempty"`
+	Status               bool     `protobuf:"varint,3,opt,name=status,proto3" json:"status,omitempty"`
+	Timestamp            int64    `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ChannelEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Timestamp != 0 {
-		i = encodeVarintChannels(dAtA, i, uint64(m.Timestamp))
-		i--
-		dAtA[i] = 0x28
-	}
-	if len(m.ChannelID) > 0 {
-		i -= len(m.ChannelID)
-		copy(dAtA[i:], m.ChannelID)
-		i = encodeVarintChannels(dAtA, i, uint64(len(m.ChannelID)))
-		i--
-		dAtA[i] = 0x22
+func (m *OnlineStatusUpdate) Reset()         { *m = OnlineStatusUpdate{} }
+func (m *OnlineStatusUpdate) String() string { return proto.CompactTextString(m) }
+func (*OnlineStatusUpdate) ProtoMessage()    {}
+func (*OnlineStatusUpdate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6eb5b11d5b15e5ec, []int{14}
+}
+func (m *OnlineStatusUpdate) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *OnlineStatusUpdate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_OnlineStatusUpdate.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if len(m.Payload) > 0 {
-		i -= len(m.Payload)
-		copy(dAtA[i:], m.Payload)
-		i = encodeVarintChannels(dAtA, i, uint64(len(m.Payload)))
-		i--
-		dAtA[i] = 0x1a
+}
+func (m *OnlineStatusUpdate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OnlineStatusUpdate.Merge(m, src)
+}
+func (m *OnlineStatusUpdate) XXX_Size() int {
+	return m.Size()
+}
+func (m *OnlineStatusUpdate) XXX_DiscardUnknown() {
+	xxx_messageInfo_OnlineStatusUpdate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OnlineStatusUpdate proto.InternalMessageInfo
+
+func (m *OnlineStatusUpdate) GetChannelID() string {
+	if m != nil {
+		return m.ChannelID
 	}
-	if len(m.EventType) > 0 {
-		i -= len(m.EventType)
-		copy(dAtA[i:], m.EventType)
-		i = encodeVarintChannels(dAtA, i, uint64(len(m.EventType)))
-		i--
-		dAtA[i] = 0x12
+	return ""
+}
+
+func (m *OnlineStatusUpdate) GetClientID() string {
+	if m != nil {
+		return m.ClientID
 	}
-	if len(m.SenderID) > 0 {
-		i -= len(m.SenderID)
-		copy(dAtA[i:], m.SenderID)
-		i = encodeVarintChannels(dAtA, i, uint64(len(m.SenderID)))
-		i--
-		dAtA[i] = 0xa
+	return ""
+}
+
+func (m *OnlineStatusUpdate) GetStatus() bool {
+	if m != nil {
+		return m.Status
 	}
-	return len(dAtA) - i, nil
+	return false
 }
 
-func (m *ClientStatus) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *OnlineStatusUpdate) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *ClientStatus) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type ReadReceipt struct {
+	ChannelID            string   `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	ClientID             string   `protobuf:"bytes,2,opt,name=clientID,proto3" json:"clientID,omitempty"`
+	EventID              uint64   `protobuf:"varint,3,opt,name=eventID,proto3" json:"eventID,omitempty"`
+	Timestamp            int64    `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ClientStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Timestamp != 0 {
-		i = encodeVarintChannels(dAtA, i, uint64(m.Timestamp))
-		i--
-		dAtA[i] = 0x10
-	}
-	if m.Status {
-		i--
-		if m.Status {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
+func (m *ReadReceipt) Reset()         { *m = ReadReceipt{} }
+func (m *ReadReceipt) String() string { return proto.CompactTextString(m) }
+func (*ReadReceipt) ProtoMessage()    {}
+func (*ReadReceipt) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6eb5b11d5b15e5ec, []int{15}
+}
+func (m *ReadReceipt) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReadReceipt) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReadReceipt.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x8
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *ReadReceipt) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadReceipt.Merge(m, src)
+}
+func (m *ReadReceipt) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReadReceipt) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadReceipt.DiscardUnknown(m)
 }
 
-func (m *InitialPresenceStatus) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ReadReceipt proto.InternalMessageInfo
+
+func (m *ReadReceipt) GetChannelID() string {
+	if m != nil {
+		return m.ChannelID
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *InitialPresenceStatus) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ReadReceipt) GetClientID() string {
+	if m != nil {
+		return m.ClientID
+	}
+	return ""
 }
 
-func (m *InitialPresenceStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.ClientStatus) > 0 {
-		for k := range m.ClientStatus {
-			v := m.ClientStatus[k]
-			baseI := i
-			if v != nil {
-				{
-					size, err := v.MarshalToSizedBuffer(dAtA[:i])
-					if err != nil {
-						return 0, err
-					}
-					i -= size
-					i = encodeVarintChannels(dAtA, i, uint64(size))
-				}
-				i--
-				dAtA[i] = 0x12
-			}
-			i -= len(k)
-			copy(dAtA[i:], k)
-			i = encodeVarintChannels(dAtA, i, uint64(len(k)))
-			i--
-			dAtA[i] = 0xa
-			i = encodeVarintChannels(dAtA, i, uint64(baseI-i))
-			i--
-			dAtA[i] = 0x12
-		}
-	}
-	if len(m.ChannelID) > 0 {
-		i -= len(m.ChannelID)
-		copy(dAtA[i:], m.ChannelID)
-		i = encodeVarintChannels(dAtA, i, uint64(len(m.ChannelID)))
-		i--
-		dAtA[i] = 0xa
+func (m *ReadReceipt) GetEventID() uint64 {
+	if m != nil {
+		return m.EventID
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *ClientJoin) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ReadReceipt) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *ClientJoin) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type ChannelSignal struct {
+	SenderID             string   `protobuf:"bytes,1,opt,name=senderID,proto3" json:"senderID,omitempty"`
+	ChannelID            string   `protobuf:"bytes,2,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	SignalType           string   `protobuf:"bytes,3,opt,name=signalType,proto3" json:"signalType,omitempty"`
+	Payload              string   `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+	Timestamp            int64    `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ClientJoin) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.ClientID) > 0 {
-		i -= len(m.ClientID)
-		copy(dAtA[i:], m.ClientID)
-		i = encodeVarintChannels(dAtA, i, uint64(len(m.ClientID)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.ChannelID) > 0 {
-		i -= len(m.ChannelID)
-		copy(dAtA[i:], m.ChannelID)
-		i = encodeVarintChannels(dAtA, i, uint64(len(m.ChannelID)))
-		i--
-		dAtA[i] = 0xa
+func (m *ChannelSignal) Reset()         { *m = ChannelSignal{} }
+func (m *ChannelSignal) String() string { return proto.CompactTextString(m) }
+func (*ChannelSignal) ProtoMessage()    {}
+func (*ChannelSignal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6eb5b11d5b15e5ec, []int{16}
+}
+func (m *ChannelSignal) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ChannelSignal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ChannelSignal.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *ChannelSignal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelSignal.Merge(m, src)
+}
+func (m *ChannelSignal) XXX_Size() int {
+	return m.Size()
+}
+func (m *ChannelSignal) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelSignal.DiscardUnknown(m)
 }
 
-func (m *ClientLeave) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ChannelSignal proto.InternalMessageInfo
+
+func (m *ChannelSignal) GetSenderID() string {
+	if m != nil {
+		return m.SenderID
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *ClientLeave) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ChannelSignal) GetChannelID() string {
+	if m != nil {
+		return m.ChannelID
+	}
+	return ""
 }
 
-func (m *ClientLeave) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.ClientID) > 0 {
-		i -= len(m.ClientID)
-		copy(dAtA[i:], m.ClientID)
-		i = encodeVarintChannels(dAtA, i, uint64(len(m.ClientID)))
-		i--
-		dAtA[i] = 0x12
+func (m *ChannelSignal) GetSignalType() string {
+	if m != nil {
+		return m.SignalType
 	}
-	if len(m.ChannelID) > 0 {
-		i -= len(m.ChannelID)
-		copy(dAtA[i:], m.ChannelID)
-		i = encodeVarintChannels(dAtA, i, uint64(len(m.ChannelID)))
-		i--
-		dAtA[i] = 0xa
+	return ""
+}
+
+func (m *ChannelSignal) GetPayload() string {
+	if m != nil {
+		return m.Payload
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
 
-func (m *OnlineStatusUpdate) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ChannelSignal) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *OnlineStatusUpdate) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type NewEvent struct {
+	Type                 NewEvent_NewEventType `protobuf:"varint,1,opt,name=type,proto3,enum=NewEvent_NewEventType" json:"type,omitempty"`
+	Payload              []byte                `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
-func (m *OnlineStatusUpdate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Timestamp != 0 {
-		i = encodeVarintChannels(dAtA, i, uint64(m.Timestamp))
-		i--
-		dAtA[i] = 0x20
-	}
-	if m.Status {
-		i--
-		if m.Status {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
+func (m *NewEvent) Reset()         { *m = NewEvent{} }
+func (m *NewEvent) String() string { return proto.CompactTextString(m) }
+func (*NewEvent) ProtoMessage()    {}
+func (*NewEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6eb5b11d5b15e5ec, []int{17}
+}
+func (m *NewEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *NewEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_NewEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x18
+		return b[:n], nil
 	}
-	if len(m.ClientID) > 0 {
-		i -= len(m.ClientID)
-		copy(dAtA[i:], m.ClientID)
-		i = encodeVarintChannels(dAtA, i, uint64(len(m.ClientID)))
-		i--
-		dAtA[i] = 0x12
+}
+func (m *NewEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewEvent.Merge(m, src)
+}
+func (m *NewEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *NewEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewEvent proto.InternalMessageInfo
+
+func (m *NewEvent) GetType() NewEvent_NewEventType {
+	if m != nil {
+		return m.Type
 	}
-	if len(m.ChannelID) > 0 {
-		i -= len(m.ChannelID)
-		copy(dAtA[i:], m.ChannelID)
-		i = encodeVarintChannels(dAtA, i, uint64(len(m.ChannelID)))
-		i--
-		dAtA[i] = 0xa
+	return NewEvent_JOIN_CHANNEL
+}
+
+func (m *NewEvent) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *NewEvent) Marshal() (dAtA []byte, err error) {
+type Envelope struct {
+	IsMultiple           bool        `protobuf:"varint,1,opt,name=isMultiple,proto3" json:"isMultiple,omitempty"`
+	Events               []*NewEvent `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *Envelope) Reset()         { *m = Envelope{} }
+func (m *Envelope) String() string { return proto.CompactTextString(m) }
+func (*Envelope) ProtoMessage()    {}
+func (*Envelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6eb5b11d5b15e5ec, []int{18}
+}
+func (m *Envelope) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Envelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Envelope.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Envelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Envelope.Merge(m, src)
+}
+func (m *Envelope) XXX_Size() int {
+	return m.Size()
+}
+func (m *Envelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_Envelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Envelope proto.InternalMessageInfo
+
+func (m *Envelope) GetIsMultiple() bool {
+	if m != nil {
+		return m.IsMultiple
+	}
+	return false
+}
+
+func (m *Envelope) GetEvents() []*NewEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("NewEvent_NewEventType", NewEvent_NewEventType_name, NewEvent_NewEventType_value)
+	proto.RegisterType((*PublishRequest)(nil), "PublishRequest")
+	proto.RegisterType((*SubscribeRequest)(nil), "SubscribeRequest")
+	proto.RegisterType((*EditRequest)(nil), "EditRequest")
+	proto.RegisterType((*DeleteRequest)(nil), "DeleteRequest")
+	proto.RegisterType((*ReadRequest)(nil), "ReadRequest")
+	proto.RegisterType((*SignalRequest)(nil), "SignalRequest")
+	proto.RegisterType((*DirectRequest)(nil), "DirectRequest")
+	proto.RegisterType((*PublishAck)(nil), "PublishAck")
+	proto.RegisterType((*ChannelEvent)(nil), "ChannelEvent")
+	proto.RegisterType((*ChannelEventDelete)(nil), "ChannelEventDelete")
+	proto.RegisterType((*ClientStatus)(nil), "ClientStatus")
+	proto.RegisterType((*InitialPresenceStatus)(nil), "InitialPresenceStatus")
+	proto.RegisterMapType((map[string]*ClientStatus)(nil), "InitialPresenceStatus.ClientStatusEntry")
+	proto.RegisterType((*ClientJoin)(nil), "ClientJoin")
+	proto.RegisterType((*ClientLeave)(nil), "ClientLeave")
+	proto.RegisterType((*OnlineStatusUpdate)(nil), "OnlineStatusUpdate")
+	proto.RegisterType((*ReadReceipt)(nil), "ReadReceipt")
+	proto.RegisterType((*ChannelSignal)(nil), "ChannelSignal")
+	proto.RegisterType((*NewEvent)(nil), "NewEvent")
+	proto.RegisterType((*Envelope)(nil), "Envelope")
+}
+
+func init() { proto.RegisterFile("channels.proto", fileDescriptor_6eb5b11d5b15e5ec) }
+
+var fileDescriptor_6eb5b11d5b15e5ec = []byte{
+	// 823 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xcd, 0x8e, 0xa3, 0x46,
+	0x10, 0xde, 0x06, 0xc6, 0x3f, 0x85, 0x71, 0xd8, 0x96, 0x76, 0xe5, 0xac, 0x56, 0x96, 0x43, 0x2e,
+	0x56, 0x0e, 0x3e, 0x4c, 0x2e, 0x51, 0x6e, 0xd8, 0x74, 0x76, 0xd9, 0x30, 0x78, 0x04, 0x78, 0xf7,
+	0x38, 0xc2, 0xb8, 0x95, 0x45, 0xcb, 0x00, 0x81, 0xb6, 0x47, 0x3e, 0xe5, 0x92, 0x87, 0xc8, 0x35,
+	0x2f, 0x90, 0xd7, 0x48, 0x8e, 0x91, 0xf2, 0x02, 0xd1, 0xe4, 0x15, 0xf2, 0x00, 0x11, 0x3f, 0xc6,
+	0xe0, 0x8c, 0x3d, 0x51, 0x66, 0xf6, 0xe6, 0xaa, 0xea, 0xfe, 0xbe, 0x8f, 0xfa, 0xaa, 0x4b, 0x86,
+	0xbe, 0xf7, 0xde, 0x0d, 0x43, 0x1a, 0xa4, 0x93, 0x38, 0x89, 0x58, 0xa4, 0x6c, 0xa0, 0x7f, 0xb9,
+	0x5e, 0x06, 0x7e, 0xfa, 0xde, 0xa2, 0xdf, 0xaf, 0x69, 0xca, 0x70, 0x1f, 0x38, 0x5d, 0x1b, 0xa0,
+	0x11, 0x1a, 0x4b, 0x16, 0xa7, 0x6b, 0xf8, 0x25, 0x74, 0xe9, 0x86, 0x86, 0xcc, 0xd9, 0xc6, 0x74,
+	0xc0, 0x8d, 0xd0, 0xb8, 0x6b, 0xed, 0x13, 0x59, 0xb5, 0x44, 0xd4, 0xb5, 0x01, 0x5f, 0x54, 0xab,
+	0x04, 0x1e, 0x40, 0x3b, 0x76, 0xb7, 0x41, 0xe4, 0xae, 0x06, 0x42, 0x5e, 0xdb, 0x85, 0xca, 0x12,
+	0x64, 0x7b, 0xbd, 0x4c, 0xbd, 0xc4, 0x5f, 0xd2, 0x1d, 0x73, 0x03, 0x0b, 0x1d, 0x62, 0x15, 0xba,
+	0xb8, 0x4a, 0xd7, 0x08, 0xc4, 0xc0, 0x4d, 0x19, 0xc9, 0xa4, 0x94, 0xdc, 0x82, 0x55, 0x4f, 0x29,
+	0x11, 0x88, 0x64, 0xe5, 0xb3, 0x13, 0x1f, 0xb6, 0xa7, 0xe3, 0xee, 0x90, 0x4e, 0x1b, 0xd0, 0xbb,
+	0xf0, 0xc4, 0x47, 0xbd, 0x03, 0x49, 0xa3, 0x01, 0x65, 0xf4, 0x91, 0x29, 0x95, 0x05, 0x88, 0x16,
+	0x75, 0x57, 0x8f, 0x0d, 0x7b, 0x03, 0x92, 0xed, 0x7f, 0x17, 0xba, 0xc1, 0xff, 0x03, 0x1e, 0x02,
+	0xa4, 0xf9, 0xf5, 0x7c, 0x34, 0x0a, 0xf3, 0x6b, 0x99, 0x13, 0x8d, 0x4a, 0x41, 0xd2, 0xfc, 0x84,
+	0x7a, 0x47, 0xbd, 0x79, 0x01, 0x1d, 0x2f, 0xf0, 0x0b, 0xd1, 0x05, 0x6f, 0x15, 0x37, 0x07, 0x92,
+	0x3f, 0x1c, 0xc8, 0xe3, 0xa4, 0x6f, 0x01, 0xca, 0x51, 0x57, 0xbd, 0x0f, 0xd9, 0xb9, 0x84, 0xc6,
+	0xc1, 0xd6, 0x89, 0x4a, 0xda, 0x5d, 0x88, 0x9f, 0x43, 0x2b, 0x65, 0x2e, 0x5b, 0xa7, 0x39, 0x73,
+	0xc7, 0x2a, 0xa3, 0x2c, 0x9f, 0x50, 0x37, 0x8d, 0xc2, 0x92, 0xb4, 0x8c, 0x94, 0x5f, 0x10, 0xf4,
+	0x66, 0x45, 0x53, 0xf2, 0xc9, 0xcb, 0xc4, 0xa7, 0x34, 0x5c, 0xd1, 0xa4, 0x1a, 0xe3, 0x2a, 0xbe,
+	0xe7, 0x35, 0xd5, 0xc4, 0xf3, 0x0d, 0xf1, 0x4d, 0x27, 0x84, 0x43, 0x27, 0x5e, 0x42, 0x97, 0xf9,
+	0xd7, 0x34, 0x65, 0xee, 0x75, 0x3c, 0x38, 0x1b, 0xa1, 0x31, 0x6f, 0xed, 0x13, 0x65, 0x73, 0x5b,
+	0xb9, 0xf7, 0x9c, 0xae, 0x29, 0x53, 0xc0, 0x75, 0xbd, 0xc5, 0xc8, 0xfe, 0xe7, 0xd7, 0x57, 0x60,
+	0x68, 0xd0, 0x9b, 0xe5, 0x86, 0xd8, 0x55, 0x73, 0xca, 0xa6, 0xa1, 0x46, 0xd3, 0x1a, 0xca, 0xb8,
+	0x03, 0x65, 0xca, 0x1f, 0x08, 0x9e, 0xe9, 0xa1, 0xcf, 0x7c, 0x37, 0xb8, 0x4c, 0x68, 0x4a, 0x43,
+	0x8f, 0xda, 0xd5, 0xbd, 0x13, 0x6a, 0x0c, 0xe8, 0x79, 0x35, 0xf6, 0x01, 0x37, 0xe2, 0xc7, 0xe2,
+	0xf9, 0x78, 0x72, 0x27, 0xd6, 0xa4, 0x2e, 0x94, 0x84, 0x2c, 0xd9, 0x5a, 0x8d, 0xdb, 0x2f, 0x4c,
+	0x78, 0xfa, 0xaf, 0x23, 0x58, 0x06, 0xfe, 0x03, 0xdd, 0x96, 0xd4, 0xd9, 0x4f, 0xfc, 0x39, 0x9c,
+	0x6d, 0xdc, 0x60, 0x5d, 0xd8, 0x26, 0x9e, 0x4b, 0x0d, 0x5c, 0xab, 0xa8, 0x7d, 0xcd, 0x7d, 0x85,
+	0x94, 0x6f, 0x00, 0x8a, 0xd2, 0x9b, 0xc8, 0x0f, 0xef, 0xf9, 0x92, 0x13, 0x83, 0xae, 0xbc, 0x02,
+	0xb1, 0xc0, 0x31, 0xa8, 0xbb, 0xa1, 0x0f, 0x00, 0xfa, 0x11, 0x01, 0x9e, 0x87, 0x81, 0x1f, 0x96,
+	0x1d, 0x59, 0xc4, 0x2b, 0x97, 0x3d, 0x00, 0xb0, 0xe6, 0x36, 0x7f, 0xdc, 0x6d, 0xe1, 0xd0, 0xed,
+	0x1f, 0x76, 0x5b, 0xcc, 0xa3, 0x7e, 0xcc, 0x1e, 0x40, 0x7f, 0x7c, 0x37, 0x9f, 0x16, 0xf0, 0x33,
+	0x02, 0xa9, 0x9c, 0xfc, 0x62, 0xef, 0xdd, 0xf7, 0x54, 0x3f, 0xc6, 0xf2, 0x3b, 0xfd, 0x58, 0x95,
+	0x5f, 0x39, 0xe8, 0x98, 0xf4, 0xa6, 0xd8, 0x24, 0x5f, 0x80, 0xc0, 0x32, 0xf8, 0x4c, 0x5a, 0xff,
+	0xfc, 0xf9, 0x64, 0x57, 0xa8, 0x7e, 0x64, 0x54, 0x96, 0xc0, 0x0e, 0x08, 0x33, 0xb1, 0xbd, 0xfd,
+	0xe2, 0xfb, 0x1b, 0x41, 0xaf, 0x7e, 0x01, 0xcb, 0xd0, 0x7b, 0x33, 0xd7, 0xcd, 0xab, 0xd9, 0x6b,
+	0xd5, 0x34, 0x89, 0x21, 0x3f, 0xc1, 0x4f, 0x41, 0x32, 0x88, 0xfa, 0x96, 0x54, 0x29, 0x84, 0x3f,
+	0x01, 0xd1, 0x24, 0xef, 0xaa, 0x04, 0x87, 0x31, 0xf4, 0x2d, 0x72, 0x31, 0xaf, 0x1d, 0xe2, 0xb1,
+	0x04, 0x5d, 0x7b, 0x31, 0xb5, 0x67, 0x96, 0x3e, 0x25, 0xb2, 0x80, 0x45, 0x68, 0x5f, 0x2e, 0xa6,
+	0x86, 0x6e, 0xbf, 0x96, 0xcf, 0x70, 0x1b, 0x78, 0x75, 0xf6, 0xad, 0xdc, 0xca, 0xc0, 0xe7, 0xa6,
+	0xa1, 0x9b, 0xe4, 0xca, 0x76, 0x54, 0x67, 0x61, 0xcb, 0x6d, 0xfc, 0x29, 0x3c, 0xd3, 0x4d, 0xdd,
+	0xd1, 0x55, 0xe3, 0xaa, 0x59, 0xea, 0x64, 0xbc, 0x0b, 0x73, 0x0f, 0xda, 0xc5, 0x1d, 0x10, 0x88,
+	0xa6, 0x3b, 0x32, 0x60, 0x80, 0x96, 0x46, 0x0c, 0xe2, 0x10, 0x59, 0xcc, 0xb2, 0x16, 0x51, 0x35,
+	0xb9, 0x97, 0x65, 0x6d, 0xfd, 0x95, 0xa9, 0x1a, 0xb2, 0x94, 0x9f, 0xd0, 0x2d, 0x32, 0x73, 0xe4,
+	0xbe, 0x72, 0x01, 0x1d, 0x12, 0x6e, 0x68, 0x10, 0xc5, 0x34, 0x73, 0xcb, 0x4f, 0x2f, 0xd6, 0x01,
+	0xf3, 0xe3, 0x80, 0x96, 0x2b, 0xaa, 0x96, 0xc1, 0x9f, 0x41, 0x2b, 0x1f, 0xa1, 0xdd, 0x2a, 0xe9,
+	0x56, 0x1d, 0xb6, 0xca, 0xc2, 0x54, 0xfe, 0xed, 0x76, 0x88, 0x7e, 0xbf, 0x1d, 0xa2, 0x3f, 0x6f,
+	0x87, 0xe8, 0xa7, 0xbf, 0x86, 0x4f, 0x96, 0xad, 0xfc, 0x2f, 0xd4, 0x97, 0xff, 0x0c, 0x00, 0xa1,
+	0x2f, 0x1d, 0xa0, 0x54, 0x09, 0x00, 0x00,
+}
+
+func (m *PublishRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1234,12 +1407,12 @@ func (m *NewEvent) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *NewEvent) MarshalTo(dAtA []byte) (int, error) {
+func (m *PublishRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *NewEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *PublishRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -1253,17 +1426,31 @@ func (m *NewEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		copy(dAtA[i:], m.Payload)
 		i = encodeVarintChannels(dAtA, i, uint64(len(m.Payload)))
 		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.ChannelID) > 0 {
+		i -= len(m.ChannelID)
+		copy(dAtA[i:], m.ChannelID)
+		i = encodeVarintChannels(dAtA, i, uint64(len(m.ChannelID)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.EventType) > 0 {
+		i -= len(m.EventType)
+		copy(dAtA[i:], m.EventType)
+		i = encodeVarintChannels(dAtA, i, uint64(len(m.EventType)))
+		i--
 		dAtA[i] = 0x12
 	}
-	if m.Type != 0 {
-		i = encodeVarintChannels(dAtA, i, uint64(m.Type))
+	if m.ID != 0 {
+		i = encodeVarintChannels(dAtA, i, uint64(m.ID))
 		i--
 		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *Envelope) Marshal() (dAtA []byte, err error) {
+func (m *SubscribeRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1273,12 +1460,12 @@ func (m *Envelope) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Envelope) MarshalTo(dAtA []byte) (int, error) {
+func (m *SubscribeRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Envelope) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *SubscribeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -1287,299 +1474,2477 @@ func (m *Envelope) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Events) > 0 {
-		for iNdEx := len(m.Events) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Events[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintChannels(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x12
-		}
+	if m.LastEventID != 0 {
+		i = encodeVarintChannels(dAtA, i, uint64(m.LastEventID))
+		i--
+		dAtA[i] = 0x18
 	}
-	if m.IsMultiple {
+	if m.ID != 0 {
+		i = encodeVarintChannels(dAtA, i, uint64(m.ID))
 		i--
-		if m.IsMultiple {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
+		dAtA[i] = 0x10
+	}
+	if len(m.ChannelID) > 0 {
+		i -= len(m.ChannelID)
+		copy(dAtA[i:], m.ChannelID)
+		i = encodeVarintChannels(dAtA, i, uint64(len(m.ChannelID)))
 		i--
-		dAtA[i] = 0x8
+		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintChannels(dAtA []byte, offset int, v uint64) int {
-	offset -= sovChannels(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *EditRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *PublishRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+
+func (m *EditRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EditRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.ID != 0 {
-		n += 1 + sovChannels(uint64(m.ID))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	l = len(m.EventType)
-	if l > 0 {
-		n += 1 + l + sovChannels(uint64(l))
+	if len(m.Payload) > 0 {
+		i -= len(m.Payload)
+		copy(dAtA[i:], m.Payload)
+		i = encodeVarintChannels(dAtA, i, uint64(len(m.Payload)))
+		i--
+		dAtA[i] = 0x22
 	}
-	l = len(m.ChannelID)
-	if l > 0 {
-		n += 1 + l + sovChannels(uint64(l))
+	if m.EventID != 0 {
+		i = encodeVarintChannels(dAtA, i, uint64(m.EventID))
+		i--
+		dAtA[i] = 0x18
 	}
-	l = len(m.Payload)
-	if l > 0 {
-		n += 1 + l + sovChannels(uint64(l))
+	if len(m.ChannelID) > 0 {
+		i -= len(m.ChannelID)
+		copy(dAtA[i:], m.ChannelID)
+		i = encodeVarintChannels(dAtA, i, uint64(len(m.ChannelID)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if m.ID != 0 {
+		i = encodeVarintChannels(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *SubscribeRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *DeleteRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeleteRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.ChannelID)
-	if l > 0 {
-		n += 1 + l + sovChannels(uint64(l))
-	}
-	if m.ID != 0 {
-		n += 1 + sovChannels(uint64(m.ID))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	if m.EventID != 0 {
+		i = encodeVarintChannels(dAtA, i, uint64(m.EventID))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.ChannelID) > 0 {
+		i -= len(m.ChannelID)
+		copy(dAtA[i:], m.ChannelID)
+		i = encodeVarintChannels(dAtA, i, uint64(len(m.ChannelID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ID != 0 {
+		i = encodeVarintChannels(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *PublishAck) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ReadRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ReadRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReadRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.ReplyTo != 0 {
-		n += 1 + sovChannels(uint64(m.ReplyTo))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Status {
-		n += 2
+	if m.EventID != 0 {
+		i = encodeVarintChannels(dAtA, i, uint64(m.EventID))
+		i--
+		dAtA[i] = 0x18
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.ChannelID) > 0 {
+		i -= len(m.ChannelID)
+		copy(dAtA[i:], m.ChannelID)
+		i = encodeVarintChannels(dAtA, i, uint64(len(m.ChannelID)))
+		i--
+		dAtA[i] = 0x12
 	}
-	return n
+	if m.ID != 0 {
+		i = encodeVarintChannels(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *ChannelEvent) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *SignalRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *SignalRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SignalRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.SenderID)
-	if l > 0 {
-		n += 1 + l + sovChannels(uint64(l))
-	}
-	l = len(m.EventType)
-	if l > 0 {
-		n += 1 + l + sovChannels(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	l = len(m.Payload)
-	if l > 0 {
-		n += 1 + l + sovChannels(uint64(l))
+	if len(m.Payload) > 0 {
+		i -= len(m.Payload)
+		copy(dAtA[i:], m.Payload)
+		i = encodeVarintChannels(dAtA, i, uint64(len(m.Payload)))
+		i--
+		dAtA[i] = 0x22
 	}
-	l = len(m.ChannelID)
-	if l > 0 {
-		n += 1 + l + sovChannels(uint64(l))
+	if len(m.SignalType) > 0 {
+		i -= len(m.SignalType)
+		copy(dAtA[i:], m.SignalType)
+		i = encodeVarintChannels(dAtA, i, uint64(len(m.SignalType)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	if m.Timestamp != 0 {
-		n += 1 + sovChannels(uint64(m.Timestamp))
+	if len(m.ChannelID) > 0 {
+		i -= len(m.ChannelID)
+		copy(dAtA[i:], m.ChannelID)
+		i = encodeVarintChannels(dAtA, i, uint64(len(m.ChannelID)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if m.ID != 0 {
+		i = encodeVarintChannels(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *ClientStatus) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *DirectRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *DirectRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DirectRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Status {
-		n += 2
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Timestamp != 0 {
-		n += 1 + sovChannels(uint64(m.Timestamp))
+	if len(m.Payload) > 0 {
+		i -= len(m.Payload)
+		copy(dAtA[i:], m.Payload)
+		i = encodeVarintChannels(dAtA, i, uint64(len(m.Payload)))
+		i--
+		dAtA[i] = 0x22
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.EventType) > 0 {
+		i -= len(m.EventType)
+		copy(dAtA[i:], m.EventType)
+		i = encodeVarintChannels(dAtA, i, uint64(len(m.EventType)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	return n
+	if len(m.ClientID) > 0 {
+		i -= len(m.ClientID)
+		copy(dAtA[i:], m.ClientID)
+		i = encodeVarintChannels(dAtA, i, uint64(len(m.ClientID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ID != 0 {
+		i = encodeVarintChannels(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *InitialPresenceStatus) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *PublishAck) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *PublishAck) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PublishAck) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.ChannelID)
-	if l > 0 {
-		n += 1 + l + sovChannels(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.ClientStatus) > 0 {
-		for k, v := range m.ClientStatus {
-			_ = k
-			_ = v
-			l = 0
-			if v != nil {
-				l = v.Size()
-				l += 1 + sovChannels(uint64(l))
-			}
-			mapEntrySize := 1 + len(k) + sovChannels(uint64(len(k))) + l
-			n += mapEntrySize + 1 + sovChannels(uint64(mapEntrySize))
+	if len(m.Reason) > 0 {
+		i -= len(m.Reason)
+		copy(dAtA[i:], m.Reason)
+		i = encodeVarintChannels(dAtA, i, uint64(len(m.Reason)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Status {
+		i--
+		if m.Status {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
 		}
+		i--
+		dAtA[i] = 0x10
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if m.ReplyTo != 0 {
+		i = encodeVarintChannels(dAtA, i, uint64(m.ReplyTo))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *ClientJoin) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ChannelEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ChannelEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ChannelEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.ChannelID)
-	if l > 0 {
-		n += 1 + l + sovChannels(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	l = len(m.ClientID)
-	if l > 0 {
-		n += 1 + l + sovChannels(uint64(l))
+	if m.ID != 0 {
+		i = encodeVarintChannels(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.Timestamp != 0 {
+		i = encodeVarintChannels(dAtA, i, uint64(m.Timestamp))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.ChannelID) > 0 {
+		i -= len(m.ChannelID)
+		copy(dAtA[i:], m.ChannelID)
+		i = encodeVarintChannels(dAtA, i, uint64(len(m.ChannelID)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Payload) > 0 {
+		i -= len(m.Payload)
+		copy(dAtA[i:], m.Payload)
+		i = encodeVarintChannels(dAtA, i, uint64(len(m.Payload)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.EventType) > 0 {
+		i -= len(m.EventType)
+		copy(dAtA[i:], m.EventType)
+		i = encodeVarintChannels(dAtA, i, uint64(len(m.EventType)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.SenderID) > 0 {
+		i -= len(m.SenderID)
+		copy(dAtA[i:], m.SenderID)
+		i = encodeVarintChannels(dAtA, i, uint64(len(m.SenderID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ChannelEventDelete) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ChannelEventDelete) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ChannelEventDelete) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	if m.ID != 0 {
+		i = encodeVarintChannels(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ChannelID) > 0 {
+		i -= len(m.ChannelID)
+		copy(dAtA[i:], m.ChannelID)
+		i = encodeVarintChannels(dAtA, i, uint64(len(m.ChannelID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *ClientLeave) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ClientStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ClientStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ClientStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.ChannelID)
-	if l > 0 {
-		n += 1 + l + sovChannels(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	l = len(m.ClientID)
-	if l > 0 {
-		n += 1 + l + sovChannels(uint64(l))
+	if m.Timestamp != 0 {
+		i = encodeVarintChannels(dAtA, i, uint64(m.Timestamp))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Status {
+		i--
+		if m.Status {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *InitialPresenceStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *InitialPresenceStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *InitialPresenceStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	if len(m.ClientStatus) > 0 {
+		for k := range m.ClientStatus {
+			v := m.ClientStatus[k]
+			baseI := i
+			if v != nil {
+				{
+					size, err := v.MarshalToSizedBuffer(dAtA[:i])
+					if err != nil {
+						return 0, err
+					}
+					i -= size
+					i = encodeVarintChannels(dAtA, i, uint64(size))
+				}
+				i--
+				dAtA[i] = 0x12
+			}
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintChannels(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintChannels(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.ChannelID) > 0 {
+		i -= len(m.ChannelID)
+		copy(dAtA[i:], m.ChannelID)
+		i = encodeVarintChannels(dAtA, i, uint64(len(m.ChannelID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *OnlineStatusUpdate) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ClientJoin) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ClientJoin) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ClientJoin) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.ChannelID)
-	if l > 0 {
-		n += 1 + l + sovChannels(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	l = len(m.ClientID)
-	if l > 0 {
-		n += 1 + l + sovChannels(uint64(l))
+	if len(m.ClientID) > 0 {
+		i -= len(m.ClientID)
+		copy(dAtA[i:], m.ClientID)
+		i = encodeVarintChannels(dAtA, i, uint64(len(m.ClientID)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.Status {
-		n += 2
+	if len(m.ChannelID) > 0 {
+		i -= len(m.ChannelID)
+		copy(dAtA[i:], m.ChannelID)
+		i = encodeVarintChannels(dAtA, i, uint64(len(m.ChannelID)))
+		i--
+		dAtA[i] = 0xa
 	}
-	if m.Timestamp != 0 {
-		n += 1 + sovChannels(uint64(m.Timestamp))
+	return len(dAtA) - i, nil
+}
+
+func (m *ClientLeave) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ClientLeave) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ClientLeave) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.ClientID) > 0 {
+		i -= len(m.ClientID)
+		copy(dAtA[i:], m.ClientID)
+		i = encodeVarintChannels(dAtA, i, uint64(len(m.ClientID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ChannelID) > 0 {
+		i -= len(m.ChannelID)
+		copy(dAtA[i:], m.ChannelID)
+		i = encodeVarintChannels(dAtA, i, uint64(len(m.ChannelID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *OnlineStatusUpdate) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *OnlineStatusUpdate) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *OnlineStatusUpdate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Timestamp != 0 {
+		i = encodeVarintChannels(dAtA, i, uint64(m.Timestamp))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Status {
+		i--
+		if m.Status {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.ClientID) > 0 {
+		i -= len(m.ClientID)
+		copy(dAtA[i:], m.ClientID)
+		i = encodeVarintChannels(dAtA, i, uint64(len(m.ClientID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ChannelID) > 0 {
+		i -= len(m.ChannelID)
+		copy(dAtA[i:], m.ChannelID)
+		i = encodeVarintChannels(dAtA, i, uint64(len(m.ChannelID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReadReceipt) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReadReceipt) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReadReceipt) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Timestamp != 0 {
+		i = encodeVarintChannels(dAtA, i, uint64(m.Timestamp))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.EventID != 0 {
+		i = encodeVarintChannels(dAtA, i, uint64(m.EventID))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.ClientID) > 0 {
+		i -= len(m.ClientID)
+		copy(dAtA[i:], m.ClientID)
+		i = encodeVarintChannels(dAtA, i, uint64(len(m.ClientID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ChannelID) > 0 {
+		i -= len(m.ChannelID)
+		copy(dAtA[i:], m.ChannelID)
+		i = encodeVarintChannels(dAtA, i, uint64(len(m.ChannelID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ChannelSignal) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ChannelSignal) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ChannelSignal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Timestamp != 0 {
+		i = encodeVarintChannels(dAtA, i, uint64(m.Timestamp))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Payload) > 0 {
+		i -= len(m.Payload)
+		copy(dAtA[i:], m.Payload)
+		i = encodeVarintChannels(dAtA, i, uint64(len(m.Payload)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.SignalType) > 0 {
+		i -= len(m.SignalType)
+		copy(dAtA[i:], m.SignalType)
+		i = encodeVarintChannels(dAtA, i, uint64(len(m.SignalType)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.ChannelID) > 0 {
+		i -= len(m.ChannelID)
+		copy(dAtA[i:], m.ChannelID)
+		i = encodeVarintChannels(dAtA, i, uint64(len(m.ChannelID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.SenderID) > 0 {
+		i -= len(m.SenderID)
+		copy(dAtA[i:], m.SenderID)
+		i = encodeVarintChannels(dAtA, i, uint64(len(m.SenderID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *NewEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NewEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NewEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Payload) > 0 {
+		i -= len(m.Payload)
+		copy(dAtA[i:], m.Payload)
+		i = encodeVarintChannels(dAtA, i, uint64(len(m.Payload)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Type != 0 {
+		i = encodeVarintChannels(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Envelope) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Envelope) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Envelope) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Events) > 0 {
+		for iNdEx := len(m.Events) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Events[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintChannels(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.IsMultiple {
+		i--
+		if m.IsMultiple {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintChannels(dAtA []byte, offset int, v uint64) int {
+	offset -= sovChannels(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *PublishRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovChannels(uint64(m.ID))
+	}
+	l = len(m.EventType)
+	if l > 0 {
+		n += 1 + l + sovChannels(uint64(l))
+	}
+	l = len(m.ChannelID)
+	if l > 0 {
+		n += 1 + l + sovChannels(uint64(l))
+	}
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovChannels(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SubscribeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ChannelID)
+	if l > 0 {
+		n += 1 + l + sovChannels(uint64(l))
+	}
+	if m.ID != 0 {
+		n += 1 + sovChannels(uint64(m.ID))
+	}
+	if m.LastEventID != 0 {
+		n += 1 + sovChannels(uint64(m.LastEventID))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *EditRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovChannels(uint64(m.ID))
+	}
+	l = len(m.ChannelID)
+	if l > 0 {
+		n += 1 + l + sovChannels(uint64(l))
+	}
+	if m.EventID != 0 {
+		n += 1 + sovChannels(uint64(m.EventID))
+	}
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovChannels(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeleteRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovChannels(uint64(m.ID))
+	}
+	l = len(m.ChannelID)
+	if l > 0 {
+		n += 1 + l + sovChannels(uint64(l))
+	}
+	if m.EventID != 0 {
+		n += 1 + sovChannels(uint64(m.EventID))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ReadRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovChannels(uint64(m.ID))
+	}
+	l = len(m.ChannelID)
+	if l > 0 {
+		n += 1 + l + sovChannels(uint64(l))
+	}
+	if m.EventID != 0 {
+		n += 1 + sovChannels(uint64(m.EventID))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SignalRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovChannels(uint64(m.ID))
+	}
+	l = len(m.ChannelID)
+	if l > 0 {
+		n += 1 + l + sovChannels(uint64(l))
+	}
+	l = len(m.SignalType)
+	if l > 0 {
+		n += 1 + l + sovChannels(uint64(l))
+	}
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovChannels(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DirectRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovChannels(uint64(m.ID))
+	}
+	l = len(m.ClientID)
+	if l > 0 {
+		n += 1 + l + sovChannels(uint64(l))
+	}
+	l = len(m.EventType)
+	if l > 0 {
+		n += 1 + l + sovChannels(uint64(l))
+	}
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovChannels(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PublishAck) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ReplyTo != 0 {
+		n += 1 + sovChannels(uint64(m.ReplyTo))
+	}
+	if m.Status {
+		n += 2
+	}
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovChannels(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ChannelEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.SenderID)
+	if l > 0 {
+		n += 1 + l + sovChannels(uint64(l))
+	}
+	l = len(m.EventType)
+	if l > 0 {
+		n += 1 + l + sovChannels(uint64(l))
+	}
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovChannels(uint64(l))
+	}
+	l = len(m.ChannelID)
+	if l > 0 {
+		n += 1 + l + sovChannels(uint64(l))
+	}
+	if m.Timestamp != 0 {
+		n += 1 + sovChannels(uint64(m.Timestamp))
+	}
+	if m.ID != 0 {
+		n += 1 + sovChannels(uint64(m.ID))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ChannelEventDelete) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ChannelID)
+	if l > 0 {
+		n += 1 + l + sovChannels(uint64(l))
+	}
+	if m.ID != 0 {
+		n += 1 + sovChannels(uint64(m.ID))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ClientStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status {
+		n += 2
+	}
+	if m.Timestamp != 0 {
+		n += 1 + sovChannels(uint64(m.Timestamp))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *InitialPresenceStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ChannelID)
+	if l > 0 {
+		n += 1 + l + sovChannels(uint64(l))
+	}
+	if len(m.ClientStatus) > 0 {
+		for k, v := range m.ClientStatus {
+			_ = k
+			_ = v
+			l = 0
+			if v != nil {
+				l = v.Size()
+				l += 1 + sovChannels(uint64(l))
+			}
+			mapEntrySize := 1 + len(k) + sovChannels(uint64(len(k))) + l
+			n += mapEntrySize + 1 + sovChannels(uint64(mapEntrySize))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ClientJoin) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ChannelID)
+	if l > 0 {
+		n += 1 + l + sovChannels(uint64(l))
+	}
+	l = len(m.ClientID)
+	if l > 0 {
+		n += 1 + l + sovChannels(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ClientLeave) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ChannelID)
+	if l > 0 {
+		n += 1 + l + sovChannels(uint64(l))
+	}
+	l = len(m.ClientID)
+	if l > 0 {
+		n += 1 + l + sovChannels(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *OnlineStatusUpdate) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ChannelID)
+	if l > 0 {
+		n += 1 + l + sovChannels(uint64(l))
+	}
+	l = len(m.ClientID)
+	if l > 0 {
+		n += 1 + l + sovChannels(uint64(l))
+	}
+	if m.Status {
+		n += 2
+	}
+	if m.Timestamp != 0 {
+		n += 1 + sovChannels(uint64(m.Timestamp))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ReadReceipt) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ChannelID)
+	if l > 0 {
+		n += 1 + l + sovChannels(uint64(l))
+	}
+	l = len(m.ClientID)
+	if l > 0 {
+		n += 1 + l + sovChannels(uint64(l))
+	}
+	if m.EventID != 0 {
+		n += 1 + sovChannels(uint64(m.EventID))
+	}
+	if m.Timestamp != 0 {
+		n += 1 + sovChannels(uint64(m.Timestamp))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ChannelSignal) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.SenderID)
+	if l > 0 {
+		n += 1 + l + sovChannels(uint64(l))
+	}
+	l = len(m.ChannelID)
+	if l > 0 {
+		n += 1 + l + sovChannels(uint64(l))
+	}
+	l = len(m.SignalType)
+	if l > 0 {
+		n += 1 + l + sovChannels(uint64(l))
+	}
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovChannels(uint64(l))
+	}
+	if m.Timestamp != 0 {
+		n += 1 + sovChannels(uint64(m.Timestamp))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *NewEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != 0 {
+		n += 1 + sovChannels(uint64(m.Type))
+	}
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovChannels(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Envelope) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.IsMultiple {
+		n += 2
+	}
+	if len(m.Events) > 0 {
+		for _, e := range m.Events {
+			l = e.Size()
+			n += 1 + l + sovChannels(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovChannels(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozChannels(x uint64) (n int) {
+	return sovChannels(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *PublishRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowChannels
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PublishRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PublishRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChannels
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChannels
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthChannels
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthChannels
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EventType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChannelID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChannels
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthChannels
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthChannels
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChannelID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChannels
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthChannels
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthChannels
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipChannels(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthChannels
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SubscribeRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowChannels
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubscribeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubscribeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChannelID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChannels
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthChannels
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthChannels
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChannelID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChannels
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastEventID", wireType)
+			}
+			m.LastEventID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChannels
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastEventID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipChannels(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthChannels
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *EditRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowChannels
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EditRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EditRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChannels
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChannelID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChannels
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthChannels
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthChannels
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChannelID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventID", wireType)
+			}
+			m.EventID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChannels
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EventID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChannels
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthChannels
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthChannels
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipChannels(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthChannels
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeleteRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowChannels
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeleteRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeleteRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChannels
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChannelID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChannels
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthChannels
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthChannels
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChannelID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventID", wireType)
+			}
+			m.EventID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChannels
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EventID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipChannels(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthChannels
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReadRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowChannels
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReadRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReadRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChannels
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChannelID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChannels
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthChannels
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthChannels
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChannelID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventID", wireType)
+			}
+			m.EventID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChannels
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EventID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipChannels(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthChannels
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SignalRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowChannels
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SignalRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SignalRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChannels
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChannelID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChannels
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthChannels
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthChannels
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChannelID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignalType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChannels
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthChannels
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthChannels
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SignalType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChannels
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthChannels
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthChannels
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipChannels(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthChannels
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DirectRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowChannels
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DirectRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DirectRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChannels
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChannels
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthChannels
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthChannels
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChannels
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthChannels
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthChannels
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EventType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChannels
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthChannels
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthChannels
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipChannels(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthChannels
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
 	}
-	return n
-}
 
-func (m *NewEvent) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Type != 0 {
-		n += 1 + sovChannels(uint64(m.Type))
-	}
-	l = len(m.Payload)
-	if l > 0 {
-		n += 1 + l + sovChannels(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
+	return nil
 }
-
-func (m *Envelope) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.IsMultiple {
-		n += 2
-	}
-	if len(m.Events) > 0 {
-		for _, e := range m.Events {
-			l = e.Size()
-			n += 1 + l + sovChannels(uint64(l))
+func (m *PublishAck) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowChannels
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PublishAck: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PublishAck: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReplyTo", wireType)
+			}
+			m.ReplyTo = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChannels
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReplyTo |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChannels
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Status = bool(v != 0)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChannels
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthChannels
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthChannels
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipChannels(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthChannels
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
 		}
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
-}
 
-func sovChannels(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozChannels(x uint64) (n int) {
-	return sovChannels(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *PublishRequest) Unmarshal(dAtA []byte) error {
+func (m *ChannelEvent) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1602,17 +3967,17 @@ func (m *PublishRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PublishRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ChannelEvent: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PublishRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ChannelEvent: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SenderID", wireType)
 			}
-			m.ID = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowChannels
@@ -1622,11 +3987,24 @@ func (m *PublishRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ID |= uint32(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthChannels
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthChannels
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SenderID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field EventType", wireType)
@@ -1661,7 +4039,7 @@ func (m *PublishRequest) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChannelID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1689,11 +4067,11 @@ func (m *PublishRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ChannelID = string(dAtA[iNdEx:postIndex])
+			m.Payload = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ChannelID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1721,8 +4099,46 @@ func (m *PublishRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Payload = string(dAtA[iNdEx:postIndex])
+			m.ChannelID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChannels
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChannels
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipChannels(dAtA[iNdEx:])
@@ -1745,7 +4161,7 @@ func (m *PublishRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SubscribeRequest) Unmarshal(dAtA []byte) error {
+func (m *ChannelEventDelete) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1768,10 +4184,10 @@ func (m *SubscribeRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SubscribeRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ChannelEventDelete: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SubscribeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ChannelEventDelete: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -1820,7 +4236,7 @@ func (m *SubscribeRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ID |= uint32(b&0x7F) << shift
+				m.ID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -1847,7 +4263,7 @@ func (m *SubscribeRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PublishAck) Unmarshal(dAtA []byte) error {
+func (m *ClientStatus) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1870,17 +4286,17 @@ func (m *PublishAck) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PublishAck: wiretype end group for non-group")
+			return fmt.Errorf("proto: ClientStatus: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PublishAck: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ClientStatus: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReplyTo", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
 			}
-			m.ReplyTo = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowChannels
@@ -1890,16 +4306,17 @@ func (m *PublishAck) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ReplyTo |= uint32(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.Status = bool(v != 0)
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
 			}
-			var v int
+			m.Timestamp = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowChannels
@@ -1909,12 +4326,11 @@ func (m *PublishAck) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.Timestamp |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Status = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipChannels(dAtA[iNdEx:])
@@ -1937,7 +4353,7 @@ func (m *PublishAck) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ChannelEvent) Unmarshal(dAtA []byte) error {
+func (m *InitialPresenceStatus) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1960,15 +4376,15 @@ func (m *ChannelEvent) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ChannelEvent: wiretype end group for non-group")
+			return fmt.Errorf("proto: InitialPresenceStatus: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ChannelEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: InitialPresenceStatus: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SenderID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ChannelID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1996,45 +4412,13 @@ func (m *ChannelEvent) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.SenderID = string(dAtA[iNdEx:postIndex])
+			m.ChannelID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EventType", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowChannels
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthChannels
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthChannels
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.EventType = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientStatus", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowChannels
@@ -2044,75 +4428,121 @@ func (m *ChannelEvent) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthChannels
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthChannels
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Payload = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChannelID", wireType)
+			if m.ClientStatus == nil {
+				m.ClientStatus = make(map[string]*ClientStatus)
 			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowChannels
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
+			var mapkey string
+			var mapvalue *ClientStatus
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowChannels
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowChannels
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthChannels
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthChannels
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowChannels
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthChannels
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthChannels
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &ClientStatus{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipChannels(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthChannels
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthChannels
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthChannels
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ChannelID = string(dAtA[iNdEx:postIndex])
+			m.ClientStatus[mapkey] = mapvalue
 			iNdEx = postIndex
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
-			}
-			m.Timestamp = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowChannels
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Timestamp |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipChannels(dAtA[iNdEx:])
@@ -2135,7 +4565,7 @@ func (m *ChannelEvent) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ClientStatus) Unmarshal(dAtA []byte) error {
+func (m *ClientJoin) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2158,17 +4588,17 @@ func (m *ClientStatus) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ClientStatus: wiretype end group for non-group")
+			return fmt.Errorf("proto: ClientJoin: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ClientStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ClientJoin: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChannelID", wireType)
 			}
-			var v int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowChannels
@@ -2178,17 +4608,29 @@ func (m *ClientStatus) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Status = bool(v != 0)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthChannels
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthChannels
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChannelID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientID", wireType)
 			}
-			m.Timestamp = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowChannels
@@ -2198,11 +4640,24 @@ func (m *ClientStatus) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Timestamp |= int64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthChannels
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthChannels
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipChannels(dAtA[iNdEx:])
@@ -2225,7 +4680,7 @@ func (m *ClientStatus) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *InitialPresenceStatus) Unmarshal(dAtA []byte) error {
+func (m *ClientLeave) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2248,10 +4703,10 @@ func (m *InitialPresenceStatus) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: InitialPresenceStatus: wiretype end group for non-group")
+			return fmt.Errorf("proto: ClientLeave: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: InitialPresenceStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ClientLeave: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -2288,9 +4743,9 @@ func (m *InitialPresenceStatus) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ClientStatus", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientID", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowChannels
@@ -2300,120 +4755,23 @@ func (m *InitialPresenceStatus) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthChannels
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthChannels
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ClientStatus == nil {
-				m.ClientStatus = make(map[string]*ClientStatus)
-			}
-			var mapkey string
-			var mapvalue *ClientStatus
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowChannels
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowChannels
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthChannels
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey < 0 {
-						return ErrInvalidLengthChannels
-					}
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					var mapmsglen int
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowChannels
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						mapmsglen |= int(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					if mapmsglen < 0 {
-						return ErrInvalidLengthChannels
-					}
-					postmsgIndex := iNdEx + mapmsglen
-					if postmsgIndex < 0 {
-						return ErrInvalidLengthChannels
-					}
-					if postmsgIndex > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapvalue = &ClientStatus{}
-					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
-						return err
-					}
-					iNdEx = postmsgIndex
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipChannels(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if (skippy < 0) || (iNdEx+skippy) < 0 {
-						return ErrInvalidLengthChannels
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
-				}
-			}
-			m.ClientStatus[mapkey] = mapvalue
+			m.ClientID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2437,7 +4795,7 @@ func (m *InitialPresenceStatus) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ClientJoin) Unmarshal(dAtA []byte) error {
+func (m *OnlineStatusUpdate) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2460,10 +4818,10 @@ func (m *ClientJoin) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ClientJoin: wiretype end group for non-group")
+			return fmt.Errorf("proto: OnlineStatusUpdate: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ClientJoin: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: OnlineStatusUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -2530,6 +4888,45 @@ func (m *ClientJoin) Unmarshal(dAtA []byte) error {
 			}
 			m.ClientID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChannels
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Status = bool(v != 0)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChannels
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipChannels(dAtA[iNdEx:])
@@ -2552,7 +4949,7 @@ func (m *ClientJoin) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ClientLeave) Unmarshal(dAtA []byte) error {
+func (m *ReadReceipt) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2575,10 +4972,10 @@ func (m *ClientLeave) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ClientLeave: wiretype end group for non-group")
+			return fmt.Errorf("proto: ReadReceipt: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ClientLeave: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ReadReceipt: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -2645,6 +5042,44 @@ func (m *ClientLeave) Unmarshal(dAtA []byte) error {
 			}
 			m.ClientID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventID", wireType)
+			}
+			m.EventID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChannels
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EventID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChannels
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipChannels(dAtA[iNdEx:])
@@ -2667,7 +5102,7 @@ func (m *ClientLeave) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *OnlineStatusUpdate) Unmarshal(dAtA []byte) error {
+func (m *ChannelSignal) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2690,15 +5125,15 @@ func (m *OnlineStatusUpdate) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: OnlineStatusUpdate: wiretype end group for non-group")
+			return fmt.Errorf("proto: ChannelSignal: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: OnlineStatusUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ChannelSignal: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChannelID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SenderID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2726,11 +5161,11 @@ func (m *OnlineStatusUpdate) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ChannelID = string(dAtA[iNdEx:postIndex])
+			m.SenderID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ClientID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ChannelID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2758,13 +5193,13 @@ func (m *OnlineStatusUpdate) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ClientID = string(dAtA[iNdEx:postIndex])
+			m.ChannelID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignalType", wireType)
 			}
-			var v int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowChannels
@@ -2774,13 +5209,57 @@ func (m *OnlineStatusUpdate) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Status = bool(v != 0)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthChannels
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthChannels
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SignalType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChannels
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthChannels
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthChannels
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
 			}
@@ -1,14 +1,13 @@
 package core
 
 import (
-	"fmt"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/lisomatrix/channels/channels/auth"
 	"io/ioutil"
 	"net/http"
-	"os"
 
 	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
 )
 
 type createClientRequest struct {
@@ -23,7 +22,8 @@ type updateClientRequest struct {
 }
 
 type getClientsResponse struct {
-	Clients []*Client `json:"clients"`
+	Clients    []*Client `json:"clients"`
+	NextCursor string    `json:"nextCursor,omitempty"`
 }
 
 // CreateClientHandler - Create new client
@@ -42,7 +42,7 @@ func CreateClientHandler(context *gin.Context) {
 	}
 
 	// Check if is admin, and validate token
-	identity, isOK := auth.AuthenticateAdmin(token)
+	identity, isOK := authenticateAdmin(token, ScopeManageClients)
 
 	// If not valid return
 	if !isOK || !identity.CanUseAppID(appID) {
@@ -72,14 +72,14 @@ func CreateClientHandler(context *gin.Context) {
 	existingClient, err := GetClient(appID, createClientRequest.ClientID)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Create Client failed to check if client already exists %v\n", err)
+		logger.WithField("AppID", appID).WithError(err).Error("HTTP Create Client failed to check if client already exists")
 	} else if existingClient != nil {
 		writer.WriteHeader(http.StatusConflict)
 		return
 	}
 
 	if isOK, err := CreateClient(appID, createClientRequest.ClientID, createClientRequest.Username, createClientRequest.Extra); err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Create Client failed %v\n", err)
+		logger.WithField("AppID", appID).WithError(err).Error("HTTP Create Client failed")
 		writer.WriteHeader(http.StatusInternalServerError)
 	} else if isOK {
 		writer.WriteHeader(http.StatusOK)
@@ -104,7 +104,7 @@ func DeleteClientHandler(context *gin.Context) {
 	}
 
 	// Check if is admin, and validate token
-	identity, isOK := auth.AuthenticateAdmin(token)
+	identity, isOK := authenticateAdmin(token, ScopeManageClients)
 
 	// If not valid return
 	if !isOK || !identity.CanUseAppID(appID) {
@@ -120,7 +120,7 @@ func DeleteClientHandler(context *gin.Context) {
 	}
 
 	if isOK, err := DeleteClient(appID, clientID); err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Delete Client: failed to delete client %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ClientID": clientID}).WithError(err).Error("HTTP Delete Client: failed to delete client")
 		writer.WriteHeader(http.StatusInternalServerError)
 	} else if isOK {
 		writer.WriteHeader(http.StatusOK)
@@ -145,7 +145,7 @@ func UpdateClientHandler(context *gin.Context) {
 	}
 
 	// Check if is admin, and validate token
-	identity, isOK := auth.AuthenticateAdmin(token)
+	identity, isOK := authenticateAdmin(token, ScopeManageClients)
 
 	// If not valid return
 	if !isOK || !identity.CanUseAppID(appID) {
@@ -182,7 +182,7 @@ func UpdateClientHandler(context *gin.Context) {
 	exists, err := GetEngine().GetClientRepository().ExistsAppClient(appID, clientID)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Update Client: failed to check client existence %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ClientID": clientID}).WithError(err).Error("HTTP Update Client: failed to check client existence")
 		writer.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -195,7 +195,7 @@ func UpdateClientHandler(context *gin.Context) {
 	err = GetEngine().GetClientRepository().UpdateClient(clientID, updateClientRequest.Username, updateClientRequest.Extra)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Update Client failed %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ClientID": clientID}).WithError(err).Error("HTTP Update Client failed")
 		writer.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -221,7 +221,7 @@ func GetClients(context *gin.Context) {
 	}
 
 	// Check if is admin, and validate token
-	identity, isOK := auth.AuthenticateAdmin(token)
+	identity, isOK := authenticateAdmin(token, ScopeManageClients)
 
 	// If not valid return
 	if !isOK {
@@ -229,38 +229,46 @@ func GetClients(context *gin.Context) {
 		return
 	}
 
-	var getClientsResponse getClientsResponse
+	// Without AppID clients of all apps are listed, only for super admins
+	if !(appID != "" && identity.CanUseAppID(appID)) && !(appID == "" && identity.IsSuperAdmin()) {
+		writer.WriteHeader(http.StatusUnauthorized)
+		return
+	}
 
-	if appID != "" && identity.CanUseAppID(appID) {
-		clients, err := GetEngine().GetClientRepository().GetAppClients(appID)
+	options, err := ParseListOptions(request.URL.Query())
 
-		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "HTTP Get app clients failed %v\n", err)
-			writer.WriteHeader(http.StatusInternalServerError)
-			return
-		}
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
 
-		getClientsResponse.Clients = clients
-	} else if appID == "" && identity.IsSuperAdmin() {
-		clients, err := GetEngine().GetClientRepository().GetAllClients()
+	// Fetch one more client to know if there is a next page
+	limit := options.Limit
+	options.Limit++
 
-		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "HTTP Get all clients failed %v\n", err)
-			writer.WriteHeader(http.StatusInternalServerError)
-			return
-		}
+	clients, err := GetEngine().GetClientRepository().GetClientsPage(appID, options)
 
-		getClientsResponse.Clients = clients
-	} else {
-		writer.WriteHeader(http.StatusUnauthorized)
+	if err != nil {
+		logger.WithField("AppID", appID).WithError(err).Error("HTTP Get clients failed")
+		writer.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
+	getClientsResponse := getClientsResponse{Clients: clients}
+
+	if len(clients) > limit {
+		clients = clients[:limit]
+		last := clients[limit-1]
+
+		getClientsResponse.Clients = clients
+		getClientsResponse.NextCursor = EncodeListCursor(&ListCursor{ID: last.ID, Name: last.Username})
+	}
+
 	var json = jsoniter.ConfigCompatibleWithStandardLibrary
 	data, err := json.Marshal(getClientsResponse)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Get all clients: Marshal failed %v\n", err)
+		logger.WithField("AppID", appID).WithError(err).Error("HTTP Get all clients: Marshal failed")
 		writer.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -285,7 +293,7 @@ func GetClientHandler(context *gin.Context) {
 	}
 
 	// Check if is admin, and validate token
-	identity, isOK := auth.AuthenticateAdmin(token)
+	identity, isOK := authenticateAdmin(token, ScopeManageClients)
 
 	// If not valid return
 	if !isOK || !identity.CanUseAppID(appID) {
@@ -303,7 +311,7 @@ func GetClientHandler(context *gin.Context) {
 	client, err := GetClient(appID, clientID)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Get Client failed %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ClientID": clientID}).WithError(err).Error("HTTP Get Client failed")
 		writer.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -312,7 +320,7 @@ func GetClientHandler(context *gin.Context) {
 	data, err := json.Marshal(client)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Get Client: failed to marshal response %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ClientID": clientID}).WithError(err).Error("HTTP Get Client: failed to marshal response")
 		writer.WriteHeader(http.StatusInternalServerError)
 		return
 	}
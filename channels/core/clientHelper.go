@@ -1,8 +1,7 @@
 package core
 
 import (
-	"fmt"
-	"os"
+	log "github.com/sirupsen/logrus"
 )
 
 // GetClient - Get client from cache first, then try database and update cache if found
@@ -17,7 +16,7 @@ func GetClient(appID string, clientID string) (*Client, error) {
 	client, err := GetEngine().GetClientRepository().GetAppClient(appID, clientID)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Get client failed %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ClientID": clientID}).WithError(err).Error("Get client failed")
 		return nil, err
 	}
 
@@ -36,7 +35,7 @@ func CreateClient(appID string, clientID string, username string, extra string)
 	err := GetEngine().GetClientRepository().CreateClient(clientID, username, appID, extra)
 
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Create Client failed %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ClientID": clientID}).WithError(err).Error("Create Client failed")
 		return false, err
 	}
 
@@ -55,7 +54,7 @@ func DeleteClient(appID string, clientID string) (bool, error) {
 	exists, err := GetEngine().GetClientRepository().ExistsAppClient(appID, clientID)
 
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Delete Client: failed to check client existence %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ClientID": clientID}).WithError(err).Error("Delete Client: failed to check client existence")
 		return false, err
 	}
 
@@ -66,7 +65,7 @@ func DeleteClient(appID string, clientID string) (bool, error) {
 	err = GetEngine().GetClientRepository().DeleteClient(clientID)
 
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Delete Client failed %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ClientID": clientID}).WithError(err).Error("Delete Client failed")
 		return false, err
 	}
 
@@ -82,7 +81,7 @@ func UpdateClient(appID string, clientID string, username string, extra string)
 	exists, err := GetEngine().GetClientRepository().ExistsAppClient(appID, clientID)
 
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Update Client: failed to check client existence %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ClientID": clientID}).WithError(err).Error("Update Client: failed to check client existence")
 		return false, err
 	}
 
@@ -93,7 +92,7 @@ func UpdateClient(appID string, clientID string, username string, extra string)
 	err = GetEngine().GetClientRepository().UpdateClient(clientID, username, extra)
 
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Update Client failed %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "ClientID": clientID}).WithError(err).Error("Update Client failed")
 		return false, err
 	}
 
@@ -1,5 +1,13 @@
 package core
 
+const (
+	CloseStatusNormal    uint16 = 1000 // Connection closed normally
+	CloseStatusGoingAway uint16 = 1001 // Server is shutting down
+	CloseStatusPolicy    uint16 = 1008 // Client kept breaking the app limits
+
+	CloseStatusDisconnected uint16 = 4001 // Client was disconnected by an admin or its token was revoked
+)
+
 // Connection - Interface for connections
 type Connection interface {
 	//Init(ws *websocket.Conn)
@@ -9,5 +17,6 @@ type Connection interface {
 	SetOnClose(func())
 	SetOnHeartBeat(func())
 	Close()
+	CloseWithStatus(code uint16, reason string) // Let the client know why the connection is being closed, then close it
 	IsConnected() bool
 }
@@ -0,0 +1,82 @@
+package core
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+
+	"github.com/lisomatrix/channels/channels/auth"
+	log "github.com/sirupsen/logrus"
+)
+
+// ConnectionTicket - Single use credential to open a connection without putting the token in the URL
+type ConnectionTicket struct {
+	AppID     string
+	ClientID  string
+	DeviceID  string
+	Role      string
+	IssuedAt  int64 // Unix seconds the token used to get the ticket was issued at, 0 if unknown
+	ExpiresAt int64 // Unix milliseconds
+}
+
+// IsExpired - Check if the ticket can't be used anymore
+func (ticket *ConnectionTicket) IsExpired() bool {
+	return time.Now().UnixNano()/int64(time.Millisecond) >= ticket.ExpiresAt
+}
+
+// TTL - Time left before the ticket expires
+func (ticket *ConnectionTicket) TTL() time.Duration {
+	return time.Until(time.Unix(0, ticket.ExpiresAt*int64(time.Millisecond)))
+}
+
+// newTicketID - Random URL safe ticket ID
+func newTicketID() (string, error) {
+	data := make([]byte, 32)
+
+	if _, err := rand.Read(data); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// CreateConnectionTicket - Issue a ticket for the identity to connect once to the app with the device, within ConnectionTicketTTL
+func CreateConnectionTicket(identity *auth.Identity, appID string, deviceID string) (string, *ConnectionTicket, bool) {
+	ticketID, err := newTicketID()
+
+	if err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ClientID": identity.ClientID}).WithError(err).Error("Connection ticket: failed to generate ticket")
+		return "", nil, false
+	}
+
+	ticket := &ConnectionTicket{
+		AppID:     appID,
+		ClientID:  identity.ClientID,
+		DeviceID:  deviceID,
+		Role:      identity.Role,
+		IssuedAt:  identity.IssuedAt,
+		ExpiresAt: time.Now().Add(ConnectionTicketTTL).UnixNano() / int64(time.Millisecond),
+	}
+
+	if !GetEngine().GetCacheStorage().StoreConnectionTicket(ticketID, ticket) {
+		return "", nil, false
+	}
+
+	return ticketID, ticket, true
+}
+
+// UseConnectionTicket - Take the ticket and get its identity and device, it fails if the ticket was used, expired or the AppID isn't the ticket one
+// An empty appID or deviceID takes the ticket ones
+func UseConnectionTicket(ticketID string, appID string, deviceID string) (*auth.Identity, string, bool) {
+	ticket := GetEngine().GetCacheStorage().TakeConnectionTicket(ticketID)
+
+	if ticket == nil || ticket.IsExpired() {
+		return nil, "", false
+	}
+
+	if (appID != "" && appID != ticket.AppID) || (deviceID != "" && deviceID != ticket.DeviceID) {
+		return nil, "", false
+	}
+
+	return &auth.Identity{Role: ticket.Role, AppID: ticket.AppID, ClientID: ticket.ClientID, IssuedAt: ticket.IssuedAt}, ticket.DeviceID, true
+}
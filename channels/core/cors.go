@@ -0,0 +1,162 @@
+package core
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// CORSConfig - Browser origins allowed to call the HTTP routes and open connections, and what they can send
+type CORSConfig struct {
+	AllowedOrigins   []string            `yaml:"allowed_origins"`   // Origins like https://example.com, https://*.example.com for its subdomains or * for any, defaults to *
+	AllowedMethods   []string            `yaml:"allowed_methods"`   // Defaults to the methods of the routes
+	AllowedHeaders   []string            `yaml:"allowed_headers"`   // Defaults to the headers the routes read
+	AllowCredentials bool                `yaml:"allow_credentials"` // Never sent to origins only allowed by *
+	MaxAge           time.Duration       `yaml:"max_age"`           // How long browsers cache preflight responses, not sent if 0
+	AppOrigins       map[string][]string `yaml:"app_origins"`       // Extra origins of specific apps by AppID
+}
+
+// DefaultCORSConfig - Any origin without credentials, as tokens are sent in headers
+var DefaultCORSConfig = CORSConfig{
+	AllowedOrigins: []string{"*"},
+	AllowedMethods: []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+	AllowedHeaders: []string{"Content-Type", "Content-Length", "Accept-Encoding", "X-CSRF-Token", "Authorization", "Accept", "Origin", "Cache-Control", "X-Requested-With", "AppID", "DeviceID"},
+}
+
+var corsConfig = DefaultCORSConfig
+
+var appOrigins sync.Map //[string][]string
+
+// SetCORSConfig - Set the allowed origins, methods and headers, unset fields use DefaultCORSConfig
+func SetCORSConfig(config CORSConfig) {
+	if len(config.AllowedOrigins) == 0 {
+		config.AllowedOrigins = DefaultCORSConfig.AllowedOrigins
+	}
+
+	if len(config.AllowedMethods) == 0 {
+		config.AllowedMethods = DefaultCORSConfig.AllowedMethods
+	}
+
+	if len(config.AllowedHeaders) == 0 {
+		config.AllowedHeaders = DefaultCORSConfig.AllowedHeaders
+	}
+
+	for appID, origins := range config.AppOrigins {
+		SetAppOrigins(appID, origins)
+	}
+
+	corsConfig = config
+}
+
+// isSet - Check if any field was given
+func (config CORSConfig) isSet() bool {
+	return len(config.AllowedOrigins) > 0 || len(config.AllowedMethods) > 0 || len(config.AllowedHeaders) > 0 ||
+		config.AllowCredentials || config.MaxAge > 0 || len(config.AppOrigins) > 0
+}
+
+// GetCORSConfig - Get the allowed origins, methods and headers
+func GetCORSConfig() CORSConfig {
+	return corsConfig
+}
+
+// SetAppOrigins - Set the origins allowed for the app, on top of the AllowedOrigins of every app
+func SetAppOrigins(appID string, origins []string) {
+	appOrigins.Store(appID, origins)
+}
+
+// GetAppOrigins - Get the origins allowed only for the app
+func GetAppOrigins(appID string) []string {
+	data, isOK := appOrigins.Load(appID)
+
+	if !isOK {
+		return nil
+	}
+
+	return data.([]string)
+}
+
+// CORSAllowOrigin - Value of Access-Control-Allow-Origin for requests of the origin to the app, empty if it isn't allowed
+// Returns * when the origin is only allowed because any origin is, credentials must not be allowed with it
+func CORSAllowOrigin(origin string, appID string) string {
+	if origin == "" {
+		return ""
+	}
+
+	if matchOrigins(origin, corsConfig.AllowedOrigins) || matchOrigins(origin, GetAppOrigins(appID)) {
+		return origin
+	}
+
+	if allowsAnyOrigin(corsConfig.AllowedOrigins) || allowsAnyOrigin(GetAppOrigins(appID)) {
+		return "*"
+	}
+
+	return ""
+}
+
+// CORSPreflightAllowOrigin - Same as CORSAllowOrigin for preflight requests
+// Browsers don't send the AppID header on them, so the origins of every app are allowed
+func CORSPreflightAllowOrigin(origin string) string {
+	allowOrigin := CORSAllowOrigin(origin, "")
+
+	if allowOrigin != "" && allowOrigin != "*" {
+		return allowOrigin
+	}
+
+	appOrigins.Range(func(key interface{}, value interface{}) bool {
+		if matchOrigins(origin, value.([]string)) {
+			allowOrigin = origin
+			return false
+		}
+
+		if allowsAnyOrigin(value.([]string)) {
+			allowOrigin = "*"
+		}
+
+		return true
+	})
+
+	return allowOrigin
+}
+
+// IsOriginAllowed - Check the origin can open connections to the app
+// Requests without Origin don't come from browsers, so they are allowed
+func IsOriginAllowed(origin string, appID string) bool {
+	return origin == "" || CORSAllowOrigin(origin, appID) != ""
+}
+
+// matchOrigins - Check if the origin is one of the allowed ones, not counting *
+func matchOrigins(origin string, allowed []string) bool {
+	origin = strings.ToLower(origin)
+
+	for _, allowedOrigin := range allowed {
+		if allowedOrigin != "*" && matchOrigin(origin, strings.ToLower(allowedOrigin)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchOrigin - Compare the origin with an allowed one, *. matches any subdomain
+func matchOrigin(origin string, allowed string) bool {
+	index := strings.Index(allowed, "*.")
+
+	if index < 0 {
+		return origin == allowed
+	}
+
+	prefix := allowed[:index]
+	suffix := allowed[index+1:]
+
+	return len(origin) > len(prefix)+len(suffix) && strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, suffix)
+}
+
+func allowsAnyOrigin(allowed []string) bool {
+	for _, allowedOrigin := range allowed {
+		if allowedOrigin == "*" {
+			return true
+		}
+	}
+
+	return false
+}
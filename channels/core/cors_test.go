@@ -0,0 +1,46 @@
+package core
+
+import "testing"
+
+func TestCORSAllowOrigin(t *testing.T) {
+	defer SetCORSConfig(DefaultCORSConfig)
+
+	if CORSAllowOrigin("https://example.com", "app") != "*" {
+		t.Error("Expected any origin to be allowed by default")
+	}
+
+	SetCORSConfig(CORSConfig{
+		AllowedOrigins: []string{"https://example.com", "https://*.example.org"},
+		AppOrigins:     map[string][]string{"app": {"http://localhost:3000"}},
+	})
+
+	defer appOrigins.Delete("app")
+
+	if CORSAllowOrigin("https://EXAMPLE.com", "other") != "https://EXAMPLE.com" {
+		t.Error("Expected origins to be compared ignoring case")
+	}
+
+	if CORSAllowOrigin("https://api.example.org", "") == "" || CORSAllowOrigin("https://example.org", "") != "" {
+		t.Error("Expected only subdomains to match the wildcard")
+	}
+
+	if CORSAllowOrigin("https://evil.com", "app") != "" || CORSAllowOrigin("http://example.com", "app") != "" {
+		t.Error("Expected other origins to be refused")
+	}
+
+	if CORSAllowOrigin("http://localhost:3000", "app") == "" || CORSAllowOrigin("http://localhost:3000", "other") != "" {
+		t.Error("Expected the app origin to be allowed only for the app")
+	}
+
+	if CORSPreflightAllowOrigin("http://localhost:3000") == "" {
+		t.Error("Expected preflight requests to allow the origins of every app")
+	}
+
+	if !IsOriginAllowed("", "other") || IsOriginAllowed("https://evil.com", "other") {
+		t.Error("Expected only requests without origin or from allowed ones to connect")
+	}
+
+	if len(GetCORSConfig().AllowedMethods) == 0 {
+		t.Error("Expected unset methods to use the defaults")
+	}
+}
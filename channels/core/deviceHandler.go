@@ -1,14 +1,13 @@
 package core
 
 import (
-	"fmt"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/lisomatrix/channels/channels/auth"
 	"io/ioutil"
 	"net/http"
-	"os"
 
 	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
 )
 
 type createDeviceRequest struct {
@@ -69,7 +68,7 @@ func CreateDevice(context *gin.Context) {
 		device, err := GetEngine().GetDeviceRepository().GetDevice(createDeviceRequest.DeviceID)
 
 		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "HTTP Create Device: failed to check device existence %v\n", err)
+			logger.WithField("AppID", appID).WithError(err).Error("HTTP Create Device: failed to check device existence")
 			writer.WriteHeader(http.StatusInternalServerError)
 			return
 		}
@@ -96,7 +95,7 @@ func CreateDevice(context *gin.Context) {
 	err = GetEngine().GetDeviceRepository().CreateDevice(device.ID, device.Token, device.ClientID)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Create Device: failed to create device %v\n", err)
+		logger.WithField("AppID", appID).WithError(err).Error("HTTP Create Device: failed to create device")
 		writer.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -141,7 +140,7 @@ func RemoveDevice(context *gin.Context) {
 	device, err := GetEngine().GetDeviceRepository().GetDevice(deviceID)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP Delete Device: failed to check device existence %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "DeviceID": deviceID}).WithError(err).Error("HTTP Delete Device: failed to check device existence")
 		writer.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -161,7 +160,7 @@ func RemoveDevice(context *gin.Context) {
 	err = GetEngine().GetDeviceRepository().DeleteDevice(deviceID)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "HTTP DELETE Device: failed to delete device %v\n", err)
+		logger.WithFields(log.Fields{"AppID": appID, "DeviceID": deviceID}).WithError(err).Error("HTTP DELETE Device: failed to delete device")
 		writer.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -0,0 +1,67 @@
+package core
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/lisomatrix/channels/channels/auth"
+	log "github.com/sirupsen/logrus"
+)
+
+// PostDirectChannel - Get the direct messages channel with another client, creating it on first use
+// POST /direct/:clientID
+func PostDirectChannel(context *gin.Context) {
+	request := context.Request
+	writer := context.Writer
+
+	// Check for required headers
+	token, appID, isOK := auth.GetAuthData(request)
+
+	if !isOK {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Validate token
+	identity, isOK := auth.VerifyToken(token)
+
+	// If not valid return
+	if !isOK || !identity.CanUseAppID(appID) {
+		writer.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	otherClientID := context.Params.ByName("clientID")
+
+	// Tokens without client can't have direct messages
+	if otherClientID == "" || identity.ClientID == "" || otherClientID == identity.ClientID {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	channel, err := GetDirectChannel(appID, identity.ClientID, otherClientID)
+
+	if err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ClientID": identity.ClientID}).WithError(err).Error("HTTP Post direct channel: failed to get direct channel")
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if channel == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+	data, err := json.Marshal(channel)
+
+	if err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channel.ID}).WithError(err).Error("HTTP Post direct channel: failed to marshal response")
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(data)
+}
@@ -0,0 +1,113 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	log "github.com/sirupsen/logrus"
+)
+
+// DirectChannelPrefix - Prefix of the channels created for direct messages between two clients
+const DirectChannelPrefix = "dm-"
+
+// directChannelExtra - Extra of direct message channels, so apps can tell who is talking
+type directChannelExtra struct {
+	Clients []string `json:"clients"`
+}
+
+// DirectChannelID - ID of the direct messages channel of two clients, the same whatever order they are given
+// Client IDs are hashed so the channel ID is short and safe to use in cache and publisher keys
+func DirectChannelID(appID string, clientID string, otherClientID string) string {
+	if otherClientID < clientID {
+		clientID, otherClientID = otherClientID, clientID
+	}
+
+	hash := sha256.Sum256([]byte(appID + "\x00" + clientID + "\x00" + otherClientID))
+
+	return DirectChannelPrefix + hex.EncodeToString(hash[:16])
+}
+
+// GetDirectChannel - Get the direct messages channel of two clients of the app
+// On first use it is created private and persistent, on every use both clients are joined if they aren't yet
+// Returns nil if the other client doesn't exist or both are the same client
+func GetDirectChannel(appID string, clientID string, otherClientID string) (*Channel, error) {
+
+	if clientID == "" || otherClientID == "" || clientID == otherClientID {
+		return nil, nil
+	}
+
+	channelID := DirectChannelID(appID, clientID, otherClientID)
+
+	channel, err := GetChannel(appID, channelID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if channel != nil {
+		if err := joinDirectChannel(appID, channel, clientID, otherClientID); err != nil {
+			return nil, err
+		}
+
+		return channel, nil
+	}
+
+	otherClient, err := GetClient(appID, otherClientID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if otherClient == nil {
+		return nil, nil
+	}
+
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+	extra, err := json.MarshalToString(directChannelExtra{Clients: []string{clientID, otherClientID}})
+
+	if err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "ClientID": clientID}).WithError(err).Error("Get direct channel: failed to marshal channel extra")
+		return nil, err
+	}
+
+	channel = &Channel{
+		ID:         channelID,
+		AppID:      appID,
+		CreatedAt:  time.Now().Unix(),
+		Extra:      extra,
+		Persistent: true,
+		Private:    true,
+		Presence:   true,
+		Push:       true,
+	}
+
+	created, err := CreateChannel(appID, channel)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Another request created it meanwhile
+	if !created {
+		if channel, err = GetChannel(appID, channelID); err != nil || channel == nil {
+			return nil, err
+		}
+	}
+
+	if err := joinDirectChannel(appID, channel, clientID, otherClientID); err != nil {
+		return nil, err
+	}
+
+	return channel, nil
+}
+
+// joinDirectChannel - Make sure both clients are in the direct messages channel, in one transaction
+// Clients already in it are skipped, so members missing after a failed or concurrent creation are added on next use
+func joinDirectChannel(appID string, channel *Channel, clientID string, otherClientID string) error {
+	_, _, err := JoinChannelClients(appID, channel.ID, []string{clientID, otherClientID})
+
+	return err
+}
@@ -0,0 +1,27 @@
+package core
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDirectChannelID(t *testing.T) {
+	channelID := DirectChannelID("app", "alice", "bob")
+
+	if channelID != DirectChannelID("app", "bob", "alice") {
+		t.Error("Expected the same channel ID whatever the order of the clients")
+	}
+
+	if channelID == DirectChannelID("other", "alice", "bob") {
+		t.Error("Expected different channel IDs for different apps")
+	}
+
+	// Separators keep "a"+"bc" and "ab"+"c" apart
+	if DirectChannelID("app", "a", "bc") == DirectChannelID("app", "ab", "c") {
+		t.Error("Expected different channel IDs for different clients")
+	}
+
+	if !strings.HasPrefix(channelID, DirectChannelPrefix) || strings.Contains(channelID, ":") {
+		t.Errorf("Unexpected channel ID %s", channelID)
+	}
+}
@@ -0,0 +1,78 @@
+package core
+
+import (
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/lisomatrix/channels/channels/auth"
+)
+
+type disconnectClientRequest struct {
+	DeviceID string `json:"deviceID"` // Only disconnect this device, every device if empty
+	Revoke   bool   `json:"revoke"`   // Also refuse the tokens issued until now
+}
+
+// DisconnectClientHandler - Close the client sessions on every server, optionally revoking its tokens
+// The body is optional, without it every device is disconnected and the tokens are kept
+// POST /client/:clientID/disconnect
+func DisconnectClientHandler(context *gin.Context) {
+
+	request := context.Request
+	writer := context.Writer
+
+	// Check for required headers
+	token, appID, isOK := auth.GetAuthData(request)
+
+	if !isOK {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Check if is admin, and validate token
+	identity, isOK := authenticateAdmin(token, ScopeManageClients)
+
+	// If not valid return
+	if !isOK || !identity.CanUseAppID(appID) {
+		writer.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	clientID := context.Params.ByName("clientID")
+
+	if clientID == "" {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	body, err := ioutil.ReadAll(request.Body)
+
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var disconnectRequest disconnectClientRequest
+
+	if len(body) > 0 {
+		var json = jsoniter.ConfigCompatibleWithStandardLibrary
+		if err := json.Unmarshal(body, &disconnectRequest); err != nil {
+			writer.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	if !disconnectRequest.Revoke {
+		DisconnectClient(appID, clientID, disconnectRequest.DeviceID)
+		writer.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if !RevokeClient(appID, clientID, disconnectRequest.DeviceID) {
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	writer.WriteHeader(http.StatusOK)
+}
@@ -1,10 +1,13 @@
 package core
 
 import (
+	"context"
+	"strings"
 	"time"
 
 	"github.com/rs/xid"
 	log "github.com/sirupsen/logrus"
+	"go.uber.org/atomic"
 )
 
 // Engine - Holds application components
@@ -19,6 +22,7 @@ type Engine struct {
 	pushHandler     PushNotificationHandler
 	storageInsert   StorageInsert
 	authHook        AuthHook
+	isShuttingDown  atomic.Bool
 }
 
 // StoreEvent - Append channel to insert queue
@@ -28,6 +32,97 @@ func (engine *Engine) StoreEvent(appID string, event *ChannelEvent) {
 	}
 }
 
+// LastQueuedEventID - Highest event ID of the channel waiting in the insert queue, 0 if there is none
+func (engine *Engine) LastQueuedEventID(appID string, channelID string) uint64 {
+	if engine.storageInsert != nil {
+		return engine.storageInsert.LastQueuedEventID(appID, channelID)
+	}
+
+	return 0
+}
+
+// EditStoredEvent - Append event payload update to insert queue
+func (engine *Engine) EditStoredEvent(appID string, event *ChannelEvent) {
+	if engine.storageInsert != nil {
+		engine.storageInsert.EditEvent(appID, event)
+	}
+}
+
+// DeleteStoredEvent - Append event removal to insert queue
+func (engine *Engine) DeleteStoredEvent(appID string, channelID string, eventID uint64) {
+	if engine.storageInsert != nil {
+		engine.storageInsert.DeleteEvent(appID, channelID, eventID)
+	}
+}
+
+// IsShuttingDown - If the engine is shutting down, new connections shouldn't be accepted
+func (engine *Engine) IsShuttingDown() bool {
+	return engine.isShuttingDown.Load()
+}
+
+// CloseSessions - Stop accepting connections, close connected sessions and unsubscribe channels from publisher
+// It only runs once, so it is safe to call before Shutdown to release long lived requests like SSE streams
+func (engine *Engine) CloseSessions() {
+	if !engine.isShuttingDown.CAS(false, true) {
+		return
+	}
+
+	engine.hubsHandler.Shutdown()
+}
+
+// Shutdown - Close sessions and wait until insert and push queues are flushed or the context is done
+// Every step runs even if a previous one failed, their errors are returned together
+func (engine *Engine) Shutdown(ctx context.Context) error {
+	logger.Info("Shutting down engine")
+
+	engine.CloseSessions()
+
+	var errs shutdownErrors
+
+	if engine.storageInsert != nil {
+		if err := engine.storageInsert.Stop(ctx); err != nil {
+			logger.WithFields(log.Fields{
+				"Step": "StorageInsert",
+			}).Error(err)
+			errs = append(errs, err)
+		}
+	}
+
+	if engine.pushHandler != nil {
+		if err := engine.pushHandler.Stop(ctx); err != nil {
+			logger.WithFields(log.Fields{
+				"Step": "PushNotificationHandler",
+			}).Error(err)
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) == 1 {
+		return errs[0]
+	}
+
+	if len(errs) > 1 {
+		return errs
+	}
+
+	logger.Info("Engine shut down")
+
+	return nil
+}
+
+// shutdownErrors - Errors of the shutdown steps that failed, in the order they ran
+type shutdownErrors []error
+
+func (errs shutdownErrors) Error() string {
+	messages := make([]string, len(errs))
+
+	for index, err := range errs {
+		messages[index] = err.Error()
+	}
+
+	return strings.Join(messages, "; ")
+}
+
 // GetCacheStorage - Get cache storage instance
 func (engine *Engine) GetCacheStorage() CacheStorage {
 	return engine.cacheStorage
@@ -56,6 +151,11 @@ func (engine *Engine) GetClientRepository() ClientRepository {
 	return engine.databaseStorage.GetClientRepository()
 }
 
+// GetAPIKeyRepository - Get persistent repository
+func (engine *Engine) GetAPIKeyRepository() APIKeyRepository {
+	return engine.databaseStorage.GetAPIKeyRepository()
+}
+
 // GetPublisher - Get Publisher handler
 func (engine *Engine) GetPublisher() PublishHandler {
 	return engine.publisher
@@ -96,46 +196,72 @@ type EngineConfig struct {
 	PublishHandler          PublishHandler          // Publish between servers handler
 	PresenceHandler         PresenceHandler         // Handler for tracking user presence
 	PushNotificationHandler PushNotificationHandler // Handler for sending push notifications
-	DBWorkers               int                     // If set to -1 it will to to the default of 10
+	DBWorkers               int                     // If set to -1 it will to to the default of 10, StorageInsertQueue starts one more than this
 	InsertCacheLimit        int                     // Amount of events stored before batching into the database
+	InsertCacheTimeout      time.Duration           // Max time events wait to be batched into the database, defaults to 5 seconds
+	InsertRetries           int                     // Attempts to insert a batch before inserting events one by one, defaults to 3
 	StorageInsert           StorageInsert           // Handler for events being stored, you can use this to batch to events, or simply ignore them. For a batching default one use StorageInsertQueue, that uses the property InsertCacheLimit
 	AuthHook                AuthHook                // For the default connection, to authorize connections
+	SignalRate              float64                 // Signals per second each session can send, defaults to 5, -1 disables the limit
+	SignalBurst             int                     // Signals a session can send at once before being limited, defaults to 10
+	EventRulesRefresh       time.Duration           // Max time event type rules changed on other servers take to apply, defaults to 30 seconds
+	ConnectionTicketTTL     time.Duration           // How long a connection ticket can be used, defaults to 30 seconds
+	RevocationTTL           time.Duration           // How long token revocations are kept, should be at least the tokens lifetime, defaults to 24 hours
+	ReplayLimit             int64                   // Most missed events sent when subscribing with a last event ID, defaults to 1000
+	APIKeyCacheTTL          time.Duration           // How long API keys are cached, deleted keys work on other servers until then, defaults to 30 seconds
+	Limits                  Limits                  // Payload size, publish rates and connections limits of all apps, unset fields use DefaultLimits
+	AppLimits               map[string]Limits       // Limits of specific apps by AppID, unset fields use Limits
+	CORS                    CORSConfig              // Allowed origins, methods and headers of browsers, unset fields use DefaultCORSConfig
+	Logger                  *log.Logger             // Logger used by all components, if nil one is created from LogConfig
+	LogConfig               LogConfig               // Level, format and output of the created logger, defaults to info level text logs on stderr
 }
 
 func InitEngine(config EngineConfig) {
 
-	SetUpLogger()
+	if config.Logger == nil {
+		newLogger, err := NewLogger(config.LogConfig)
+
+		if err != nil {
+			logger.WithFields(log.Fields{
+				"LogConfig": config.LogConfig,
+			}).Fatal(err)
+		}
+
+		config.Logger = newLogger
+	}
+
+	SetLogger(config.Logger)
 
 	if config.ServerID == "" {
 		config.ServerID = xid.New().String()
 	}
 
 	if config.DBStorage == nil {
-		log.WithFields(log.Fields{
+		logger.WithFields(log.Fields{
 			"EngineConfig": config,
 		}).Fatal("Missing DatabaseStorage on EngineConfig")
 	}
 
 	if config.CacheStorage == nil {
-		log.WithFields(log.Fields{
+		logger.WithFields(log.Fields{
 			"EngineConfig": config,
 		}).Fatal("Missing CacheStorage on EngineConfig")
 	}
 
 	if config.PublishHandler == nil {
-		log.WithFields(log.Fields{
+		logger.WithFields(log.Fields{
 			"EngineConfig": config,
 		}).Fatal("Missing PublishHandler on EngineConfig")
 	}
 
 	if config.PresenceHandler == nil {
-		log.WithFields(log.Fields{
+		logger.WithFields(log.Fields{
 			"EngineConfig": config,
 		}).Fatal("Missing PresenceHandler on EngineConfig")
 	}
 
 	if config.PushNotificationHandler == nil {
-		log.WithFields(log.Fields{
+		logger.WithFields(log.Fields{
 			"EngineConfig": config,
 		}).Fatal("Missing PushNotificationHandler on EngineConfig")
 	}
@@ -148,11 +274,13 @@ func InitEngine(config EngineConfig) {
 		config.DBWorkers = 10
 	}
 
+	InsertWorkers = config.DBWorkers + 1
+
 	engine = &Engine{
 		serverID:        config.ServerID,
 		hubsHandler:     config.HubsHandler,
 		databaseStorage: config.DBStorage,
-		cacheStorage:    config.CacheStorage,
+		cacheStorage:    &metricsCacheStorage{config.CacheStorage},
 		insertQueue:     config.StorageInsert,
 		publisher:       config.PublishHandler,
 		presence:        config.PresenceHandler,
@@ -161,21 +289,83 @@ func InitEngine(config EngineConfig) {
 		authHook:        config.AuthHook,
 	}
 
-	CacheLimit = config.InsertCacheLimit
+	if config.InsertCacheLimit > 0 {
+		CacheLimit = config.InsertCacheLimit
+	}
 
-	var index = 0
-	for {
+	if config.InsertCacheTimeout > 0 {
+		CacheTimeout = config.InsertCacheTimeout
+	}
 
-		if index > config.DBWorkers {
-			break
-		}
-		go engine.storageInsert.Start(config.DBStorage.GetChannelRepository())
-		index++
+	if config.InsertRetries > 0 {
+		InsertRetries = config.InsertRetries
+	}
+
+	if config.SignalRate != 0 {
+		SignalRate = config.SignalRate
+	}
+
+	if config.SignalBurst > 0 {
+		SignalBurst = config.SignalBurst
+	}
+
+	if config.EventRulesRefresh > 0 {
+		EventRulesRefresh = config.EventRulesRefresh
+	}
+
+	if config.ConnectionTicketTTL > 0 {
+		ConnectionTicketTTL = config.ConnectionTicketTTL
+	}
+
+	if config.RevocationTTL > 0 {
+		RevocationTTL = config.RevocationTTL
 	}
+
+	if config.ReplayLimit > 0 {
+		ReplayLimit = config.ReplayLimit
+	}
+
+	if config.APIKeyCacheTTL > 0 {
+		APIKeyCacheTTL = config.APIKeyCacheTTL
+	}
+
+	DefaultLimits = config.Limits.withDefaults(DefaultLimits)
+
+	for appID, limits := range config.AppLimits {
+		SetAppLimits(appID, limits)
+	}
+
+	// Keep the config given to SetCORSConfig when the engine has none
+	if config.CORS.isSet() {
+		SetCORSConfig(config.CORS)
+	}
+
+	// It runs InsertWorkers workers itself
+	go engine.storageInsert.Start(config.DBStorage.GetChannelRepository())
 }
 
 var CacheLimit = 70 // Amount of insert before batching
 
+var CacheTimeout = 5 * time.Second // Max time before batching
+
+var InsertRetries = 3 // Attempts to insert a batch
+
+var InsertWorkers = 11 // Workers of the StorageInsertQueue, each one stores the events of its channels in order
+
+var SignalRate = 5.0 // Signals per second each session can send
+
+var SignalBurst = 10 // Signals a session can send at once
+
+var EventRulesRefresh = 30 * time.Second // Max time before reloading cached event type rules
+
+var ConnectionTicketTTL = 30 * time.Second // How long a connection ticket can be used
+
+var RevocationTTL = 24 * time.Hour // How long token revocations are kept
+
+var ReplayLimit int64 = 1000 // Most missed events sent on subscribe
+
+var APIKeyCacheTTL = 30 * time.Second // How long API keys are cached
+
 const (
-	CacheTimeout = 5 * time.Second
+	InsertRetryDelay = 500 * time.Millisecond // Delay between insert attempts, multiplied by the attempt
 )
@@ -0,0 +1,53 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// stopFailer - Insert queue and push handler whose Stop fails
+type stopFailer struct {
+	StorageInsert
+	PushNotificationHandler
+	err     error
+	stopped bool
+}
+
+func (failer *stopFailer) Stop(ctx context.Context) error {
+	failer.stopped = true
+	return failer.err
+}
+
+func (failer *stopFailer) Len() int {
+	return 0
+}
+
+func TestEngineShutdownRunsEveryStep(t *testing.T) {
+	insertQueue := &stopFailer{err: errors.New("insert queue not flushed")}
+	pushHandler := &stopFailer{err: errors.New("push queue not flushed")}
+
+	testEngine := &Engine{
+		hubsHandler:   NewHubsHandler(nil),
+		storageInsert: insertQueue,
+		pushHandler:   pushHandler,
+	}
+
+	err := testEngine.Shutdown(context.Background())
+
+	if !insertQueue.stopped || !pushHandler.stopped {
+		t.Fatal("Expected every step to run")
+	}
+
+	if err == nil || !strings.Contains(err.Error(), "insert queue") || !strings.Contains(err.Error(), "push queue") {
+		t.Errorf("Expected both errors, got %v", err)
+	}
+
+	pushHandler.err = nil
+	insertQueue.err = context.DeadlineExceeded
+
+	if err := (&Engine{hubsHandler: NewHubsHandler(nil), storageInsert: insertQueue, pushHandler: pushHandler}).Shutdown(context.Background()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the only error to be returned as is, got %v", err)
+	}
+}
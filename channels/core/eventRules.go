@@ -0,0 +1,158 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// MaxEventTypeLength - Max length of an event type, as stored in the database
+const MaxEventTypeLength = 50
+
+// ErrInvalidEventTypeRules - The rules have an empty, too long or repeated event type
+var ErrInvalidEventTypeRules = errors.New("invalid event type rules")
+
+// eventRules - Compiled event type rules of a channel or app
+type eventRules struct {
+	schemas  map[string]*jsonSchema // Allowed event types, with a nil schema if the payload isn't validated
+	loadedAt time.Time
+}
+
+// Rules by app and channel, rules of other servers are reloaded after EventRulesRefresh
+var eventRulesCache sync.Map
+
+func eventRulesKey(appID string, channelID string) string {
+	return appID + ":" + channelID
+}
+
+// compileEventRules - Check and compile the schemas of the given rules
+func compileEventRules(rules []*EventTypeRule) (*eventRules, error) {
+	compiled := &eventRules{
+		schemas:  make(map[string]*jsonSchema, len(rules)),
+		loadedAt: time.Now(),
+	}
+
+	for _, rule := range rules {
+		if rule.EventType == "" || len(rule.EventType) > MaxEventTypeLength {
+			return nil, fmt.Errorf("%w: event type must have between 1 and %d characters", ErrInvalidEventTypeRules, MaxEventTypeLength)
+		}
+
+		if _, exists := compiled.schemas[rule.EventType]; exists {
+			return nil, fmt.Errorf("%w: event type %s is repeated", ErrInvalidEventTypeRules, rule.EventType)
+		}
+
+		var schema *jsonSchema
+
+		if rule.Schema != "" {
+			var err error
+
+			if schema, err = compileJSONSchema(rule.Schema); err != nil {
+				return nil, fmt.Errorf("%s: %w", rule.EventType, err)
+			}
+		}
+
+		compiled.schemas[rule.EventType] = schema
+	}
+
+	return compiled, nil
+}
+
+// loadEventRules - Get the channel rules, or the app rules if channelID is empty, from cache or the database
+func loadEventRules(appID string, channelID string) (*eventRules, error) {
+	key := eventRulesKey(appID, channelID)
+
+	if data, isOK := eventRulesCache.Load(key); isOK {
+		rules := data.(*eventRules)
+
+		if time.Since(rules.loadedAt) < EventRulesRefresh {
+			return rules, nil
+		}
+	}
+
+	stored, err := GetEngine().GetChannelRepository().GetEventTypeRules(appID, channelID)
+
+	if err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Event rules: failed to get event type rules")
+		return nil, err
+	}
+
+	rules, err := compileEventRules(stored)
+
+	if err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Event rules: failed to compile stored event type rules")
+		return nil, err
+	}
+
+	eventRulesCache.Store(key, rules)
+
+	return rules, nil
+}
+
+// ValidateChannelEvent - Check the event type and payload against the channel rules, or the app rules if the channel has none
+// Without any rules every event is allowed, otherwise a rejection reason is returned for events not following them
+func ValidateChannelEvent(appID string, channelID string, eventType string, payload string) (string, error) {
+	rules, err := loadEventRules(appID, channelID)
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(rules.schemas) == 0 {
+		if rules, err = loadEventRules(appID, ""); err != nil {
+			return "", err
+		}
+
+		if len(rules.schemas) == 0 {
+			return "", nil
+		}
+	}
+
+	schema, isOK := rules.schemas[eventType]
+
+	if !isOK {
+		return "event type not allowed", nil
+	}
+
+	if schema == nil {
+		return "", nil
+	}
+
+	if err := schema.validatePayload(payload); err != nil {
+		return err.Error(), nil
+	}
+
+	return "", nil
+}
+
+// GetEventTypeRules - Get the rules of the channel, or of the app if channelID is empty
+func GetEventTypeRules(appID string, channelID string) ([]*EventTypeRule, error) {
+	rules, err := GetEngine().GetChannelRepository().GetEventTypeRules(appID, channelID)
+
+	if err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Get event type rules: failed to get rules")
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// SetEventTypeRules - Replace the rules of the channel, or of the app if channelID is empty
+// Returns a ErrInvalidEventTypeRules or ErrInvalidJSONSchema error if the rules aren't valid
+// Other servers apply the new rules once their cached ones expire
+func SetEventTypeRules(appID string, channelID string, rules []*EventTypeRule) error {
+	if _, err := compileEventRules(rules); err != nil {
+		return err
+	}
+
+	if err := GetEngine().GetChannelRepository().SetEventTypeRules(appID, channelID, rules); err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Set event type rules: failed to store rules")
+		return err
+	}
+
+	eventRulesCache.Delete(eventRulesKey(appID, channelID))
+
+	return nil
+}
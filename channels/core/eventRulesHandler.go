@@ -0,0 +1,274 @@
+package core
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/lisomatrix/channels/channels/auth"
+	log "github.com/sirupsen/logrus"
+)
+
+type eventTypeRuleJSON struct {
+	EventType string              `json:"eventType"`
+	Schema    jsoniter.RawMessage `json:"schema,omitempty"`
+}
+
+type eventTypeRulesJSON struct {
+	Rules []*eventTypeRuleJSON `json:"rules"`
+}
+
+type eventRejectedResponse struct {
+	Reason string `json:"reason"`
+}
+
+// PutChannelEventRulesHandler - Replace the event types allowed in the channel, an empty list falls back to the app rules
+// PUT /channel/:channelID/events
+func PutChannelEventRulesHandler(context *gin.Context) {
+	request := context.Request
+	writer := context.Writer
+
+	// Check for required headers
+	token, appID, isOK := auth.GetAuthData(request)
+
+	if !isOK {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Check if is admin, and validate token
+	identity, isOK := authenticateAdmin(token, ScopeManageChannels)
+
+	// If not valid return
+	if !isOK || !identity.CanUseAppID(appID) {
+		writer.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	channelID := context.Params.ByName("channelID")
+
+	if channelID == "" {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	exists, err := GetEngine().GetChannelRepository().ExistsAppChannel(appID, channelID)
+
+	if err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Put channel event rules: failed to check app channel existence")
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !exists {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	putEventTypeRules(context, appID, channelID)
+}
+
+// GetChannelEventRulesHandler - Get the event types allowed in the channel
+// GET /c/:channelID/events
+func GetChannelEventRulesHandler(context *gin.Context) {
+	_, appID, channelID, isOK := authorizeChannelRead(context)
+
+	if !isOK {
+		return
+	}
+
+	writeEventTypeRules(context, appID, channelID)
+}
+
+// PutAppEventRulesHandler - Replace the event types allowed in the app channels without their own rules
+// PUT /app/:appID/events
+func PutAppEventRulesHandler(context *gin.Context) {
+	appID, isOK := authorizeAppAdmin(context, ScopeManageChannels)
+
+	if !isOK {
+		return
+	}
+
+	putEventTypeRules(context, appID, "")
+}
+
+// GetAppEventRulesHandler - Get the event types allowed in the app channels without their own rules
+// GET /app/:appID/events
+func GetAppEventRulesHandler(context *gin.Context) {
+	appID, isOK := authorizeAppAdmin(context, ScopeManageChannels)
+
+	if !isOK {
+		return
+	}
+
+	writeEventTypeRules(context, appID, "")
+}
+
+// authorizeAppAdmin - Validate the admin, or API key with the scope, can use the app and it exists, the response is written when it fails
+func authorizeAppAdmin(context *gin.Context, scope string) (string, bool) {
+	request := context.Request
+	writer := context.Writer
+
+	token := request.Header.Get("Authorization")
+
+	if token == "" {
+		writer.WriteHeader(http.StatusUnauthorized)
+		return "", false
+	}
+
+	// Check if is admin, and validate token
+	identity, isOK := authenticateAdmin(token, scope)
+
+	if !isOK {
+		writer.WriteHeader(http.StatusUnauthorized)
+		return "", false
+	}
+
+	appID := context.Params.ByName("appID")
+
+	if appID == "" {
+		writer.WriteHeader(http.StatusBadRequest)
+		return "", false
+	}
+
+	// If client is just admin then check it is their app
+	if !identity.CanUseAppID(appID) {
+		writer.WriteHeader(http.StatusUnauthorized)
+		return "", false
+	}
+
+	app, err := GetEngine().GetAppRepository().GetApp(appID)
+
+	if err != nil {
+		logger.WithField("AppID", appID).WithError(err).Error("HTTP App: failed to check app existence")
+		writer.WriteHeader(http.StatusInternalServerError)
+		return "", false
+	}
+
+	if app == nil {
+		writer.WriteHeader(http.StatusNotFound)
+		return "", false
+	}
+
+	return appID, true
+}
+
+// putEventTypeRules - Read the rules from the request body and store them, invalid rules are answered with the reason
+func putEventTypeRules(context *gin.Context, appID string, channelID string) {
+	writer := context.Writer
+
+	body, err := ioutil.ReadAll(context.Request.Body)
+
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var rulesRequest eventTypeRulesJSON
+
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+	if err := json.Unmarshal(body, &rulesRequest); err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	rules := make([]*EventTypeRule, 0, len(rulesRequest.Rules))
+
+	for _, rule := range rulesRequest.Rules {
+		if rule == nil {
+			writer.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		schema := string(rule.Schema)
+
+		if schema == "null" {
+			schema = ""
+		}
+
+		rules = append(rules, &EventTypeRule{EventType: rule.EventType, Schema: schema})
+	}
+
+	err = SetEventTypeRules(appID, channelID, rules)
+
+	if errors.Is(err, ErrInvalidEventTypeRules) || errors.Is(err, ErrInvalidJSONSchema) {
+		writeRejected(writer, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	writer.WriteHeader(http.StatusOK)
+}
+
+// writeEventTypeRules - Respond with the stored rules of the channel, or of the app if channelID is empty
+func writeEventTypeRules(context *gin.Context, appID string, channelID string) {
+	writer := context.Writer
+
+	rules, err := GetEventTypeRules(appID, channelID)
+
+	if err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	response := eventTypeRulesJSON{Rules: make([]*eventTypeRuleJSON, 0, len(rules))}
+
+	for _, rule := range rules {
+		var schema jsoniter.RawMessage
+
+		if rule.Schema != "" {
+			schema = jsoniter.RawMessage(rule.Schema)
+		}
+
+		response.Rules = append(response.Rules, &eventTypeRuleJSON{EventType: rule.EventType, Schema: schema})
+	}
+
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+	data, err := json.Marshal(response)
+
+	if err != nil {
+		logger.WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("HTTP Get event rules: failed to marshal response")
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	_, _ = writer.Write(data)
+}
+
+// checkChannelEvent - Validate the event against the channel rules, the response is written when it is rejected or fails
+func checkChannelEvent(writer gin.ResponseWriter, appID string, channelID string, eventType string, payload string) bool {
+	reason, err := ValidateChannelEvent(appID, channelID, eventType, payload)
+
+	if err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		return false
+	}
+
+	if reason != "" {
+		writeRejected(writer, http.StatusBadRequest, reason)
+		return false
+	}
+
+	return true
+}
+
+// writeRejected - Respond with the given status and the reason
+func writeRejected(writer gin.ResponseWriter, status int, reason string) {
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+	data, err := json.Marshal(eventRejectedResponse{Reason: reason})
+
+	if err != nil {
+		writer.WriteHeader(status)
+		return
+	}
+
+	writer.WriteHeader(status)
+	_, _ = writer.Write(data)
+}
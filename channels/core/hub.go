@@ -3,6 +3,8 @@ package core
 import (
 	"strings"
 	"sync"
+
+	log "github.com/sirupsen/logrus"
 )
 
 // NewHub - Create a new Hub
@@ -14,10 +16,17 @@ func NewHub(AppID string, hook HubHook) *Hub {
 
 // Hub - Handles channels and publishing
 type Hub struct {
-	AppID            string
-	channels         sync.Map //[string]*Channel
-	connectedClients sync.Map //[string]*Session
-	hook             HubHook
+	AppID             string
+	channels          sync.Map       //[string]*Channel
+	connectedClients  sync.Map       //[string]*Session
+	clientConnections map[string]int // Connected sessions of each client
+	connectionsLock   sync.Mutex
+	hook              HubHook
+}
+
+// logger - Logger with the hub fields
+func (hub *Hub) logger() *log.Entry {
+	return logger.WithField("AppID", hub.AppID)
 }
 
 // DeleteChannel - Remove channel including subscriptions
@@ -66,30 +75,115 @@ func (hub *Hub) RemoveChannelFromClient(clientID string, channelID string) {
 	})
 }
 
-// AddClient - Add client to connected map
-func (hub *Hub) AddClient(session *Session) {
+// CanAddClient - Check the client has less connections than the app limits allow
+// A device reconnecting replaces its previous session, so it is always allowed
+// AddClient checks it again, this only allows refusing connections before upgrading them
+func (hub *Hub) CanAddClient(clientID string, deviceID string) bool {
+	hub.connectionsLock.Lock()
+	defer hub.connectionsLock.Unlock()
+
+	return hub.canAddClient(clientID, clientID+"-"+deviceID, deviceID != "")
+}
+
+// canAddClient - Check the connections limit, connectionsLock must be held
+func (hub *Hub) canAddClient(clientID string, identifier string, canReplace bool) bool {
+	limits := GetAppLimits(hub.AppID)
+
+	if limits.MaxClientConnections < 0 {
+		return true
+	}
+
+	if canReplace {
+		if _, isOK := hub.connectedClients.Load(identifier); isOK {
+			return true
+		}
+	}
+
+	return hub.clientConnections[clientID] < limits.MaxClientConnections
+}
+
+// AddClient - Add client to connected map, if the client has less connections than the app limits allow
+// A device reconnecting replaces its previous session, so it is always added and the previous session is closed
+// Returns false if the session wasn't added, it should be closed
+func (hub *Hub) AddClient(session *Session) bool {
+	hub.connectionsLock.Lock()
+
+	if !hub.canAddClient(session.clientID, session.GetIdentifier(), true) {
+		hub.connectionsLock.Unlock()
+		return false
+	}
+
+	previous, replaced := hub.connectedClients.Load(session.GetIdentifier())
 	hub.connectedClients.Store(session.GetIdentifier(), session)
 
+	if !replaced {
+		if hub.clientConnections == nil {
+			hub.clientConnections = make(map[string]int)
+		}
+
+		hub.clientConnections[session.clientID]++
+	}
+
+	hub.connectionsLock.Unlock()
+
+	// It is no longer the current session, so removing it keeps the new one connected
+	if replaced && previous.(*Session) != session {
+		previous.(*Session).CloseWithStatus(CloseStatusPolicy, "replaced by a new connection")
+	}
+
 	if hub.hook != nil {
 		hub.hook.OnSessionAdded(session, hub)
 	}
+
+	return true
 }
 
 // RemoveClient - Remove client from connected clients and channels
+// A session replaced by a new one of the same device leaves its channels, but the new one stays connected
 func (hub *Hub) RemoveClient(session *Session) {
-	_, isOK := hub.connectedClients.LoadAndDelete(session.GetIdentifier())
+	hub.connectionsLock.Lock()
 
-	if isOK {
-		for _, channel := range session.SubscribedChannels {
-			hub.removeSessionFromChannel(channel.Data.ID, session)
+	if session.isRemoved {
+		hub.connectionsLock.Unlock()
+		return
+	}
+
+	session.isRemoved = true
+
+	if value, isOK := hub.connectedClients.Load(session.GetIdentifier()); isOK && value.(*Session) == session {
+		hub.connectedClients.Delete(session.GetIdentifier())
+
+		hub.clientConnections[session.clientID]--
+
+		if hub.clientConnections[session.clientID] <= 0 {
+			delete(hub.clientConnections, session.clientID)
 		}
 	}
 
+	hub.connectionsLock.Unlock()
+
+	for _, channel := range session.SubscribedChannels {
+		hub.removeSessionFromChannel(channel.Data().ID, session)
+	}
+
 	if hub.hook != nil {
 		hub.hook.OnSessionRemoved(session, hub)
 	}
 }
 
+// DisconnectClient - Close the client sessions of this server, of every device if deviceID is empty
+func (hub *Hub) DisconnectClient(clientID string, deviceID string, reason string) {
+	hub.connectedClients.Range(func(key interface{}, value interface{}) bool {
+		session := value.(*Session)
+
+		if session.clientID == clientID && (deviceID == "" || session.deviceID == deviceID) {
+			session.CloseWithStatus(CloseStatusDisconnected, reason)
+		}
+
+		return true
+	})
+}
+
 func (hub *Hub) removeSessionFromChannel(channelID string, session *Session) {
 	data, isOK := hub.channels.Load(channelID)
 
@@ -101,6 +195,21 @@ func (hub *Hub) removeSessionFromChannel(channelID string, session *Session) {
 	chann.RemoveClient(session)
 }
 
+// Shutdown - Close all sessions letting clients know the server is going away
+// and stop receiving events of the hub channels from other servers
+func (hub *Hub) Shutdown() {
+	hub.connectedClients.Range(func(key interface{}, value interface{}) bool {
+		value.(*Session).CloseWithStatus(CloseStatusGoingAway, "server shutting down")
+		return true
+	})
+
+	hub.channels.Range(func(key interface{}, value interface{}) bool {
+		chann := value.(*HubChannel)
+		GetEngine().GetPublisher().Unsubscribe(chann.Data().AppID, chann.Data().ID)
+		return true
+	})
+}
+
 // Close - Remove all channels and connections
 func (hub *Hub) Close() {
 	if hub.hook != nil {
@@ -137,7 +246,8 @@ func (hub *Hub) ContainsChannel(channelID string) *HubChannel {
 }
 
 // Publish - Send the given payload to subscribed session
-func (hub *Hub) Publish(channelID string, channelEvent *ChannelEvent, shouldStore bool, session *Session) bool {
+// Events not following the channel event type rules are rejected, returning the reason
+func (hub *Hub) Publish(channelID string, channelEvent *ChannelEvent, shouldStore bool, session *Session) (bool, string) {
 
 	// Get channel from local cache
 	data, isOk := hub.channels.Load(channelID)
@@ -151,7 +261,7 @@ func (hub *Hub) Publish(channelID string, channelEvent *ChannelEvent, shouldStor
 
 		// If not found cancel publish
 		if chann == nil {
-			return false
+			return false, ""
 		}
 
 		// If found cache it
@@ -162,6 +272,12 @@ func (hub *Hub) Publish(channelID string, channelEvent *ChannelEvent, shouldStor
 		chann = data.(*HubChannel)
 	}
 
+	reason, err := ValidateChannelEvent(hub.AppID, channelID, channelEvent.EventType, channelEvent.Payload)
+
+	if err != nil || reason != "" {
+		return false, reason
+	}
+
 	// If a hook is set
 	if hub.hook != nil {
 
@@ -170,7 +286,7 @@ func (hub *Hub) Publish(channelID string, channelEvent *ChannelEvent, shouldStor
 
 		// If returned false, we won't publish nor store
 		if !shouldAllow {
-			return false
+			return false, ""
 		}
 
 		// Otherwise set as requested
@@ -178,7 +294,7 @@ func (hub *Hub) Publish(channelID string, channelEvent *ChannelEvent, shouldStor
 	}
 
 	// Publish event
-	return chann.Publish(channelEvent, shouldStore)
+	return chann.Publish(channelEvent, shouldStore), ""
 }
 
 // Subscribe - Add subscriber to given channel
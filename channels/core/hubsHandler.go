@@ -49,6 +49,14 @@ func (handler *HubsHandler) NewHub(AppID string) *Hub {
 	return hub
 }
 
+// Shutdown - Shutdown all hubs in this server
+func (handler *HubsHandler) Shutdown() {
+	handler.hubs.Range(func(key interface{}, value interface{}) bool {
+		value.(*Hub).Shutdown()
+		return true
+	})
+}
+
 // RemoveHub - Remove hub from active hub and close all channels and connections
 func (handler *HubsHandler) RemoveHub(AppID string) {
 	data, isOK := handler.hubs.LoadAndDelete(AppID)
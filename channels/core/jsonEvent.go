@@ -0,0 +1,140 @@
+package core
+
+import (
+	"errors"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+var (
+	ErrUnsupportedEventType = errors.New("unsupported event type")
+)
+
+// JSONNewEvent - JSON representation of NewEvent for clients that don't use protobuf
+// The type is the NewEventType name and the payload the JSON of the message it carries
+type JSONNewEvent struct {
+	Type    string              `json:"type"`
+	Payload jsoniter.RawMessage `json:"payload,omitempty"`
+}
+
+// DecodeNewEventPayload - Unmarshal the message carried by an event sent to clients
+// NEW_CHANNEL and REMOVE_CHANNEL carry the channel ID as string
+func DecodeNewEventPayload(newEvent *NewEvent) (interface{}, error) {
+	var message interface{ Unmarshal([]byte) error }
+
+	switch newEvent.Type {
+	case NewEvent_NEW_CHANNEL, NewEvent_REMOVE_CHANNEL:
+		return string(newEvent.Payload), nil
+	case NewEvent_PUBLISH, NewEvent_EDIT:
+		message = &ChannelEvent{}
+	case NewEvent_DELETE:
+		message = &ChannelEventDelete{}
+	case NewEvent_READ:
+		message = &ReadReceipt{}
+	case NewEvent_SIGNAL:
+		message = &ChannelSignal{}
+	case NewEvent_ACK:
+		message = &PublishAck{}
+	case NewEvent_JOIN_CHANNEL:
+		message = &ClientJoin{}
+	case NewEvent_LEAVE_CHANNEL:
+		message = &ClientLeave{}
+	case NewEvent_ONLINE_STATUS:
+		message = &OnlineStatusUpdate{}
+	case NewEvent_INITIAL_ONLINE_STATUS:
+		message = &InitialPresenceStatus{}
+	default:
+		return nil, ErrUnsupportedEventType
+	}
+
+	if err := message.Unmarshal(newEvent.Payload); err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// MarshalNewEventJSON - Convert protobuf NewEvent sent to clients into JSON
+func MarshalNewEventJSON(data []byte) ([]byte, error) {
+	var newEvent NewEvent
+
+	if err := newEvent.Unmarshal(data); err != nil {
+		return nil, err
+	}
+
+	payload, err := DecodeNewEventPayload(&newEvent)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+	payloadData, err := json.Marshal(payload)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&JSONNewEvent{
+		Type:    newEvent.Type.String(),
+		Payload: payloadData,
+	})
+}
+
+// UnmarshalNewEventJSON - Convert JSON NewEvent sent by clients into protobuf
+// Only the requests clients can send are accepted
+func UnmarshalNewEventJSON(data []byte) ([]byte, error) {
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+	var jsonEvent JSONNewEvent
+
+	if err := json.Unmarshal(data, &jsonEvent); err != nil {
+		return nil, err
+	}
+
+	eventType, isOK := NewEvent_NewEventType_value[jsonEvent.Type]
+
+	if !isOK {
+		return nil, ErrUnsupportedEventType
+	}
+
+	var message interface{ Marshal() ([]byte, error) }
+
+	switch NewEvent_NewEventType(eventType) {
+	case NewEvent_SUBSCRIBE, NewEvent_UNSUBSCRIBE:
+		message = &SubscribeRequest{}
+	case NewEvent_PUBLISH:
+		message = &PublishRequest{}
+	case NewEvent_DIRECT:
+		message = &DirectRequest{}
+	case NewEvent_EDIT:
+		message = &EditRequest{}
+	case NewEvent_DELETE:
+		message = &DeleteRequest{}
+	case NewEvent_READ:
+		message = &ReadRequest{}
+	case NewEvent_SIGNAL:
+		message = &SignalRequest{}
+	default:
+		return nil, ErrUnsupportedEventType
+	}
+
+	if len(jsonEvent.Payload) != 0 {
+		if err := json.Unmarshal(jsonEvent.Payload, message); err != nil {
+			return nil, err
+		}
+	}
+
+	payload, err := message.Marshal()
+
+	if err != nil {
+		return nil, err
+	}
+
+	newEvent := NewEvent{
+		Type:    NewEvent_NewEventType(eventType),
+		Payload: payload,
+	}
+
+	return newEvent.Marshal()
+}
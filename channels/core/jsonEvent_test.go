@@ -0,0 +1,85 @@
+package core
+
+import (
+	"testing"
+)
+
+func TestUnmarshalNewEventJSON(t *testing.T) {
+	data, err := UnmarshalNewEventJSON([]byte(`{"type":"SUBSCRIBE","payload":{"channelID":"321","ID":4,"lastEventID":10}}`))
+
+	if err != nil {
+		t.Fatalf("Failed to convert json event: %v", err)
+	}
+
+	var newEvent NewEvent
+
+	if err := newEvent.Unmarshal(data); err != nil {
+		t.Fatalf("Failed to unmarshal event: %v", err)
+	}
+
+	var subscribe SubscribeRequest
+
+	if err := subscribe.Unmarshal(newEvent.Payload); err != nil {
+		t.Fatalf("Failed to unmarshal subscribe request: %v", err)
+	}
+
+	if newEvent.Type != NewEvent_SUBSCRIBE || subscribe.ChannelID != "321" || subscribe.ID != 4 || subscribe.LastEventID != 10 {
+		t.Errorf("Unexpected event %v with payload %v", newEvent.Type, subscribe)
+	}
+
+	// Clients can't send server events
+	if _, err := UnmarshalNewEventJSON([]byte(`{"type":"ACK","payload":{}}`)); err != ErrUnsupportedEventType {
+		t.Errorf("Expected unsupported event type error, got %v", err)
+	}
+}
+
+func TestMarshalNewEventJSON(t *testing.T) {
+	channelEvent := ChannelEvent{
+		SenderID:  "123",
+		EventType: "message",
+		Payload:   "hello",
+		ChannelID: "321",
+		Timestamp: 1615735212,
+		ID:        42,
+	}
+
+	payload, _ := channelEvent.Marshal()
+	newEvent := NewEvent{Type: NewEvent_PUBLISH, Payload: payload}
+	data, _ := newEvent.Marshal()
+
+	jsonData, err := MarshalNewEventJSON(data)
+
+	if err != nil {
+		t.Fatalf("Failed to convert event to json: %v", err)
+	}
+
+	expected := `{"type":"PUBLISH","payload":{"senderID":"123","eventType":"message","payload":"hello","channelID":"321","timestamp":1615735212,"ID":42}}`
+
+	if string(jsonData) != expected {
+		t.Errorf("Expected %s, got %s", expected, jsonData)
+	}
+}
+
+func TestUnmarshalReadRequestJSON(t *testing.T) {
+	data, err := UnmarshalNewEventJSON([]byte(`{"type":"READ","payload":{"ID":4,"channelID":"123","eventID":42}}`))
+
+	if err != nil {
+		t.Fatalf("Failed to convert json event: %v", err)
+	}
+
+	var newEvent NewEvent
+
+	if err := newEvent.Unmarshal(data); err != nil {
+		t.Fatalf("Failed to unmarshal event: %v", err)
+	}
+
+	var read ReadRequest
+
+	if err := read.Unmarshal(newEvent.Payload); err != nil {
+		t.Fatalf("Failed to unmarshal read request: %v", err)
+	}
+
+	if newEvent.Type != NewEvent_READ || read.ChannelID != "123" || read.ID != 4 || read.EventID != 42 {
+		t.Errorf("Unexpected event %v with payload %v", newEvent.Type, read)
+	}
+}
@@ -0,0 +1,338 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// ErrInvalidJSONSchema - The schema is malformed or uses an unsupported keyword
+var ErrInvalidJSONSchema = errors.New("invalid JSON schema")
+
+// Keywords that would need a full JSON Schema implementation, rejected instead of silently ignored
+var unsupportedSchemaKeywords = []string{"$ref", "allOf", "anyOf", "oneOf", "not", "if", "then", "else", "patternProperties", "dependencies"}
+
+var schemaTypes = map[string]bool{"object": true, "array": true, "string": true, "number": true, "integer": true, "boolean": true, "null": true}
+
+// jsonSchema - Compiled subset of JSON Schema used to validate event payloads
+// Supports type, properties, required, additionalProperties, items, enum,
+// minLength, maxLength, minimum, maximum, minItems, maxItems and pattern
+type jsonSchema struct {
+	types                []string
+	properties           map[string]*jsonSchema
+	required             []string
+	additionalProperties bool
+	items                *jsonSchema
+	enum                 []interface{}
+	minLength            *int
+	maxLength            *int
+	minimum              *float64
+	maximum              *float64
+	minItems             *int
+	maxItems             *int
+	pattern              *regexp.Regexp
+}
+
+// compileJSONSchema - Parse the given JSON Schema document
+func compileJSONSchema(data string) (*jsonSchema, error) {
+	var raw interface{}
+
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+	if err := json.Unmarshal([]byte(data), &raw); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidJSONSchema, err)
+	}
+
+	return compileSchemaValue(raw, "schema")
+}
+
+func compileSchemaValue(raw interface{}, path string) (*jsonSchema, error) {
+	object, isOK := raw.(map[string]interface{})
+
+	if !isOK {
+		return nil, fmt.Errorf("%w: %s must be an object", ErrInvalidJSONSchema, path)
+	}
+
+	for _, keyword := range unsupportedSchemaKeywords {
+		if _, ok := object[keyword]; ok {
+			return nil, fmt.Errorf("%w: %s uses unsupported keyword %s", ErrInvalidJSONSchema, path, keyword)
+		}
+	}
+
+	schema := &jsonSchema{additionalProperties: true}
+
+	var err error
+
+	switch value := object["type"].(type) {
+	case nil:
+	case string:
+		schema.types = []string{value}
+	case []interface{}:
+		for _, item := range value {
+			name, ok := item.(string)
+
+			if !ok {
+				return nil, fmt.Errorf("%w: %s.type must contain strings", ErrInvalidJSONSchema, path)
+			}
+
+			schema.types = append(schema.types, name)
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s.type must be a string or an array", ErrInvalidJSONSchema, path)
+	}
+
+	for _, name := range schema.types {
+		if !schemaTypes[name] {
+			return nil, fmt.Errorf("%w: %s.type has unknown type %s", ErrInvalidJSONSchema, path, name)
+		}
+	}
+
+	if value, ok := object["properties"]; ok {
+		properties, ok := value.(map[string]interface{})
+
+		if !ok {
+			return nil, fmt.Errorf("%w: %s.properties must be an object", ErrInvalidJSONSchema, path)
+		}
+
+		schema.properties = make(map[string]*jsonSchema, len(properties))
+
+		for name, property := range properties {
+			if schema.properties[name], err = compileSchemaValue(property, path+".properties."+name); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if value, ok := object["required"]; ok {
+		required, ok := value.([]interface{})
+
+		if !ok {
+			return nil, fmt.Errorf("%w: %s.required must be an array", ErrInvalidJSONSchema, path)
+		}
+
+		for _, item := range required {
+			name, ok := item.(string)
+
+			if !ok {
+				return nil, fmt.Errorf("%w: %s.required must contain strings", ErrInvalidJSONSchema, path)
+			}
+
+			schema.required = append(schema.required, name)
+		}
+	}
+
+	if value, ok := object["additionalProperties"]; ok {
+		allowed, ok := value.(bool)
+
+		if !ok {
+			return nil, fmt.Errorf("%w: %s.additionalProperties must be a boolean", ErrInvalidJSONSchema, path)
+		}
+
+		schema.additionalProperties = allowed
+	}
+
+	if value, ok := object["items"]; ok {
+		if schema.items, err = compileSchemaValue(value, path+".items"); err != nil {
+			return nil, err
+		}
+	}
+
+	if value, ok := object["enum"]; ok {
+		enum, ok := value.([]interface{})
+
+		if !ok || len(enum) == 0 {
+			return nil, fmt.Errorf("%w: %s.enum must be a non empty array", ErrInvalidJSONSchema, path)
+		}
+
+		schema.enum = enum
+	}
+
+	if value, ok := object["pattern"]; ok {
+		pattern, ok := value.(string)
+
+		if !ok {
+			return nil, fmt.Errorf("%w: %s.pattern must be a string", ErrInvalidJSONSchema, path)
+		}
+
+		if schema.pattern, err = regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("%w: %s.pattern: %v", ErrInvalidJSONSchema, path, err)
+		}
+	}
+
+	for keyword, target := range map[string]**int{"minLength": &schema.minLength, "maxLength": &schema.maxLength, "minItems": &schema.minItems, "maxItems": &schema.maxItems} {
+		if value, ok := object[keyword]; ok {
+			number, ok := value.(float64)
+
+			if !ok || number < 0 || number != math.Trunc(number) {
+				return nil, fmt.Errorf("%w: %s.%s must be a non negative integer", ErrInvalidJSONSchema, path, keyword)
+			}
+
+			limit := int(number)
+			*target = &limit
+		}
+	}
+
+	for keyword, target := range map[string]**float64{"minimum": &schema.minimum, "maximum": &schema.maximum} {
+		if value, ok := object[keyword]; ok {
+			number, ok := value.(float64)
+
+			if !ok {
+				return nil, fmt.Errorf("%w: %s.%s must be a number", ErrInvalidJSONSchema, path, keyword)
+			}
+
+			*target = &number
+		}
+	}
+
+	return schema, nil
+}
+
+// validatePayload - Check that the payload is JSON matching the schema
+func (schema *jsonSchema) validatePayload(payload string) error {
+	var value interface{}
+
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+	if err := json.Unmarshal([]byte(payload), &value); err != nil {
+		return errors.New("payload is not valid JSON")
+	}
+
+	return schema.validate(value, "payload")
+}
+
+func (schema *jsonSchema) validate(value interface{}, path string) error {
+
+	if len(schema.types) > 0 && !schema.matchesType(value) {
+		return fmt.Errorf("%s must be of type %s", path, strings.Join(schema.types, " or "))
+	}
+
+	if len(schema.enum) > 0 {
+		found := false
+
+		for _, item := range schema.enum {
+			if reflect.DeepEqual(item, value) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("%s is not one of the allowed values", path)
+		}
+	}
+
+	switch typed := value.(type) {
+	case string:
+		length := utf8.RuneCountInString(typed)
+
+		if schema.minLength != nil && length < *schema.minLength {
+			return fmt.Errorf("%s must have at least %d characters", path, *schema.minLength)
+		}
+
+		if schema.maxLength != nil && length > *schema.maxLength {
+			return fmt.Errorf("%s must have at most %d characters", path, *schema.maxLength)
+		}
+
+		if schema.pattern != nil && !schema.pattern.MatchString(typed) {
+			return fmt.Errorf("%s does not match pattern %s", path, schema.pattern.String())
+		}
+
+	case float64:
+		if schema.minimum != nil && typed < *schema.minimum {
+			return fmt.Errorf("%s must be at least %v", path, *schema.minimum)
+		}
+
+		if schema.maximum != nil && typed > *schema.maximum {
+			return fmt.Errorf("%s must be at most %v", path, *schema.maximum)
+		}
+
+	case []interface{}:
+		if schema.minItems != nil && len(typed) < *schema.minItems {
+			return fmt.Errorf("%s must have at least %d items", path, *schema.minItems)
+		}
+
+		if schema.maxItems != nil && len(typed) > *schema.maxItems {
+			return fmt.Errorf("%s must have at most %d items", path, *schema.maxItems)
+		}
+
+		if schema.items != nil {
+			for i, item := range typed {
+				if err := schema.items.validate(item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+
+	case map[string]interface{}:
+		for _, name := range schema.required {
+			if _, ok := typed[name]; !ok {
+				return fmt.Errorf("%s.%s is required", path, name)
+			}
+		}
+
+		// Sorted to always report the same error for the same payload
+		names := make([]string, 0, len(typed))
+
+		for name := range typed {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		for _, name := range names {
+			property, ok := schema.properties[name]
+
+			if !ok {
+				if !schema.additionalProperties {
+					return fmt.Errorf("%s.%s is not allowed", path, name)
+				}
+
+				continue
+			}
+
+			if err := property.validate(typed[name], path+"."+name); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (schema *jsonSchema) matchesType(value interface{}) bool {
+	for _, name := range schema.types {
+		switch typed := value.(type) {
+		case nil:
+			if name == "null" {
+				return true
+			}
+		case bool:
+			if name == "boolean" {
+				return true
+			}
+		case string:
+			if name == "string" {
+				return true
+			}
+		case float64:
+			if name == "number" || (name == "integer" && typed == math.Trunc(typed)) {
+				return true
+			}
+		case []interface{}:
+			if name == "array" {
+				return true
+			}
+		case map[string]interface{}:
+			if name == "object" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
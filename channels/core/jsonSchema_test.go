@@ -0,0 +1,85 @@
+package core
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestJSONSchemaValidatePayload(t *testing.T) {
+	schema, err := compileJSONSchema(`{
+		"type": "object",
+		"required": ["text"],
+		"additionalProperties": false,
+		"properties": {
+			"text": {"type": "string", "minLength": 1, "maxLength": 5},
+			"priority": {"type": "integer", "minimum": 0, "maximum": 3},
+			"tags": {"type": "array", "maxItems": 2, "items": {"enum": ["a", "b"]}}
+		}
+	}`)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		payload string
+		valid   bool
+	}{
+		{`{"text": "hi"}`, true},
+		{`{"text": "hi", "priority": 2, "tags": ["a", "b"]}`, true},
+		{`{"text": ""}`, false},
+		{`{"text": "too long"}`, false},
+		{`{"priority": 1}`, false},
+		{`{"text": "hi", "priority": 1.5}`, false},
+		{`{"text": "hi", "priority": 4}`, false},
+		{`{"text": "hi", "tags": ["c"]}`, false},
+		{`{"text": "hi", "tags": ["a", "b", "a"]}`, false},
+		{`{"text": "hi", "other": true}`, false},
+		{`["text"]`, false},
+		{`{"text": "hi"`, false},
+	}
+
+	for _, c := range cases {
+		if err := schema.validatePayload(c.payload); (err == nil) != c.valid {
+			t.Errorf("Payload %s: expected valid %v got %v", c.payload, c.valid, err)
+		}
+	}
+}
+
+func TestCompileJSONSchemaRejectsUnsupported(t *testing.T) {
+	schemas := []string{
+		`not json`,
+		`"string"`,
+		`{"type": "date"}`,
+		`{"oneOf": [{"type": "string"}]}`,
+		`{"properties": {"a": {"$ref": "#/definitions/a"}}}`,
+		`{"pattern": "("}`,
+		`{"minLength": -1}`,
+	}
+
+	for _, schema := range schemas {
+		if _, err := compileJSONSchema(schema); !errors.Is(err, ErrInvalidJSONSchema) {
+			t.Errorf("Schema %s: expected invalid schema error got %v", schema, err)
+		}
+	}
+}
+
+func TestCompileEventRules(t *testing.T) {
+	if _, err := compileEventRules([]*EventTypeRule{{EventType: "message"}, {EventType: "message"}}); !errors.Is(err, ErrInvalidEventTypeRules) {
+		t.Errorf("Expected repeated event type error got %v", err)
+	}
+
+	if _, err := compileEventRules([]*EventTypeRule{{EventType: ""}}); !errors.Is(err, ErrInvalidEventTypeRules) {
+		t.Errorf("Expected empty event type error got %v", err)
+	}
+
+	rules, err := compileEventRules([]*EventTypeRule{{EventType: "typing"}, {EventType: "message", Schema: `{"type": "object"}`}})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rules.schemas["typing"] != nil || rules.schemas["message"] == nil {
+		t.Error("Unexpected compiled rules")
+	}
+}
@@ -0,0 +1,147 @@
+package core
+
+import (
+	"sync"
+	"time"
+)
+
+// Limits - Bounds on what the clients of an app can send, 0 uses the default and -1 disables a limit
+type Limits struct {
+	MaxPayloadSize       int     // Max bytes of a published, edited or signaled payload, defaults to 64KB
+	SessionPublishRate   float64 // Publishes per second each session can send, defaults to 10
+	SessionPublishBurst  int     // Publishes a session can send at once before being limited, defaults to 20
+	ChannelPublishRate   float64 // Publishes per second into each channel through this server, defaults to 100
+	ChannelPublishBurst  int     // Publishes into a channel at once before being limited, defaults to 200
+	MaxClientConnections int     // Connections each client can have open on this server, defaults to 10
+	MaxViolations        int     // Limited messages in a row before the session is disconnected, defaults to 20
+}
+
+// DefaultLimits - Limits of the apps without their own
+var DefaultLimits = Limits{
+	MaxPayloadSize:       64 * 1024,
+	SessionPublishRate:   10,
+	SessionPublishBurst:  20,
+	ChannelPublishRate:   100,
+	ChannelPublishBurst:  200,
+	MaxClientConnections: 10,
+	MaxViolations:        20,
+}
+
+// Reasons sent back to clients when a limit is hit
+const (
+	ReasonPayloadTooLarge    = "payload too large"
+	ReasonPublishRateLimited = "publish rate limited"
+	ReasonChannelRateLimited = "channel publish rate limited"
+)
+
+const channelLimiterSweepPeriod = time.Minute // How often full channel buckets are dropped
+
+var appLimits sync.Map //[string]Limits
+
+// SetAppLimits - Set the limits of an app, unset fields use DefaultLimits
+// Sessions already connected keep their publish rate until they reconnect
+func SetAppLimits(appID string, limits Limits) {
+	appLimits.Store(appID, limits)
+}
+
+// GetAppLimits - Get the limits of an app, with unset fields taken from DefaultLimits
+func GetAppLimits(appID string) Limits {
+	data, isOK := appLimits.Load(appID)
+
+	if !isOK {
+		return DefaultLimits
+	}
+
+	return data.(Limits).withDefaults(DefaultLimits)
+}
+
+// withDefaults - Copy of the limits with unset fields taken from the given defaults
+func (limits Limits) withDefaults(defaults Limits) Limits {
+	if limits.MaxPayloadSize == 0 {
+		limits.MaxPayloadSize = defaults.MaxPayloadSize
+	}
+
+	if limits.SessionPublishRate == 0 {
+		limits.SessionPublishRate = defaults.SessionPublishRate
+	}
+
+	if limits.SessionPublishBurst == 0 {
+		limits.SessionPublishBurst = defaults.SessionPublishBurst
+	}
+
+	if limits.ChannelPublishRate == 0 {
+		limits.ChannelPublishRate = defaults.ChannelPublishRate
+	}
+
+	if limits.ChannelPublishBurst == 0 {
+		limits.ChannelPublishBurst = defaults.ChannelPublishBurst
+	}
+
+	if limits.MaxClientConnections == 0 {
+		limits.MaxClientConnections = defaults.MaxClientConnections
+	}
+
+	if limits.MaxViolations == 0 {
+		limits.MaxViolations = defaults.MaxViolations
+	}
+
+	return limits
+}
+
+// IsPayloadAllowed - Check the payload size against the limit
+func (limits Limits) IsPayloadAllowed(payload string) bool {
+	return limits.MaxPayloadSize < 0 || len(payload) <= limits.MaxPayloadSize
+}
+
+// channelLimiters - Publish rate of each channel through this server
+type channelLimiters struct {
+	limiters  sync.Map //[string]*rateLimiter
+	lock      sync.Mutex
+	lastSweep time.Time
+}
+
+var publishChannelLimiters = &channelLimiters{lastSweep: time.Now()}
+
+// Allow - Take a publish from the channel bucket
+func (store *channelLimiters) Allow(appID string, channelID string, limits Limits) bool {
+	if limits.ChannelPublishRate <= 0 {
+		return true
+	}
+
+	store.sweep()
+
+	key := appID + ":" + channelID
+	data, isOK := store.limiters.Load(key)
+
+	if !isOK {
+		data, _ = store.limiters.LoadOrStore(key, newRateLimiter(limits.ChannelPublishRate, limits.ChannelPublishBurst))
+	}
+
+	return data.(*rateLimiter).Allow()
+}
+
+// sweep - Drop the full buckets, they behave like new ones so channels that stopped publishing don't use memory
+func (store *channelLimiters) sweep() {
+	store.lock.Lock()
+
+	if time.Since(store.lastSweep) < channelLimiterSweepPeriod {
+		store.lock.Unlock()
+		return
+	}
+
+	store.lastSweep = time.Now()
+	store.lock.Unlock()
+
+	store.limiters.Range(func(key interface{}, value interface{}) bool {
+		if value.(*rateLimiter).IsFull() {
+			store.limiters.Delete(key)
+		}
+
+		return true
+	})
+}
+
+// AllowChannelPublish - Check the channel publish rate of the app limits
+func AllowChannelPublish(appID string, channelID string) bool {
+	return publishChannelLimiters.Allow(appID, channelID, GetAppLimits(appID))
+}
@@ -0,0 +1,118 @@
+package core
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetAppLimitsDefaults(t *testing.T) {
+	SetAppLimits("limited-app", Limits{MaxPayloadSize: 10, ChannelPublishRate: -1})
+	defer appLimits.Delete("limited-app")
+
+	limits := GetAppLimits("limited-app")
+
+	if limits.MaxPayloadSize != 10 || limits.ChannelPublishRate != -1 || limits.SessionPublishRate != DefaultLimits.SessionPublishRate {
+		t.Errorf("Unexpected app limits %+v", limits)
+	}
+
+	if !limits.IsPayloadAllowed(strings.Repeat("a", 10)) || limits.IsPayloadAllowed(strings.Repeat("a", 11)) {
+		t.Error("Unexpected payload size check")
+	}
+
+	if !(Limits{MaxPayloadSize: -1}).IsPayloadAllowed(strings.Repeat("a", 1<<20)) {
+		t.Error("Expected a disabled limit to allow any payload")
+	}
+
+	if GetAppLimits("other-app") != DefaultLimits {
+		t.Error("Expected apps without limits to use the defaults")
+	}
+}
+
+func TestChannelLimitersSweep(t *testing.T) {
+	store := &channelLimiters{lastSweep: time.Now()}
+	limits := Limits{ChannelPublishRate: 1000, ChannelPublishBurst: 1}
+
+	if !store.Allow("app", "channel", limits) || store.Allow("app", "channel", limits) {
+		t.Fatal("Expected the channel burst to be used")
+	}
+
+	// The bucket refills in 1ms, so the next sweep drops it
+	time.Sleep(5 * time.Millisecond)
+	store.lastSweep = time.Time{}
+
+	if !store.Allow("other", "channel", limits) {
+		t.Fatal("Expected a new channel to be allowed")
+	}
+
+	if _, isOK := store.limiters.Load("app:channel"); isOK {
+		t.Error("Expected the full bucket to be swept")
+	}
+}
+
+func TestHubCanAddClient(t *testing.T) {
+	SetAppLimits("connections-app", Limits{MaxClientConnections: 2})
+	defer appLimits.Delete("connections-app")
+
+	hub := NewHub("connections-app", nil)
+
+	first := &Session{clientID: "client", SessionIdentifier: "client-a", hub: hub, connection: &closeRecorder{}}
+	second := &Session{clientID: "client", SessionIdentifier: "client-b", hub: hub, connection: &closeRecorder{}}
+
+	hub.AddClient(first)
+	hub.AddClient(second)
+
+	if hub.CanAddClient("client", "c") {
+		t.Error("Expected a third device to be refused")
+	}
+
+	if !hub.CanAddClient("client", "a") {
+		t.Error("Expected a reconnecting device to be allowed")
+	}
+
+	if !hub.CanAddClient("another", "a") {
+		t.Error("Expected other clients to be allowed")
+	}
+
+	if hub.AddClient(&Session{clientID: "client", SessionIdentifier: "client-c"}) {
+		t.Error("Expected a third session to be refused")
+	}
+
+	// The old session of a reconnecting device is closed once the new one was added
+	reconnected := &Session{clientID: "client", SessionIdentifier: "client-a", hub: hub, connection: &closeRecorder{}}
+
+	if !hub.AddClient(reconnected) {
+		t.Fatal("Expected a reconnecting device to be added")
+	}
+
+	if !first.isClosed || first.connection.(*closeRecorder).code != CloseStatusPolicy {
+		t.Error("Expected the replaced session to be closed")
+	}
+
+	hub.RemoveClient(first)
+
+	if value, isOK := hub.connectedClients.Load("client-a"); !isOK || value.(*Session) != reconnected {
+		t.Error("Expected the new session to stay connected")
+	}
+
+	hub.RemoveClient(second)
+	hub.RemoveClient(second)
+
+	if !hub.CanAddClient("client", "c") || hub.clientConnections["client"] != 1 {
+		t.Errorf("Expected a device to be allowed after one disconnected, got %d connections", hub.clientConnections["client"])
+	}
+}
+
+// closeRecorder - Connection that only keeps the status it was closed with
+type closeRecorder struct {
+	Connection
+	code uint16
+}
+
+func (connection *closeRecorder) IsConnected() bool {
+	return connection.code == 0
+}
+
+func (connection *closeRecorder) CloseWithStatus(code uint16, reason string) {
+	connection.code = code
+}
@@ -0,0 +1,118 @@
+package core
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strconv"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+const (
+	ListSortID   = "id"   // Sort listings by ID
+	ListSortName = "name" // Sort listings by name, or username for clients
+
+	DefaultListLimit = 100  // Items per page when no limit is given
+	MaxListLimit     = 1000 // Max items per page
+)
+
+var (
+	ErrInvalidListOptions = errors.New("invalid list options")
+)
+
+// ListCursor - Sort values of the last item of a page, the next page starts after it
+type ListCursor struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// ListOptions - Pagination, sorting and filtering of listings
+// Items are ordered by the sort field and then by ID, so pages don't skip or repeat items when others are added
+type ListOptions struct {
+	Limit      int         // Max items returned, 0 returns all of them
+	After      *ListCursor // Return items after this one, nil for the first page
+	SortBy     string      // ListSortID or ListSortName, defaults to ListSortID
+	Descending bool
+	Name       string // Only items whose name contains it, case insensitive
+}
+
+// SortByName - Check if items are sorted by name
+func (options *ListOptions) SortByName() bool {
+	return options.SortBy == ListSortName
+}
+
+// EncodeListCursor - Opaque cursor given to clients to request the next page
+func EncodeListCursor(cursor *ListCursor) string {
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+	data, _ := json.Marshal(cursor)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeListCursor - Read a cursor created by EncodeListCursor
+func DecodeListCursor(value string) (*ListCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+
+	if err != nil {
+		return nil, ErrInvalidListOptions
+	}
+
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+	var cursor ListCursor
+
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == "" {
+		return nil, ErrInvalidListOptions
+	}
+
+	return &cursor, nil
+}
+
+// ParseListOptions - Read the limit, cursor, sort, order and name query params of a listing request
+// The limit defaults to DefaultListLimit and can't be over MaxListLimit
+func ParseListOptions(query url.Values) (ListOptions, error) {
+	options := ListOptions{
+		Limit:  DefaultListLimit,
+		SortBy: ListSortID,
+		Name:   query.Get("name"),
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+
+		if err != nil || value < 1 || value > MaxListLimit {
+			return options, ErrInvalidListOptions
+		}
+
+		options.Limit = value
+	}
+
+	switch query.Get("sort") {
+	case "", ListSortID:
+	case ListSortName:
+		options.SortBy = ListSortName
+	default:
+		return options, ErrInvalidListOptions
+	}
+
+	switch query.Get("order") {
+	case "", "asc":
+	case "desc":
+		options.Descending = true
+	default:
+		return options, ErrInvalidListOptions
+	}
+
+	if cursor := query.Get("cursor"); cursor != "" {
+		after, err := DecodeListCursor(cursor)
+
+		if err != nil {
+			return options, err
+		}
+
+		options.After = after
+	}
+
+	return options, nil
+}
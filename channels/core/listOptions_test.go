@@ -0,0 +1,60 @@
+package core
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestListCursorRoundTrip(t *testing.T) {
+	cursor := &ListCursor{ID: "channel-1", Name: "General"}
+
+	decoded, err := DecodeListCursor(EncodeListCursor(cursor))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *decoded != *cursor {
+		t.Errorf("Expected %+v got %+v", cursor, decoded)
+	}
+
+	if _, err := DecodeListCursor("not a cursor"); err != ErrInvalidListOptions {
+		t.Errorf("Expected invalid cursor error got %v", err)
+	}
+}
+
+func TestParseListOptions(t *testing.T) {
+	options, err := ParseListOptions(url.Values{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if options.Limit != DefaultListLimit || options.SortBy != ListSortID || options.Descending || options.After != nil {
+		t.Errorf("Unexpected default options %+v", options)
+	}
+
+	cursor := EncodeListCursor(&ListCursor{ID: "a", Name: "b"})
+	options, err = ParseListOptions(url.Values{"limit": {"10"}, "sort": {"name"}, "order": {"desc"}, "cursor": {cursor}, "name": {"gen"}})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if options.Limit != 10 || !options.SortByName() || !options.Descending || options.After == nil || options.After.ID != "a" || options.Name != "gen" {
+		t.Errorf("Unexpected options %+v", options)
+	}
+
+	for _, query := range []url.Values{
+		{"limit": {"0"}},
+		{"limit": {"1001"}},
+		{"limit": {"ten"}},
+		{"sort": {"createdAt"}},
+		{"order": {"up"}},
+		{"cursor": {"!"}},
+	} {
+		if _, err := ParseListOptions(query); err != ErrInvalidListOptions {
+			t.Errorf("Expected invalid options for %v", query)
+		}
+	}
+}
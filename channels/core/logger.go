@@ -1,25 +1,61 @@
 package core
 
 import (
+	"io"
 	"os"
 
 	log "github.com/sirupsen/logrus"
 )
 
-func SetUpLogger() {
+var logger = newDefaultLogger()
 
-	// Make sure to log what function it error happened
-	log.SetReportCaller(true)
-	// What kind of level of logging we want
-	log.SetLevel(log.DebugLevel)
+// LogConfig - Settings for the logger created by the engine when none is given
+type LogConfig struct {
+	Level  string    `yaml:"level"`  // trace, debug, info, warn, error, fatal or panic, defaults to info
+	Format string    `yaml:"format"` // text or json, defaults to text
+	Output io.Writer `yaml:"-"`      // Defaults to stderr
+}
+
+func newDefaultLogger() *log.Logger {
+	defaultLogger := log.New()
+	defaultLogger.SetOutput(os.Stderr)
+	defaultLogger.SetLevel(log.InfoLevel)
+
+	return defaultLogger
+}
+
+// NewLogger - Create a logger with the given settings
+func NewLogger(config LogConfig) (*log.Logger, error) {
+	newLogger := newDefaultLogger()
+
+	if config.Level != "" {
+		level, err := log.ParseLevel(config.Level)
+
+		if err != nil {
+			return nil, err
+		}
 
-	// Create local output file
-	f, err := os.Create("./logs.txt")
+		newLogger.SetLevel(level)
+	}
+
+	if config.Format == "json" {
+		newLogger.SetFormatter(&log.JSONFormatter{})
+	}
 
-	if err != nil {
-		log.Fatal(err)
+	if config.Output != nil {
+		newLogger.SetOutput(config.Output)
 	}
 
-	// Say logger to write to it
-	log.SetOutput(f)
+	return newLogger, nil
+}
+
+// SetLogger - Set the logger used by all components, call it before initializing them
+func SetLogger(newLogger *log.Logger) {
+	logger = newLogger
+}
+
+// Logger - Get the logger used by all components
+// Log with the fields AppID, ChannelID, ClientID, DeviceID and SessionID when they are known
+func Logger() *log.Logger {
+	return logger
 }
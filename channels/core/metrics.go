@@ -0,0 +1,142 @@
+package core
+
+import (
+	"github.com/lisomatrix/channels/channels/metrics"
+)
+
+var (
+	eventsPublished = metrics.NewCounterVec("channels_events_published_total", "Channel events published, from clients of this server (local) or from other servers (external)", "app_id", "source")
+	eventsDelivered = metrics.NewCounterVec("channels_events_delivered_total", "Channel events sent to sessions", "app_id")
+	insertErrors    = metrics.NewCounterVec("channels_insert_errors_total", "Failed batch inserts, including retries")
+	insertDuration  = metrics.NewHistogram("channels_insert_duration_seconds", "Time taken to insert a batch of events into the database", metrics.DefaultBuckets)
+	cacheRequests   = metrics.NewCounterVec("channels_cache_requests_total", "Cache lookups by operation and result (hit or miss)", "operation", "result")
+	signalsSent     = metrics.NewCounterVec("channels_signals_published_total", "Ephemeral signals published, from clients of this server (local) or from other servers (external)", "app_id", "source")
+	signalsLimited  = metrics.NewCounterVec("channels_signals_rate_limited_total", "Ephemeral signals dropped because the session sent too many", "app_id")
+	publishLimited  = metrics.NewCounterVec("channels_publishes_limited_total", "Publishes, edits and direct messages refused by the app limits", "app_id")
+)
+
+func init() {
+	metrics.NewGaugeVecFunc("channels_sessions_connected", "Sessions connected to this server", []string{"app_id"}, func() []metrics.Sample {
+		samples := make([]metrics.Sample, 0)
+
+		rangeHubs(func(hub *Hub) {
+			count := 0
+
+			hub.connectedClients.Range(func(key interface{}, value interface{}) bool {
+				count++
+				return true
+			})
+
+			samples = append(samples, metrics.Sample{LabelValues: []string{hub.AppID}, Value: float64(count)})
+		})
+
+		return samples
+	})
+
+	// Channel IDs aren't used as labels, every channel would be a new series
+	metrics.NewGaugeVecFunc("channels_channel_subscriptions", "Sessions subscribed to the open channels of each app in this server", []string{"app_id"}, func() []metrics.Sample {
+		samples := make([]metrics.Sample, 0)
+
+		rangeHubs(func(hub *Hub) {
+			count := int64(0)
+
+			hub.channels.Range(func(key interface{}, value interface{}) bool {
+				count += int64(value.(*HubChannel).connectedCounter.Load())
+				return true
+			})
+
+			samples = append(samples, metrics.Sample{LabelValues: []string{hub.AppID}, Value: float64(count)})
+		})
+
+		return samples
+	})
+
+	metrics.NewGaugeVecFunc("channels_channels_open", "Channels of each app with sessions subscribed in this server", []string{"app_id"}, func() []metrics.Sample {
+		samples := make([]metrics.Sample, 0)
+
+		rangeHubs(func(hub *Hub) {
+			count := 0
+
+			hub.channels.Range(func(key interface{}, value interface{}) bool {
+				count++
+				return true
+			})
+
+			samples = append(samples, metrics.Sample{LabelValues: []string{hub.AppID}, Value: float64(count)})
+		})
+
+		return samples
+	})
+
+	metrics.NewGaugeFunc("channels_insert_queue_length", "Events waiting to be inserted into the database", func() float64 {
+		if engine == nil || engine.storageInsert == nil {
+			return 0
+		}
+
+		return float64(engine.storageInsert.Len())
+	})
+
+	metrics.NewGaugeFunc("channels_push_queue_length", "Push notification requests waiting to be sent", func() float64 {
+		if engine == nil || engine.pushHandler == nil {
+			return 0
+		}
+
+		return float64(engine.pushHandler.Len())
+	})
+}
+
+// rangeHubs - Call fn for every hub of the engine, if it was initialized
+func rangeHubs(fn func(hub *Hub)) {
+	if engine == nil {
+		return
+	}
+
+	engine.hubsHandler.hubs.Range(func(key interface{}, value interface{}) bool {
+		fn(value.(*Hub))
+		return true
+	})
+}
+
+// cacheResult - Label value for cache lookups
+func cacheResult(found bool) string {
+	if found {
+		return "hit"
+	}
+
+	return "miss"
+}
+
+// metricsCacheStorage - CacheStorage wrapper that records hits and misses of lookups
+type metricsCacheStorage struct {
+	CacheStorage
+}
+
+func (cache *metricsCacheStorage) GetClient(appID string, clientID string) *Client {
+	client := cache.CacheStorage.GetClient(appID, clientID)
+	cacheRequests.Inc("client", cacheResult(client != nil))
+	return client
+}
+
+func (cache *metricsCacheStorage) GetApp(appID string) *App {
+	app := cache.CacheStorage.GetApp(appID)
+	cacheRequests.Inc("app", cacheResult(app != nil))
+	return app
+}
+
+func (cache *metricsCacheStorage) GetChannel(appID string, channelID string) *Channel {
+	channel := cache.CacheStorage.GetChannel(appID, channelID)
+	cacheRequests.Inc("channel", cacheResult(channel != nil))
+	return channel
+}
+
+func (cache *metricsCacheStorage) GetClientChannels(clientID string) ([]string, bool) {
+	channelIDs, found := cache.CacheStorage.GetClientChannels(clientID)
+	cacheRequests.Inc("client_channels", cacheResult(found))
+	return channelIDs, found
+}
+
+func (cache *metricsCacheStorage) GetChannelEvents(channelID string, appID string, amount int64) []*ChannelEvent {
+	events := cache.CacheStorage.GetChannelEvents(channelID, appID, amount)
+	cacheRequests.Inc("channel_events", cacheResult(len(events) != 0))
+	return events
+}
@@ -0,0 +1,32 @@
+package core
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lisomatrix/channels/channels/auth"
+	"github.com/lisomatrix/channels/channels/metrics"
+)
+
+// MetricsHandler - Expose the server metrics to Prometheus, only to super admins as they cover every app
+// The token can be sent with the Bearer prefix, as Prometheus does
+// GET /metrics
+func MetricsHandler(context *gin.Context) {
+
+	token := strings.TrimPrefix(context.Request.Header.Get("Authorization"), "Bearer ")
+
+	if token == "" {
+		context.Writer.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	identity, isOK := auth.VerifyToken(token)
+
+	if !isOK || !identity.IsSuperAdmin() {
+		context.Writer.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	metrics.Handler(context)
+}
@@ -4,8 +4,15 @@ package core
 type PublishHandler interface {
 	PublishChannelPresenceChange(appID string, channelID string, clientID string, isJoin bool)
 	PublishChannelAccessChange(appID string, channelID string, clientID string, isAdd bool)
+	PublishChannelRoleChange(appID string, channelID string, clientID string, role ChannelRole)
 	PublishChannelEvent(appID string, channelID string, channelEvent *ChannelEvent)
+	PublishChannelEventEdit(appID string, channelID string, channelEvent *ChannelEvent)
+	PublishChannelEventDelete(appID string, channelID string, eventID uint64)
+	PublishChannelRead(appID string, channelID string, receipt *ReadReceipt)
+	PublishChannelSignal(appID string, channelID string, signal *ChannelSignal)
+	PublishChannelUpdate(appID string, channel *Channel)
 	PublishChannelOnlineChange(appID string, channelID string, statusUpdate *OnlineStatusUpdate)
+	PublishClientDisconnect(appID string, clientID string, deviceID string) // Every server closes the client sessions, of every device if deviceID is empty
 	Subscribe(appID string, channelID string)
 	Unsubscribe(appID string, channelID string)
 }
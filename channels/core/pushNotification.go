@@ -1,5 +1,7 @@
 package core
 
+import "context"
+
 // PushRequestItem - Push notification request item
 type PushRequestItem struct {
 	ChannelID string
@@ -11,4 +13,6 @@ type PushRequestItem struct {
 
 type PushNotificationHandler interface {
 	EnqueueRequest(request *PushRequestItem)
+	Stop(ctx context.Context) error // Stop accepting requests and wait until the queued ones are sent
+	Len() int                       // Requests waiting to be sent
 }
\ No newline at end of file
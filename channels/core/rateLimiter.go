@@ -0,0 +1,64 @@
+package core
+
+import (
+	"sync"
+	"time"
+)
+
+// rateLimiter - Token bucket, allows bursts up to its size and refills at a steady rate
+type rateLimiter struct {
+	lock     sync.Mutex
+	rate     float64 // Tokens added per second
+	burst    float64 // Max tokens available at once
+	tokens   float64
+	lastFill time.Time
+}
+
+// newRateLimiter - Create a full bucket, a rate of 0 or less disables the limit
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &rateLimiter{
+		rate:     rate,
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		lastFill: time.Now(),
+	}
+}
+
+// Allow - Take a token if there is one available
+func (limiter *rateLimiter) Allow() bool {
+	if limiter.rate <= 0 {
+		return true
+	}
+
+	limiter.lock.Lock()
+	defer limiter.lock.Unlock()
+
+	now := time.Now()
+
+	limiter.tokens += now.Sub(limiter.lastFill).Seconds() * limiter.rate
+	limiter.lastFill = now
+
+	if limiter.tokens > limiter.burst {
+		limiter.tokens = limiter.burst
+	}
+
+	if limiter.tokens < 1 {
+		return false
+	}
+
+	limiter.tokens--
+
+	return true
+}
+
+// IsFull - Check if the bucket refilled completely, then it behaves like a new one
+func (limiter *rateLimiter) IsFull() bool {
+	limiter.lock.Lock()
+	defer limiter.lock.Unlock()
+
+	return limiter.tokens+time.Since(limiter.lastFill).Seconds()*limiter.rate >= limiter.burst
+}
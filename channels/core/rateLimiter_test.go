@@ -0,0 +1,37 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimiterBurstAndRefill(t *testing.T) {
+	limiter := newRateLimiter(10, 3)
+
+	for i := 0; i < 3; i++ {
+		if !limiter.Allow() {
+			t.Fatalf("Expected burst token %d to be allowed", i)
+		}
+	}
+
+	if limiter.Allow() {
+		t.Fatal("Expected limiter to refuse after the burst")
+	}
+
+	// 10 tokens per second, one is back after 100ms
+	time.Sleep(150 * time.Millisecond)
+
+	if !limiter.Allow() {
+		t.Error("Expected a token to be refilled")
+	}
+}
+
+func TestRateLimiterDisabled(t *testing.T) {
+	limiter := newRateLimiter(0, 1)
+
+	for i := 0; i < 100; i++ {
+		if !limiter.Allow() {
+			t.Fatal("Expected disabled limiter to allow every event")
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package core
+
+import (
+	"time"
+
+	"github.com/lisomatrix/channels/channels/auth"
+	log "github.com/sirupsen/logrus"
+)
+
+// DisconnectClient - Close the client sessions on every server, of every device if deviceID is empty
+// The client can connect again, use RevokeClient to also refuse its current tokens
+func DisconnectClient(appID string, clientID string, deviceID string) {
+	if hub := GetEngine().GetHubsHandler().ContainsHub(appID); hub != nil {
+		hub.DisconnectClient(clientID, deviceID, "disconnected")
+	}
+
+	GetEngine().GetPublisher().PublishClientDisconnect(appID, clientID, deviceID)
+}
+
+// RevokeClient - Refuse the client tokens issued until now, of every device if deviceID is empty, and disconnect its sessions
+// Tokens issued after the revocation are accepted, tokens without iat are refused until the revocation expires after RevocationTTL
+func RevokeClient(appID string, clientID string, deviceID string) bool {
+	if !GetEngine().GetCacheStorage().StoreRevocation(appID, clientID, deviceID, time.Now().Unix(), RevocationTTL) {
+		return false
+	}
+
+	logger.WithFields(log.Fields{"AppID": appID, "ClientID": clientID, "DeviceID": deviceID}).Info("Revoked client tokens")
+
+	DisconnectClient(appID, clientID, deviceID)
+
+	return true
+}
+
+// IsRevoked - Check if the identity token was revoked for the client or for the device
+func IsRevoked(identity *auth.Identity, appID string, deviceID string) bool {
+	cache := GetEngine().GetCacheStorage()
+
+	if isRevokedAt(identity, cache.GetRevocation(appID, identity.ClientID, "")) {
+		return true
+	}
+
+	return deviceID != "" && isRevokedAt(identity, cache.GetRevocation(appID, identity.ClientID, deviceID))
+}
+
+// isRevokedAt - Tokens issued in the same second of the revocation are still accepted, so new ones can be issued right away
+func isRevokedAt(identity *auth.Identity, revokedAt int64) bool {
+	if revokedAt == 0 {
+		return false
+	}
+
+	return identity.IssuedAt == 0 || identity.IssuedAt < revokedAt
+}
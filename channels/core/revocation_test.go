@@ -0,0 +1,27 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/lisomatrix/channels/channels/auth"
+)
+
+func TestIsRevokedAt(t *testing.T) {
+	identity := &auth.Identity{ClientID: "client", IssuedAt: 100}
+
+	if isRevokedAt(identity, 0) {
+		t.Error("Expected token without revocation to be accepted")
+	}
+
+	if !isRevokedAt(identity, 101) {
+		t.Error("Expected token issued before the revocation to be revoked")
+	}
+
+	if isRevokedAt(identity, 100) || isRevokedAt(identity, 99) {
+		t.Error("Expected token issued after the revocation to be accepted")
+	}
+
+	if !isRevokedAt(&auth.Identity{ClientID: "client"}, 99) {
+		t.Error("Expected token without iat to be revoked")
+	}
+}
@@ -1,14 +1,13 @@
 package core
 
 import (
-	"fmt"
-	"log"
-	"os"
+	"sync"
 	"time"
 
 	"github.com/lisomatrix/channels/channels/auth"
 
 	"github.com/rs/xid"
+	log "github.com/sirupsen/logrus"
 )
 
 // RemoveIndex - Helper to remove index from slice
@@ -41,6 +40,29 @@ type Session struct {
 	AllowedChannels    []string
 	SessionIdentifier  string // We create a string once and store now, instead of creating every time
 	hook               SessionHook
+	replayLock         sync.Mutex
+	replaying          map[string][]replayedEvent // Live events held back while missed events are being sent
+	signalLimiter      *rateLimiter               // Limits the ephemeral signals sent by the client
+	publishLimiter     *rateLimiter               // Limits the events published, edited and sent directly by the client
+	violations         int                        // Messages refused by the limits in a row
+	expiryTimer        *time.Timer                // Checks if the token was revoked once it expires
+	isRemoved          bool                       // Removed from the hub, guarded by the hub connectionsLock
+}
+
+// replayedEvent - Live channel event received while replaying missed events
+type replayedEvent struct {
+	ID   uint64
+	data []byte
+}
+
+// logger - Logger with the session fields
+func (session *Session) logger() *log.Entry {
+	return logger.WithFields(log.Fields{
+		"AppID":     session.hub.AppID,
+		"ClientID":  session.clientID,
+		"DeviceID":  session.deviceID,
+		"SessionID": session.ID,
+	})
 }
 
 func (session *Session) SetHook(hook SessionHook) {
@@ -58,7 +80,11 @@ func (session *Session) Init(connection Connection, deviceID string, identity *a
 		ids, err := GetEngine().GetChannelRepository().GetClientAllowedChannels(identity.ClientID)
 
 		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "SessionInit: failed to load client allowed channels %v\n", err)
+			logger.WithFields(log.Fields{
+				"AppID":    hub.AppID,
+				"ClientID": identity.ClientID,
+				"DeviceID": deviceID,
+			}).WithError(err).Error("SessionInit: failed to load client allowed channels")
 			channelIds = make([]string, 0)
 		} else {
 			channelIds = ids
@@ -87,12 +113,21 @@ func (session *Session) Init(connection Connection, deviceID string, identity *a
 	// Session ID
 	session.ID = xid.New().String()
 	session.SessionIdentifier = session.clientID + "-" + session.deviceID
+	session.signalLimiter = newRateLimiter(SignalRate, SignalBurst)
+
+	limits := GetAppLimits(hub.AppID)
+	session.publishLimiter = newRateLimiter(limits.SessionPublishRate, limits.SessionPublishBurst)
 
 	// Set handlers
 	connection.SetOnMessage(session.onNewMessage)
 	connection.SetOnClose(session.onClose)
 	connection.SetOnHeartBeat(session.onHeartBeat)
 
+	// Revocations are broadcast, this catches the ones the server missed
+	if identity.ExpiresAt > 0 {
+		session.expiryTimer = time.AfterFunc(time.Until(time.Unix(identity.ExpiresAt, 0)), session.onTokenExpired)
+	}
+
 	// Update user device online status
 	GetEngine().GetPresence().UpdateClientTimestamp(session.clientID)
 
@@ -117,14 +152,25 @@ func (session *Session) AddChannel(channelID string) {
 	data, err := newEvent.Marshal()
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Session Add channel: failed to marhal new event: %v\n", err)
+		session.logger().WithField("ChannelID", channelID).WithError(err).Error("Session Add channel: failed to marhal new event")
 	}
 
 	session.connection.Send(data)
 }
 
-// RemoveChannel - Remove channel while client is connected
-func (session *Session) RemoveChannel(channelID string) {
+// IsAllowedChannel - Check if the client is a member of the channel
+func (session *Session) IsAllowedChannel(channelID string) bool {
+	for _, allowedChannel := range session.AllowedChannels {
+		if allowedChannel == channelID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// notifyChannelRemoved - Let the client know it can't use the channel anymore
+func (session *Session) notifyChannelRemoved(channelID string) {
 
 	newEvent := NewEvent{
 		Type:    NewEvent_REMOVE_CHANNEL,
@@ -134,15 +180,21 @@ func (session *Session) RemoveChannel(channelID string) {
 	data, err := newEvent.Marshal()
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Session Remove channel: failed to marhal new event: %v\n", err)
+		session.logger().WithField("ChannelID", channelID).WithError(err).Error("Session Remove channel: failed to marhal new event")
 	}
 
 	session.connection.Send(data)
+}
+
+// RemoveChannel - Remove channel while client is connected
+func (session *Session) RemoveChannel(channelID string) {
+
+	session.notifyChannelRemoved(channelID)
 
 	var found = false
 
 	for index, channel := range session.SubscribedChannels {
-		if channelID == channel.Data.ID {
+		if channelID == channel.Data().ID {
 
 			session.SubscribedChannels = RemoveChannelIndex(session.SubscribedChannels, index)
 			found = true
@@ -166,7 +218,7 @@ func (session *Session) Send(channelEvent *ChannelEvent) error {
 	data, err := channelEvent.Marshal()
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Session Publish: failed to marhal channel event: %v\n", err)
+		session.logger().WithError(err).Error("Session Publish: failed to marhal channel event")
 		return err
 	}
 
@@ -178,7 +230,7 @@ func (session *Session) Send(channelEvent *ChannelEvent) error {
 	newEventData, err := newEvent.Marshal()
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Session Publish: failed to marhal new channel event: %v\n", err)
+		session.logger().WithError(err).Error("Session Publish: failed to marhal new channel event")
 		return err
 	}
 
@@ -195,6 +247,105 @@ func (session *Session) Publish(data []byte) {
 	}
 
 	session.connection.Send(data)
+	eventsDelivered.Inc(session.hub.AppID)
+}
+
+// PublishChannelEvent - Send channel event data to subscribed client
+// If missed events are being replayed for the channel it is held back until they are sent
+func (session *Session) PublishChannelEvent(channelID string, eventID uint64, data []byte) {
+
+	if session.isClosed {
+		return
+	}
+
+	session.replayLock.Lock()
+
+	if events, isOK := session.replaying[channelID]; isOK {
+		session.replaying[channelID] = append(events, replayedEvent{ID: eventID, data: data})
+		session.replayLock.Unlock()
+		return
+	}
+
+	session.replayLock.Unlock()
+
+	session.connection.Send(data)
+	eventsDelivered.Inc(session.hub.AppID)
+}
+
+// startReplay - Start holding back live events of the channel
+func (session *Session) startReplay(channelID string) {
+	session.replayLock.Lock()
+	defer session.replayLock.Unlock()
+
+	if session.replaying == nil {
+		session.replaying = make(map[string][]replayedEvent)
+	}
+
+	session.replaying[channelID] = make([]replayedEvent, 0)
+}
+
+// stopReplay - Send held back live events that weren't replayed and resume live delivery
+func (session *Session) stopReplay(channelID string, lastReplayedID uint64) {
+	session.replayLock.Lock()
+	defer session.replayLock.Unlock()
+
+	for _, event := range session.replaying[channelID] {
+		// Events without ID aren't stored, so they can't have been replayed
+		if event.ID == 0 || event.ID > lastReplayedID {
+			session.Publish(event.data)
+		}
+	}
+
+	delete(session.replaying, channelID)
+}
+
+// cancelReplay - Drop held back live events and resume live delivery
+func (session *Session) cancelReplay(channelID string) {
+	session.replayLock.Lock()
+	defer session.replayLock.Unlock()
+
+	delete(session.replaying, channelID)
+}
+
+// ReasonReplayTruncated - Sent back when the missed events couldn't all be replayed
+// The client gets them with /c/:channelID/after/:eventID, then subscribes again
+const ReasonReplayTruncated = "replay truncated"
+
+// replay - Send the channel events after the given event ID, then switch to live delivery
+// Returns false if the missed events couldn't all be sent, the live events held back are dropped
+// and the reason is set if there are more than ReplayLimit of them or sending one failed
+func (session *Session) replay(channel *HubChannel, lastEventID uint64) (bool, string) {
+
+	lastReplayedID := lastEventID
+
+	if channel.Data().Persistent {
+		// One more than the limit tells if some would be left out
+		events, err := GetChannelEventsAfterID(channel.Data().AppID, channel.Data().ID, lastEventID, ReplayLimit+1)
+
+		if err != nil {
+			session.logger().WithError(err).Error("Session Replay: failed to get missed events")
+			session.cancelReplay(channel.Data().ID)
+			return false, ""
+		}
+
+		if int64(len(events)) > ReplayLimit {
+			session.cancelReplay(channel.Data().ID)
+			return false, ReasonReplayTruncated
+		}
+
+		for _, event := range events {
+			if err := session.Send(event); err != nil {
+				session.cancelReplay(channel.Data().ID)
+				return false, ReasonReplayTruncated
+			}
+
+			lastReplayedID = event.ID
+		}
+	}
+
+	session.stopReplay(channel.Data().ID, lastReplayedID)
+
+	return true, ""
 }
 
 func (session *Session) onHeartBeat() {
@@ -215,13 +366,24 @@ func (session *Session) onClose() {
 	}
 }
 
+// onTokenExpired - Close the session if its token was revoked
+func (session *Session) onTokenExpired() {
+	if session.isClosed {
+		return
+	}
+
+	if IsRevoked(session.identity, session.hub.AppID, session.deviceID) {
+		session.CloseWithStatus(CloseStatusDisconnected, "token revoked")
+	}
+}
+
 func (session *Session) onNewMessage(data []byte) {
 	var newEvent NewEvent
 
 	err := newEvent.Unmarshal(data)
 
 	if err != nil {
-		log.Println(err)
+		session.logger().Error(err)
 		return
 	}
 
@@ -232,13 +394,30 @@ func (session *Session) onNewMessage(data []byte) {
 		err = channelSub.Unmarshal(newEvent.Payload)
 
 		if err != nil {
-			log.Println(err)
+			session.logger().Error(err)
+			return
+		}
+
+		if didSubscribe, reason := session.CanSubscribe(channelSub.ChannelID, channelSub.LastEventID); reason != "" {
+			session.notifyRejected(channelSub.ID, reason)
+		} else {
+			session.notifyAck(channelSub.ID, didSubscribe)
+		}
+
+	} else if newEvent.Type == NewEvent_UNSUBSCRIBE {
+
+		var channelUnsub SubscribeRequest
+
+		err = channelUnsub.Unmarshal(newEvent.Payload)
+
+		if err != nil {
+			session.logger().Error(err)
 			return
 		}
 
-		didSubscribe := session.CanSubscribe(channelSub.ChannelID)
+		didUnsubscribe := session.Unsubscribe(channelUnsub.ChannelID)
 
-		session.notifyAck(channelSub.ID, didSubscribe)
+		session.notifyAck(channelUnsub.ID, didUnsubscribe)
 
 	} else if newEvent.Type == NewEvent_PUBLISH {
 
@@ -247,7 +426,15 @@ func (session *Session) onNewMessage(data []byte) {
 		err := channelPubRequest.Unmarshal(newEvent.Payload)
 
 		if err != nil {
-			log.Println(err)
+			session.logger().Error(err)
+			return
+		}
+
+		if reason := session.checkLimits(channelPubRequest.ChannelID, channelPubRequest.Payload); reason != "" {
+			if channelPubRequest.ID != 0 {
+				session.notifyRejected(channelPubRequest.ID, reason)
+			}
+
 			return
 		}
 
@@ -260,21 +447,173 @@ func (session *Session) onNewMessage(data []byte) {
 		}
 
 		session.CanPublish(channelPubRequest.ChannelID, &channelEvent, &channelPubRequest)
+
+	} else if newEvent.Type == NewEvent_DIRECT {
+
+		var directRequest DirectRequest
+
+		err := directRequest.Unmarshal(newEvent.Payload)
+
+		if err != nil {
+			session.logger().Error(err)
+			return
+		}
+
+		if reason := session.checkLimits("", directRequest.Payload); reason != "" {
+			if directRequest.ID != 0 {
+				session.notifyRejected(directRequest.ID, reason)
+			}
+
+			return
+		}
+
+		session.CanDirect(&directRequest)
+
+	} else if newEvent.Type == NewEvent_EDIT {
+
+		var editRequest EditRequest
+
+		err := editRequest.Unmarshal(newEvent.Payload)
+
+		if err != nil {
+			session.logger().Error(err)
+			return
+		}
+
+		if reason := session.checkLimits(editRequest.ChannelID, editRequest.Payload); reason != "" {
+			session.notifyRejected(editRequest.ID, reason)
+			return
+		}
+
+		didEdit, reason := session.CanEdit(editRequest.ChannelID, editRequest.EventID, editRequest.Payload)
+
+		if reason != "" {
+			session.notifyRejected(editRequest.ID, reason)
+		} else {
+			session.notifyAck(editRequest.ID, didEdit)
+		}
+
+	} else if newEvent.Type == NewEvent_DELETE {
+
+		var deleteRequest DeleteRequest
+
+		err := deleteRequest.Unmarshal(newEvent.Payload)
+
+		if err != nil {
+			session.logger().Error(err)
+			return
+		}
+
+		didDelete := session.CanDelete(deleteRequest.ChannelID, deleteRequest.EventID)
+
+		session.notifyAck(deleteRequest.ID, didDelete)
+
+	} else if newEvent.Type == NewEvent_READ {
+
+		var readRequest ReadRequest
+
+		err := readRequest.Unmarshal(newEvent.Payload)
+
+		if err != nil {
+			session.logger().Error(err)
+			return
+		}
+
+		didRead := session.CanRead(readRequest.ChannelID, readRequest.EventID)
+
+		session.notifyAck(readRequest.ID, didRead)
+
+	} else if newEvent.Type == NewEvent_SIGNAL {
+
+		var signalRequest SignalRequest
+
+		err := signalRequest.Unmarshal(newEvent.Payload)
+
+		if err != nil {
+			session.logger().Error(err)
+			return
+		}
+
+		if !GetAppLimits(session.hub.AppID).IsPayloadAllowed(signalRequest.Payload) {
+			session.limitViolation()
+
+			if signalRequest.ID != 0 {
+				session.notifyRejected(signalRequest.ID, ReasonPayloadTooLarge)
+			}
+
+			return
+		}
+
+		didSignal := session.CanSignal(&signalRequest)
+
+		//* INFO: Like publishing, if ID == 0 then we don't need a response back
+		if signalRequest.ID != 0 {
+			session.notifyAck(signalRequest.ID, didSignal)
+		}
+	}
+
+}
+
+// checkLimits - Check the payload size and publish rates of the app limits, returning why the message is refused
+// The channel rate isn't checked without a channelID
+func (session *Session) checkLimits(channelID string, payload string) string {
+	limits := GetAppLimits(session.hub.AppID)
+
+	reason := ""
+
+	if !limits.IsPayloadAllowed(payload) {
+		reason = ReasonPayloadTooLarge
+	} else if !session.publishLimiter.Allow() {
+		reason = ReasonPublishRateLimited
+	} else if channelID != "" && !publishChannelLimiters.Allow(session.hub.AppID, channelID, limits) {
+		reason = ReasonChannelRateLimited
 	}
 
+	if reason == "" {
+		session.violations = 0
+		return ""
+	}
+
+	publishLimited.Inc(session.hub.AppID)
+	session.limitViolation()
+
+	return reason
+}
+
+// limitViolation - Count a message refused by the limits, sessions refused too many times in a row are disconnected
+func (session *Session) limitViolation() {
+	limits := GetAppLimits(session.hub.AppID)
+
+	session.violations++
+
+	if limits.MaxViolations > 0 && session.violations >= limits.MaxViolations {
+		session.logger().WithField("Violations", session.violations).Warn("Session limits: too many limited messages, disconnecting")
+		session.CloseWithStatus(CloseStatusPolicy, "too many limited messages")
+	}
 }
 
 // notifyAck - Notify publish success
 func (session *Session) notifyAck(requestID uint32, status bool) {
-	ack := PublishAck{
+	session.sendAck(&PublishAck{
 		ReplyTo: requestID,
 		Status:  status,
-	}
+	})
+}
+
+// notifyRejected - Notify a publish failure with the reason it was rejected
+func (session *Session) notifyRejected(requestID uint32, reason string) {
+	session.sendAck(&PublishAck{
+		ReplyTo: requestID,
+		Reason:  reason,
+	})
+}
 
+// sendAck - Send the given ack to the client
+func (session *Session) sendAck(ack *PublishAck) {
 	data, err := ack.Marshal()
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Session Notify: failed to marhal ack: %v\n", err)
+		session.logger().WithError(err).Error("Session Notify: failed to marhal ack")
 		return
 	}
 
@@ -286,7 +625,7 @@ func (session *Session) notifyAck(requestID uint32, status bool) {
 	data, err = newEvent.Marshal()
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Session Notify: failed to marhal event: %v\n", err)
+		session.logger().WithError(err).Error("Session Notify: failed to marhal event")
 		return
 	}
 
@@ -298,33 +637,220 @@ func (session *Session) notifyAck(requestID uint32, status bool) {
 // Otherwise we publish but won't store the event, nor send the notify back
 func (session *Session) CanPublish(channelID string, event *ChannelEvent, publishRequest *PublishRequest) {
 
-	isAllowed := false
+	isAllowed := session.canPublishIn(channelID)
+
+	didPublish := false
+	reason := ""
+
+	if session.hook == nil && isAllowed {
+		didPublish, reason = session.hub.Publish(channelID, event, publishRequest.ID != 0, session)
+	} else if session.hook != nil && session.hook.CanPublish(channelID, session, isAllowed) {
+		didPublish, reason = session.hub.Publish(channelID, event, publishRequest.ID != 0, session)
+	}
+
+	//* INFO: If ID == 0 then we don't need a response back and it won't be stored
+	if publishRequest != nil && publishRequest.ID != 0 {
+		if reason != "" {
+			session.notifyRejected(publishRequest.ID, reason)
+		} else {
+			session.notifyAck(publishRequest.ID, didPublish)
+		}
+	}
+}
+
+// canPublishIn - Check if the client is in the channel with a role allowed to publish, admins always are
+func (session *Session) canPublishIn(channelID string) bool {
 
 	if session.identity.IsAdminKind() {
-		isAllowed = true
+		return true
 	}
 
-	if !isAllowed {
-		for _, c := range session.AllowedChannels {
-			if c == channelID {
-				isAllowed = true
-				break
-			}
+	for _, c := range session.AllowedChannels {
+		if c == channelID {
+			return session.channelRole(channelID).CanPublish()
 		}
 	}
 
-	didPublish := false
+	return false
+}
 
-	if session.hook == nil && isAllowed {
-		didPublish = session.hub.Publish(channelID, event, publishRequest.ID != 0, session)
-	} else if session.hook != nil && session.hook.CanPublish(channelID, session, isAllowed) {
-		didPublish = session.hub.Publish(channelID, event, publishRequest.ID != 0, session)
+// channelRole - Role of the client in the channel, admins act as owners
+func (session *Session) channelRole(channelID string) ChannelRole {
+
+	if session.identity.IsAdminKind() {
+		return ChannelRoleOwner
 	}
 
-	//* INFO: If ID == 0 then we don't need a response back and it won't be stored
-	if publishRequest != nil && publishRequest.ID != 0 {
-		session.notifyAck(publishRequest.ID, didPublish)
+	role, err := GetChannelClientRole(session.hub.AppID, channelID, session.identity.ClientID)
+
+	if err != nil {
+		session.logger().WithField("ChannelID", channelID).WithError(err).Error("Session channel role: failed to get role")
+		return ""
 	}
+
+	return role
+}
+
+// CanDirect - Publish to the direct messages channel shared with another client, creating it on first use
+// Publishing follows the same rules as CanPublish, so an admin can still remove a client from the channel
+func (session *Session) CanDirect(directRequest *DirectRequest) {
+
+	channel, err := GetDirectChannel(session.hub.AppID, session.identity.ClientID, directRequest.ClientID)
+
+	if err != nil || channel == nil {
+		if directRequest.ID != 0 {
+			session.notifyAck(directRequest.ID, false)
+		}
+
+		return
+	}
+
+	publishRequest := PublishRequest{
+		ID:        directRequest.ID,
+		EventType: directRequest.EventType,
+		ChannelID: channel.ID,
+		Payload:   directRequest.Payload,
+	}
+
+	var channelEvent = ChannelEvent{
+		SenderID:  session.identity.ClientID,
+		EventType: directRequest.EventType,
+		Payload:   directRequest.Payload,
+		ChannelID: channel.ID,
+		Timestamp: time.Now().Unix(),
+	}
+
+	session.CanPublish(channel.ID, &channelEvent, &publishRequest)
+}
+
+// CanEdit - Check if user is allowed to edit the event, if so replace its payload
+// Payloads not following the event type rules are rejected, returning the reason
+func (session *Session) CanEdit(channelID string, eventID uint64, payload string) (bool, string) {
+	event := session.getChangeableEvent(channelID, eventID)
+
+	if event == nil {
+		return false, ""
+	}
+
+	reason, err := ValidateChannelEvent(session.hub.AppID, channelID, event.EventType, payload)
+
+	if err != nil || reason != "" {
+		return false, reason
+	}
+
+	EditChannelEvent(session.hub.AppID, event, payload)
+
+	return true, ""
+}
+
+// CanDelete - Check if user is allowed to delete the event, if so remove it
+func (session *Session) CanDelete(channelID string, eventID uint64) bool {
+	event := session.getChangeableEvent(channelID, eventID)
+
+	if event == nil {
+		return false
+	}
+
+	DeleteChannelEvent(session.hub.AppID, channelID, eventID)
+
+	return true
+}
+
+// getChangeableEvent - Get a stored event the user can edit or delete
+// Users must be allowed to publish in the channel and can only change their own events, unless they are admins or moderate the channel
+func (session *Session) getChangeableEvent(channelID string, eventID uint64) *ChannelEvent {
+
+	isAllowed := session.canPublishIn(channelID)
+
+	if session.hook != nil {
+		isAllowed = session.hook.CanPublish(channelID, session, isAllowed)
+	}
+
+	if !isAllowed {
+		return nil
+	}
+
+	channel, err := GetChannel(session.hub.AppID, channelID)
+
+	// Only persistent channels store events
+	if err != nil || channel == nil || channel.IsClosed || !channel.Persistent {
+		return nil
+	}
+
+	event, err := GetChannelEvent(session.hub.AppID, channelID, eventID)
+
+	if err != nil || event == nil {
+		return nil
+	}
+
+	if event.SenderID != session.identity.ClientID && !session.channelRole(channelID).CanModerate() {
+		return nil
+	}
+
+	return event
+}
+
+// CanSignal - Check if user is allowed to publish in the channel and isn't sending too many signals, if so send the signal
+func (session *Session) CanSignal(signalRequest *SignalRequest) bool {
+
+	isAllowed := session.canPublishIn(signalRequest.ChannelID)
+
+	if session.hook != nil {
+		isAllowed = session.hook.CanPublish(signalRequest.ChannelID, session, isAllowed)
+	}
+
+	if !isAllowed {
+		return false
+	}
+
+	if !session.signalLimiter.Allow() {
+		signalsLimited.Inc(session.hub.AppID)
+		session.limitViolation()
+		return false
+	}
+
+	channel, err := GetChannel(session.hub.AppID, signalRequest.ChannelID)
+
+	if err != nil || channel == nil || channel.IsClosed {
+		return false
+	}
+
+	PublishChannelSignal(session.hub.AppID, &ChannelSignal{
+		SenderID:   session.identity.ClientID,
+		ChannelID:  signalRequest.ChannelID,
+		SignalType: signalRequest.SignalType,
+		Payload:    signalRequest.Payload,
+		Timestamp:  time.Now().Unix(),
+	}, session)
+
+	return true
+}
+
+// CanRead - Check if user is a member of the channel, if so store its read marker
+// The marker replaces the previous one, so it can also be moved back to mark events as unread
+func (session *Session) CanRead(channelID string, eventID uint64) bool {
+
+	isAllowed := false
+
+	for _, c := range session.AllowedChannels {
+		if c == channelID {
+			isAllowed = true
+			break
+		}
+	}
+
+	if !isAllowed {
+		return false
+	}
+
+	channel, err := GetChannel(session.hub.AppID, channelID)
+
+	// Only persistent channels have event IDs to mark
+	if err != nil || channel == nil || !channel.Persistent {
+		return false
+	}
+
+	return SetReadMarker(session.hub.AppID, channelID, session.identity.ClientID, eventID) == nil
 }
 
 // GetIdentifier - Get client and device identifier
@@ -333,15 +859,17 @@ func (session *Session) GetIdentifier() string {
 }
 
 // CanSubscribe - Check if user is allowed to subscribe, if so subscribe
-func (session *Session) CanSubscribe(channelID string) bool {
+// If lastEventID is given, the events published after it are sent before live ones
+// The reason is set if the subscription failed because the missed events couldn't all be replayed
+func (session *Session) CanSubscribe(channelID string, lastEventID uint64) (bool, string) {
 
 	channel, err := GetChannel(session.hub.AppID, channelID)
 
 	if err != nil {
-		log.Println(err)
-		return false
+		session.logger().WithField("ChannelID", channelID).Error(err)
+		return false, ""
 	} else if channel == nil {
-		return false
+		return false, ""
 	}
 
 	inAllowedChannels := false
@@ -365,37 +893,89 @@ func (session *Session) CanSubscribe(channelID string) bool {
 	// If is in allowed and there isn't a hook for further checking
 	// Then subscribe
 	if session.hook == nil && inAllowedChannels {
-		channel := session.hub.Subscribe(channelID, session)
+		return session.subscribe(channelID, lastEventID)
+	}
 
-		if channel == nil {
-			return false
-		}
+	// If there is a hook then ask it
+	if session.hook != nil && session.hook.CanSubscribe(channelID, session, inAllowedChannels) {
+		return session.subscribe(channelID, lastEventID)
+	}
+
+	return false, ""
+}
 
-		session.SubscribedChannels = append(session.SubscribedChannels, channel)
+// subscribe - Subscribe to hub channel and replay missed events if requested
+func (session *Session) subscribe(channelID string, lastEventID uint64) (bool, string) {
 
-		return true
+	// Hold back live events from the moment we subscribe
+	// So none is lost or sent twice while replaying
+	if lastEventID != 0 {
+		session.startReplay(channelID)
 	}
 
-	// If there is a hook then ask it
-	if session.hook != nil && session.hook.CanSubscribe(channelID, session, inAllowedChannels) {
-		channel := session.hub.Subscribe(channelID, session)
+	channel := session.hub.Subscribe(channelID, session)
 
-		if channel == nil {
-			return false
+	if channel == nil {
+		if lastEventID != 0 {
+			session.stopReplay(channelID, 0)
 		}
 
-		session.SubscribedChannels = append(session.SubscribedChannels, channel)
+		return false, ""
+	}
+
+	session.SubscribedChannels = append(session.SubscribedChannels, channel)
 
-		return true
+	// Without the missed events the client would have a gap, so it must subscribe again
+	if lastEventID != 0 {
+		if didReplay, reason := session.replay(channel, lastEventID); !didReplay {
+			session.Unsubscribe(channelID)
+			return false, reason
+		}
+	}
+
+	return true, ""
+}
+
+// Unsubscribe - Stop receiving events from a subscribed channel
+// Returns false if the session wasn't subscribed to the channel
+func (session *Session) Unsubscribe(channelID string) bool {
+
+	for index, channel := range session.SubscribedChannels {
+		if channelID == channel.Data().ID {
+
+			session.SubscribedChannels = RemoveChannelIndex(session.SubscribedChannels, index)
+			session.hub.Unsubscribe(channelID, session)
+
+			return true
+		}
 	}
 
 	return false
 }
 
+// CloseWithStatus - closes session and connection letting the client know why
+func (session *Session) CloseWithStatus(code uint16, reason string) {
+	session.isClosed = true
+
+	if session.expiryTimer != nil {
+		session.expiryTimer.Stop()
+	}
+
+	if session.connection.IsConnected() {
+		session.connection.CloseWithStatus(code, reason)
+	}
+
+	session.hub.RemoveClient(session)
+}
+
 // Close - closes session and connection
 func (session *Session) Close() {
 	session.isClosed = true
 
+	if session.expiryTimer != nil {
+		session.expiryTimer.Stop()
+	}
+
 	if session.connection.IsConnected() {
 		session.connection.Close()
 	}
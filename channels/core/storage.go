@@ -13,6 +13,7 @@ type AppRepository interface {
 	GetApps() ([]*App, error)
 	GetApp(id string) (*App, error)
 	UpdateApp(id string, name string) error
+	GetAppsPage(options ListOptions) ([]*App, error)
 }
 
 // Client - Database representation of a Client
@@ -37,6 +38,7 @@ type ClientRepository interface {
 	GetAppClients(appID string) ([]*Client, error)
 	//GetAppClientsCount(appID string) (uint64, error)
 	GetAllClients() ([]*Client, error)
+	GetClientsPage(appID string, options ListOptions) ([]*Client, error) // Clients of all apps when appID is empty
 	//GetAllClientsCount() (uint64, error)
 }
 
@@ -72,7 +74,26 @@ type Channel struct {
 	Push       bool   `json:"isPush"`
 }
 
-// ChannelRepository - Repository for handling Channel, Channel_Event and Channel_Client tables
+// ChannelClient - Client of a channel and its role in it
+type ChannelClient struct {
+	Client *Client
+	Role   ChannelRole
+}
+
+// EventTypeRule - Event type allowed in a channel or app, with an optional JSON Schema for its payload
+type EventTypeRule struct {
+	EventType string `json:"eventType"`
+	Schema    string `json:"schema,omitempty"`
+}
+
+// ReadMarker - Database representation of the last channel event read by a client
+type ReadMarker struct {
+	ClientID  string `json:"clientID"`
+	EventID   uint64 `json:"eventID"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// ChannelRepository - Repository for handling Channel, Channel_Event, Channel_Client, Channel_Read and Event_Type_Rule tables
 type ChannelRepository interface {
 	CreateChannel(id string, appID string, name string, createdAt int64, isClosed bool, extra string, persistent bool, private bool, presence bool, push bool) error
 
@@ -82,9 +103,20 @@ type ChannelRepository interface {
 
 	JoinClient(appID string, channelID string, clientID string) error
 	LeaveClient(appID string, channelID string, clientID string) error
+	JoinClients(appID string, channelID string, clientIDs []string) error  // In one transaction, clients already in the channel are ignored
+	LeaveClients(appID string, channelID string, clientIDs []string) error // In one transaction
+	GetChannelClientsPage(appID string, channelID string, options ListOptions) ([]*ChannelClient, error)
+
+	GetChannelClientRole(appID string, channelID string, clientID string) (ChannelRole, error) // Returns empty if the client isn't in the channel
+	SetChannelClientRole(appID string, channelID string, clientID string, role ChannelRole) error
 
 	SetChannelCloseStatus(appID string, channelID string, isClosed bool) error
 
+	UpdateChannel(appID string, channel *Channel) error // Update name, extra, persistent, private, presence and push
+
+	GetEventTypeRules(appID string, channelID string) ([]*EventTypeRule, error)     // Empty channelID for the app rules
+	SetEventTypeRules(appID string, channelID string, rules []*EventTypeRule) error // Replaces the previous rules in one transaction
+
 	GetClientAllowedChannels(clientID string) ([]string, error)
 	GetClientPrivateChannels(clientID string) ([]*Channel, error)
 	GetClientPublicChannels(clientID string) ([]*Channel, error)
@@ -92,6 +124,9 @@ type ChannelRepository interface {
 	GetAppPrivateChannels(appID string) ([]*Channel, error)
 	GetAppPublicChannels(appID string) ([]*Channel, error)
 
+	GetAppChannelsPage(appID string, private bool, options ListOptions) ([]*Channel, error)
+	GetClientChannelsPage(clientID string, private bool, options ListOptions) ([]*Channel, error)
+
 	ExistsAppChannel(appID string, channelID string) (bool, error)
 	GetAppChannel(appID string, channelID string) (*Channel, error)
 
@@ -106,11 +141,42 @@ type ChannelRepository interface {
 	AddChannelEvent(appID string, channelID string, event *ChannelEvent) error
 	AddChannelEvents(items []InsertItem) error
 
+	GetChannelEvent(appID string, channelID string, eventID uint64) (*ChannelEvent, error) // Returns nil if the event doesn't exist
+	UpdateChannelEvent(appID string, channelID string, eventID uint64, payload string) error
+	DeleteChannelEvent(appID string, channelID string, eventID uint64) error
+
 	GetChannelEventsAfter(appID string, channelID string, timestamp int64) ([]*ChannelEvent, error)
 	GetChannelEventsAfterAndBefore(appID string, channelID string, timestampAfter int64, timestampBefore int64) ([]*ChannelEvent, error)
 	GetChannelLastEvents(appID string, channelID string, amount int64) ([]*ChannelEvent, error)
 	GetChannelLastEventsAfter(appID string, channelID string, amount int64, timestamp int64) ([]*ChannelEvent, error)
 	GetChannelLastEventsBefore(appID string, channelID string, amount int64, timestamp int64) ([]*ChannelEvent, error)
+
+	GetChannelLastEventID(appID string, channelID string) (uint64, error)
+	GetChannelEventsAfterID(appID string, channelID string, eventID uint64) ([]*ChannelEvent, error)
+	GetChannelLastEventsAfterID(appID string, channelID string, amount int64, eventID uint64) ([]*ChannelEvent, error)
+
+	SetReadMarker(appID string, channelID string, clientID string, eventID uint64, timestamp int64) error
+	GetReadMarker(appID string, channelID string, clientID string) (*ReadMarker, error) // Returns nil if the client didn't read the channel yet
+	GetReadMarkers(appID string, channelID string) ([]*ReadMarker, error)
+	CountUnreadChannelEvents(appID string, channelID string, clientID string, eventID uint64) (uint64, error) // Events after eventID not sent by the client
+}
+
+// APIKey - Database representation of an app API key, only the hash of the key is stored
+type APIKey struct {
+	ID        string
+	AppID     string
+	Name      string
+	KeyHash   string   // Hex SHA-256 of the key
+	Scopes    []string // Stored comma separated
+	CreatedAt int64
+}
+
+// APIKeyRepository - Repository for handling API_Key table
+type APIKeyRepository interface {
+	CreateAPIKey(key *APIKey) error
+	GetAPIKeyByHash(keyHash string) (*APIKey, error) // Returns nil if there isn't a key with the hash
+	GetAppAPIKeys(appID string) ([]*APIKey, error)
+	DeleteAPIKey(appID string, keyID string) (bool, error) // Returns false if the app doesn't have the key
 }
 
 // DatabaseStorage - Persistent database storage interface
@@ -119,4 +185,5 @@ type DatabaseStorage interface {
 	GetClientRepository() ClientRepository
 	GetChannelRepository() ChannelRepository
 	GetDeviceRepository() DeviceRepository
+	GetAPIKeyRepository() APIKeyRepository
 }
@@ -1,89 +1,374 @@
 package core
 
 import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"sync"
+	"time"
+
 	log "github.com/sirupsen/logrus"
 )
 
+// InsertItemKind - What should be done with the event of a queued item
+type InsertItemKind int
+
+const (
+	InsertItemAdd    InsertItemKind = iota // Insert the event
+	InsertItemEdit                         // Update the payload of the stored event
+	InsertItemDelete                       // Remove the stored event, only ChannelID and ID are set
+)
+
 // InsertItem - Insert Item queued
 type InsertItem struct {
 	Event *ChannelEvent
 	AppID string
+	Kind  InsertItemKind
 }
 
 type StorageInsert interface {
 	StoreEvent(appID string, event *ChannelEvent)
+	EditEvent(appID string, event *ChannelEvent)                // Update the stored event payload, after the events queued before it are stored
+	DeleteEvent(appID string, channelID string, eventID uint64) // Remove the stored event, after the events queued before it are stored
 	Start(channelRepository ChannelRepository)
+	Stop(ctx context.Context) error                          // Stop accepting events and wait until the queued ones are stored
+	Len() int                                                // Events waiting to be stored
+	LastQueuedEventID(appID string, channelID string) uint64 // Highest event ID of the channel waiting to be stored, 0 if there is none
 }
 
 func NewStorageInsertQueue() *StorageInsertQueue {
-	return &StorageInsertQueue{
-		insertChannel: make(chan InsertItem, 300),
-	}
+	return &StorageInsertQueue{}
 }
 
-// StorageInsertQueue - Receives all insert requests and send them into the database
+// StorageInsertQueue - Receives all insert requests and send them into the database in batches
+// A batch is inserted once it reaches CacheLimit events or CacheTimeout has passed
+// Items of a channel always go to the same worker, so edits and deletes are applied after the event is inserted
 type StorageInsertQueue struct {
-	insertChannel chan InsertItem
+	shards    []chan InsertItem // One per worker, created with InsertWorkers on first use
+	initOnce  sync.Once
+	isStarted bool
+	lock      sync.RWMutex
+	isStopped bool
+	workers   sync.WaitGroup
+
+	pendingLock sync.Mutex
+	pending     map[string]*pendingEvents // By AppID:ChannelID
+}
+
+// pendingEvents - Events of a channel added to the queue and not inserted yet
+type pendingEvents struct {
+	count  int
+	lastID uint64
+}
+
+func channelKey(appID string, channelID string) string {
+	return appID + ":" + channelID
+}
+
+// getShards - Create the worker queues once, so a channel is always sent to the same one
+func (storage *StorageInsertQueue) getShards() []chan InsertItem {
+	storage.initOnce.Do(func() {
+		workers := InsertWorkers
+
+		if workers < 1 {
+			workers = 1
+		}
+
+		storage.shards = make([]chan InsertItem, workers)
+
+		for index := range storage.shards {
+			storage.shards[index] = make(chan InsertItem, 300)
+		}
+	})
+
+	return storage.shards
+}
+
+// shard - Queue of the worker handling the channel
+func (storage *StorageInsertQueue) shard(appID string, channelID string) chan InsertItem {
+	shards := storage.getShards()
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(channelKey(appID, channelID)))
+
+	return shards[hash.Sum32()%uint32(len(shards))]
 }
 
 func (storage *StorageInsertQueue) StoreEvent(appID string, event *ChannelEvent) {
-	storage.insertChannel <- InsertItem{
+	storage.enqueue(InsertItem{
 		AppID: appID,
 		Event: event,
+		Kind:  InsertItemAdd,
+	})
+}
+
+func (storage *StorageInsertQueue) EditEvent(appID string, event *ChannelEvent) {
+	storage.enqueue(InsertItem{
+		AppID: appID,
+		Event: event,
+		Kind:  InsertItemEdit,
+	})
+}
+
+func (storage *StorageInsertQueue) DeleteEvent(appID string, channelID string, eventID uint64) {
+	storage.enqueue(InsertItem{
+		AppID: appID,
+		Event: &ChannelEvent{ChannelID: channelID, ID: eventID},
+		Kind:  InsertItemDelete,
+	})
+}
+
+func (storage *StorageInsertQueue) enqueue(item InsertItem) {
+	storage.lock.RLock()
+	defer storage.lock.RUnlock()
+
+	if storage.isStopped {
+		logger.WithFields(log.Fields{
+			"AppID":     item.AppID,
+			"ChannelID": item.Event.ChannelID,
+		}).Error("Storage insert queue is stopped, event will not be stored")
+		return
+	}
+
+	if item.Kind == InsertItemAdd {
+		storage.addPending(item)
+	}
+
+	storage.shard(item.AppID, item.Event.ChannelID) <- item
+}
+
+// addPending - Remember the event until it is inserted, so its ID isn't given again meanwhile
+func (storage *StorageInsertQueue) addPending(item InsertItem) {
+	storage.pendingLock.Lock()
+	defer storage.pendingLock.Unlock()
+
+	if storage.pending == nil {
+		storage.pending = make(map[string]*pendingEvents)
+	}
+
+	key := channelKey(item.AppID, item.Event.ChannelID)
+	pending, isOK := storage.pending[key]
+
+	if !isOK {
+		pending = &pendingEvents{}
+		storage.pending[key] = pending
+	}
+
+	pending.count++
+
+	if item.Event.ID > pending.lastID {
+		pending.lastID = item.Event.ID
+	}
+}
+
+// removePending - Forget the batch events once they were inserted, or failed to
+func (storage *StorageInsertQueue) removePending(batch []InsertItem) {
+	storage.pendingLock.Lock()
+	defer storage.pendingLock.Unlock()
+
+	for _, item := range batch {
+		key := channelKey(item.AppID, item.Event.ChannelID)
+
+		if pending, isOK := storage.pending[key]; isOK {
+			pending.count--
+
+			if pending.count <= 0 {
+				delete(storage.pending, key)
+			}
+		}
+	}
+}
+
+// LastQueuedEventID - Highest event ID of the channel waiting to be inserted, including the ones in a batch
+func (storage *StorageInsertQueue) LastQueuedEventID(appID string, channelID string) uint64 {
+	storage.pendingLock.Lock()
+	defer storage.pendingLock.Unlock()
+
+	if pending, isOK := storage.pending[channelKey(appID, channelID)]; isOK {
+		return pending.lastID
 	}
+
+	return 0
 }
 
+// Len - Events waiting in the queue, not including the ones already in a batch
+func (storage *StorageInsertQueue) Len() int {
+	size := 0
+
+	for _, shard := range storage.getShards() {
+		size += len(shard)
+	}
+
+	return size
+}
+
+// Start - Start the InsertWorkers workers and wait until the queue is stopped
+// Later calls return right away
 func (storage *StorageInsertQueue) Start(repo ChannelRepository) {
+	shards := storage.getShards()
+
+	storage.lock.Lock()
+
+	if storage.isStopped || storage.isStarted {
+		storage.lock.Unlock()
+		return
+	}
+
+	storage.isStarted = true
+	storage.workers.Add(len(shards))
+	storage.lock.Unlock()
+
+	for _, shard := range shards[1:] {
+		go storage.work(repo, shard)
+	}
+
+	storage.work(repo, shards[0])
+}
+
+// work - Batch the adds of the queue, edits and deletes insert the batch before being applied
+func (storage *StorageInsertQueue) work(repo ChannelRepository, queue chan InsertItem) {
+	defer storage.workers.Done()
+
+	batch := make([]InsertItem, 0, CacheLimit)
+	tick := time.NewTicker(CacheTimeout)
+	defer tick.Stop()
+
 	for {
-		item, isActive := <-storage.insertChannel
+		select {
+		case item, isActive := <-queue:
+
+			// Queue was stopped, store what is left and leave
+			if !isActive {
+				if len(batch) != 0 {
+					storage.insert(repo, batch)
+				}
+				return
+			}
+
+			// Edits and deletes are applied once the events queued before them are stored
+			if item.Kind != InsertItemAdd {
+				if len(batch) != 0 {
+					storage.insert(repo, batch)
+					batch = batch[:0]
+				}
+
+				storage.change(repo, item)
+				break
+			}
+
+			batch = append(batch, item)
+
+			if len(batch) < CacheLimit {
+				break
+			}
+
+			storage.insert(repo, batch)
+			batch = batch[:0]
+
+			// Reset the timeout ticker, so the timeout trigger remains consistent
+			tick.Reset(CacheTimeout)
+		case <-tick.C:
+			if len(batch) == 0 {
+				continue
+			}
+
+			storage.insert(repo, batch)
+			batch = batch[:0]
+		}
+	}
+}
 
-		if !isActive {
+// ErrInsertQueueNotStarted - Returned by Stop when events were queued but no worker was started to store them
+var ErrInsertQueueNotStarted = errors.New("insert queue was never started, queued events were not stored")
+
+// Stop - Stop accepting events and wait until workers insert the queued ones
+func (storage *StorageInsertQueue) Stop(ctx context.Context) error {
+	storage.lock.Lock()
+
+	if !storage.isStopped {
+		storage.isStopped = true
+
+		for _, shard := range storage.getShards() {
+			close(shard)
+		}
+	}
+
+	isStarted := storage.isStarted
+	storage.lock.Unlock()
+
+	if !isStarted {
+		if storage.Len() != 0 {
+			return ErrInsertQueueNotStarted
+		}
+
+		return nil
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		storage.workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// insert - Insert batch retrying when it fails
+// If it keeps failing events are inserted one by one, so only the invalid ones are lost
+func (storage *StorageInsertQueue) insert(repo ChannelRepository, batch []InsertItem) {
+	defer storage.removePending(batch)
+
+	for attempt := 1; attempt <= InsertRetries; attempt++ {
+		start := time.Now()
+		err := repo.AddChannelEvents(batch)
+		insertDuration.ObserveSince(start)
+
+		if err == nil {
 			return
 		}
 
+		insertErrors.Inc()
+
+		logger.WithFields(log.Fields{
+			"Attempt": attempt,
+			"Size":    len(batch),
+		}).Error(err)
+
+		if attempt < InsertRetries {
+			time.Sleep(time.Duration(attempt) * InsertRetryDelay)
+		}
+	}
+
+	for _, item := range batch {
 		if err := repo.AddChannelEvent(item.AppID, item.Event.ChannelID, item.Event); err != nil {
-			log.WithFields(log.Fields{
+			logger.WithFields(log.Fields{
 				"Item": item,
 			}).Error(err)
 		}
 	}
 }
 
-// func (storage *StorageInsertQueue) Startx(repo ChannelRepository) {
-// 	cache := make([]InsertItem, 0, CacheLimit)
-// 	tick := time.NewTicker(CacheTimeout)
-
-// 	for {
-// 		select {
-// 		case m := <-storage.insertChannel:
-// 			cache = append(cache, m)
-
-// 			if len(cache) < CacheLimit {
-// 				break
-// 			}
-
-// 			// Reset the timeout ticker.
-// 			// Otherwise we will get too many sends.
-// 			tick.Stop()
-
-// 			// Send the cached messages and reset the cache.
-// 			if err := repo.AddChannelEvents(cache); err != nil {
-// 				log.Printf("error: %v", err)
-// 			}
-// 			cache = cache[:0]
-
-// 			// Recreate the ticker, so the timeout trigger
-// 			// remains consistent.
-// 			tick = time.NewTicker(CacheTimeout)
-// 		case <-tick.C:
-// 			if len(cache) == 0 {
-// 				continue
-// 			}
-
-// 			if err := repo.AddChannelEvents(cache); err != nil {
-// 				log.Printf("error: %v", err)
-// 			}
-// 			cache = cache[:0]
-// 		}
-// 	}
-// }
+// change - Apply an edit or delete to a stored event
+func (storage *StorageInsertQueue) change(repo ChannelRepository, item InsertItem) {
+	var err error
+
+	if item.Kind == InsertItemEdit {
+		err = repo.UpdateChannelEvent(item.AppID, item.Event.ChannelID, item.Event.ID, item.Event.Payload)
+	} else {
+		err = repo.DeleteChannelEvent(item.AppID, item.Event.ChannelID, item.Event.ID)
+	}
+
+	if err != nil {
+		logger.WithFields(log.Fields{
+			"AppID":     item.AppID,
+			"ChannelID": item.Event.ChannelID,
+			"EventID":   item.Event.ID,
+		}).Error(err)
+	}
+}
@@ -0,0 +1,289 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// insertRepository - ChannelRepository that only records inserted events
+type insertRepository struct {
+	ChannelRepository
+	lock      sync.Mutex
+	batches   [][]InsertItem
+	single    []InsertItem
+	changes   []InsertItem
+	failFirst int
+	inserted  map[string]bool // ChannelID and event ID of the inserted events
+	missing   int             // Changes applied before their event was inserted
+}
+
+func (repo *insertRepository) markInserted(items []InsertItem) {
+	if repo.inserted == nil {
+		repo.inserted = make(map[string]bool)
+	}
+
+	for _, item := range items {
+		repo.inserted[item.Event.ChannelID+":"+strconv.FormatUint(item.Event.ID, 10)] = true
+	}
+}
+
+func (repo *insertRepository) markChanged(item InsertItem) {
+	if !repo.inserted[item.Event.ChannelID+":"+strconv.FormatUint(item.Event.ID, 10)] {
+		repo.missing++
+	}
+
+	repo.changes = append(repo.changes, item)
+}
+
+func (repo *insertRepository) AddChannelEvents(items []InsertItem) error {
+	repo.lock.Lock()
+	defer repo.lock.Unlock()
+
+	if repo.failFirst > 0 {
+		repo.failFirst--
+		return errors.New("insert failed")
+	}
+
+	batch := make([]InsertItem, len(items))
+	copy(batch, items)
+	repo.batches = append(repo.batches, batch)
+	repo.markInserted(items)
+
+	return nil
+}
+
+func (repo *insertRepository) AddChannelEvent(appID string, channelID string, event *ChannelEvent) error {
+	repo.lock.Lock()
+	defer repo.lock.Unlock()
+
+	repo.single = append(repo.single, InsertItem{AppID: appID, Event: event})
+
+	return nil
+}
+
+func (repo *insertRepository) UpdateChannelEvent(appID string, channelID string, eventID uint64, payload string) error {
+	repo.lock.Lock()
+	defer repo.lock.Unlock()
+
+	repo.markChanged(InsertItem{AppID: appID, Event: &ChannelEvent{ChannelID: channelID, ID: eventID, Payload: payload}, Kind: InsertItemEdit})
+
+	return nil
+}
+
+func (repo *insertRepository) DeleteChannelEvent(appID string, channelID string, eventID uint64) error {
+	repo.lock.Lock()
+	defer repo.lock.Unlock()
+
+	repo.markChanged(InsertItem{AppID: appID, Event: &ChannelEvent{ChannelID: channelID, ID: eventID}, Kind: InsertItemDelete})
+
+	return nil
+}
+
+// saveInsertSettings - Restore the insert queue settings once the test finishes
+func saveInsertSettings(t *testing.T) {
+	limit, timeout, retries := CacheLimit, CacheTimeout, InsertRetries
+
+	t.Cleanup(func() {
+		CacheLimit, CacheTimeout, InsertRetries = limit, timeout, retries
+	})
+}
+
+func TestStorageInsertQueueBatches(t *testing.T) {
+	saveInsertSettings(t)
+	CacheLimit = 3
+	CacheTimeout = time.Hour
+
+	repo := &insertRepository{}
+	queue := NewStorageInsertQueue()
+
+	go queue.Start(repo)
+
+	for i := 0; i < 7; i++ {
+		queue.StoreEvent("123", &ChannelEvent{ChannelID: "321", ID: uint64(i + 1)})
+	}
+
+	// Give the worker time to pick up the events before stopping
+	time.Sleep(50 * time.Millisecond)
+
+	if err := queue.Stop(context.Background()); err != nil {
+		t.Fatalf("Failed to stop queue: %v", err)
+	}
+
+	if len(repo.batches) != 3 {
+		t.Fatalf("Expected 3 batches, got %d", len(repo.batches))
+	}
+
+	if len(repo.batches[0]) != 3 || len(repo.batches[1]) != 3 || len(repo.batches[2]) != 1 {
+		t.Errorf("Unexpected batch sizes %d %d %d", len(repo.batches[0]), len(repo.batches[1]), len(repo.batches[2]))
+	}
+
+	// Events stored after stopping are ignored
+	queue.StoreEvent("123", &ChannelEvent{ChannelID: "321", ID: 8})
+}
+
+func TestStorageInsertQueueTimeout(t *testing.T) {
+	saveInsertSettings(t)
+	CacheLimit = 100
+	CacheTimeout = 10 * time.Millisecond
+
+	repo := &insertRepository{}
+	queue := NewStorageInsertQueue()
+
+	go queue.Start(repo)
+
+	queue.StoreEvent("123", &ChannelEvent{ChannelID: "321", ID: 1})
+
+	time.Sleep(100 * time.Millisecond)
+
+	repo.lock.Lock()
+	batches := len(repo.batches)
+	repo.lock.Unlock()
+
+	if batches != 1 {
+		t.Errorf("Expected batch to be inserted after timeout, got %d batches", batches)
+	}
+
+	_ = queue.Stop(context.Background())
+}
+
+func TestStorageInsertQueueRetry(t *testing.T) {
+	saveInsertSettings(t)
+	CacheLimit = 2
+	CacheTimeout = time.Hour
+	InsertRetries = 2
+
+	repo := &insertRepository{failFirst: 2}
+	queue := NewStorageInsertQueue()
+
+	go queue.Start(repo)
+
+	queue.StoreEvent("123", &ChannelEvent{ChannelID: "321", ID: 1})
+	queue.StoreEvent("123", &ChannelEvent{ChannelID: "321", ID: 2})
+
+	time.Sleep(50 * time.Millisecond)
+
+	if err := queue.Stop(context.Background()); err != nil {
+		t.Fatalf("Failed to stop queue: %v", err)
+	}
+
+	// After all attempts failed events are inserted one by one
+	if len(repo.batches) != 0 || len(repo.single) != 2 {
+		t.Errorf("Expected 2 single inserts, got %d batches and %d single inserts", len(repo.batches), len(repo.single))
+	}
+}
+
+func TestStorageInsertQueueChangesAfterInsert(t *testing.T) {
+	saveInsertSettings(t)
+	CacheLimit = 100
+	CacheTimeout = time.Hour
+
+	repo := &insertRepository{}
+	queue := NewStorageInsertQueue()
+
+	go queue.Start(repo)
+
+	queue.StoreEvent("123", &ChannelEvent{ChannelID: "321", ID: 1})
+	queue.StoreEvent("123", &ChannelEvent{ChannelID: "321", ID: 2})
+	queue.EditEvent("123", &ChannelEvent{ChannelID: "321", ID: 1, Payload: "edited"})
+	queue.DeleteEvent("123", "321", 2)
+
+	time.Sleep(50 * time.Millisecond)
+
+	repo.lock.Lock()
+	batches := len(repo.batches)
+	changes := len(repo.changes)
+	repo.lock.Unlock()
+
+	// The pending batch is inserted before the edit, without waiting for the timeout
+	if batches != 1 || changes != 2 {
+		t.Fatalf("Expected 1 batch and 2 changes, got %d batches and %d changes", batches, changes)
+	}
+
+	if repo.changes[0].Kind != InsertItemEdit || repo.changes[0].Event.Payload != "edited" || repo.changes[1].Kind != InsertItemDelete || repo.changes[1].Event.ID != 2 {
+		t.Errorf("Unexpected changes %v %v", repo.changes[0], repo.changes[1])
+	}
+
+	_ = queue.Stop(context.Background())
+}
+
+func TestStorageInsertQueueChannelOrder(t *testing.T) {
+	saveInsertSettings(t)
+	CacheLimit = 100
+	CacheTimeout = time.Hour
+	defer func(workers int) { InsertWorkers = workers }(InsertWorkers)
+	InsertWorkers = 4
+
+	repo := &insertRepository{}
+	queue := NewStorageInsertQueue()
+
+	// Starting it again doesn't add workers
+	for i := 0; i < 4; i++ {
+		go queue.Start(repo)
+	}
+
+	for i := 0; i < 20; i++ {
+		channelID := strconv.Itoa(i)
+
+		queue.StoreEvent("123", &ChannelEvent{ChannelID: channelID, ID: 1})
+		queue.EditEvent("123", &ChannelEvent{ChannelID: channelID, ID: 1, Payload: "edited"})
+		queue.DeleteEvent("123", channelID, 1)
+	}
+
+	// Give the workers time to start before stopping
+	time.Sleep(50 * time.Millisecond)
+
+	if err := queue.Stop(context.Background()); err != nil {
+		t.Fatalf("Failed to stop queue: %v", err)
+	}
+
+	if len(repo.changes) != 40 || repo.missing != 0 {
+		t.Errorf("Expected 40 changes after their inserts, got %d changes and %d before the insert", len(repo.changes), repo.missing)
+	}
+}
+
+func TestStorageInsertQueueLastQueuedEventID(t *testing.T) {
+	saveInsertSettings(t)
+	CacheLimit = 100
+	CacheTimeout = time.Hour
+
+	repo := &insertRepository{}
+	queue := NewStorageInsertQueue()
+
+	queue.StoreEvent("123", &ChannelEvent{ChannelID: "321", ID: 4})
+	queue.StoreEvent("123", &ChannelEvent{ChannelID: "321", ID: 5})
+	queue.DeleteEvent("123", "321", 6)
+
+	if queue.LastQueuedEventID("123", "321") != 5 || queue.LastQueuedEventID("123", "other") != 0 {
+		t.Errorf("Expected last queued event ID 5, got %d", queue.LastQueuedEventID("123", "321"))
+	}
+
+	go queue.Start(repo)
+
+	// Give the worker time to start before stopping
+	time.Sleep(50 * time.Millisecond)
+
+	if err := queue.Stop(context.Background()); err != nil {
+		t.Fatalf("Failed to stop queue: %v", err)
+	}
+
+	if queue.LastQueuedEventID("123", "321") != 0 {
+		t.Error("Expected inserted events to be forgotten")
+	}
+}
+
+func TestStorageInsertQueueStopBeforeStart(t *testing.T) {
+	if err := NewStorageInsertQueue().Stop(context.Background()); err != nil {
+		t.Errorf("Expected an empty queue to stop, got %v", err)
+	}
+
+	queue := NewStorageInsertQueue()
+	queue.StoreEvent("123", &ChannelEvent{ChannelID: "321", ID: 1})
+
+	if err := queue.Stop(context.Background()); !errors.Is(err, ErrInsertQueueNotStarted) {
+		t.Errorf("Expected queued events to be reported, got %v", err)
+	}
+}
@@ -0,0 +1,344 @@
+// This package holds a minimal metrics registry exposed in the Prometheus text format
+package metrics
+
+import (
+	"bytes"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/atomic"
+)
+
+// Metric - Anything that can be written in the Prometheus text format
+type Metric interface {
+	Name() string
+	Write(buffer *bytes.Buffer)
+}
+
+var (
+	registryLock sync.RWMutex
+	registry     = make(map[string]Metric)
+)
+
+// Register - Add metric to the registry, a metric with the same name is replaced
+func Register(metric Metric) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	registry[metric.Name()] = metric
+}
+
+// WriteAll - Write all registered metrics sorted by name
+func WriteAll(buffer *bytes.Buffer) {
+	registryLock.RLock()
+
+	metrics := make([]Metric, 0, len(registry))
+
+	for _, metric := range registry {
+		metrics = append(metrics, metric)
+	}
+
+	registryLock.RUnlock()
+
+	sort.Slice(metrics, func(i, j int) bool {
+		return metrics[i].Name() < metrics[j].Name()
+	})
+
+	for _, metric := range metrics {
+		metric.Write(buffer)
+	}
+}
+
+// Handler - Expose registered metrics to Prometheus
+// GET /metrics
+func Handler(context *gin.Context) {
+	var buffer bytes.Buffer
+
+	WriteAll(&buffer)
+
+	context.Data(http.StatusOK, "text/plain; version=0.0.4; charset=utf-8", buffer.Bytes())
+}
+
+// Sample - Value of a metric with the given label values
+type Sample struct {
+	LabelValues []string
+	Value       float64
+}
+
+// CounterVec - Counter partitioned by labels
+type CounterVec struct {
+	name   string
+	help   string
+	labels []string
+	lock   sync.RWMutex
+	values map[string]*counterValue
+}
+
+type counterValue struct {
+	labelValues []string
+	value       atomic.Uint64
+}
+
+// NewCounterVec - Create and register a counter with the given labels
+func NewCounterVec(name string, help string, labels ...string) *CounterVec {
+	counter := &CounterVec{
+		name:   name,
+		help:   help,
+		labels: labels,
+		values: make(map[string]*counterValue),
+	}
+
+	Register(counter)
+
+	return counter
+}
+
+func (counter *CounterVec) Name() string {
+	return counter.name
+}
+
+// Inc - Increment the counter with the given label values by one
+func (counter *CounterVec) Inc(labelValues ...string) {
+	counter.Add(1, labelValues...)
+}
+
+// Add - Increment the counter with the given label values
+func (counter *CounterVec) Add(delta uint64, labelValues ...string) {
+	key := strings.Join(labelValues, "\xff")
+
+	counter.lock.RLock()
+	value, isOK := counter.values[key]
+	counter.lock.RUnlock()
+
+	if !isOK {
+		counter.lock.Lock()
+
+		if value, isOK = counter.values[key]; !isOK {
+			value = &counterValue{labelValues: labelValues}
+			counter.values[key] = value
+		}
+
+		counter.lock.Unlock()
+	}
+
+	value.value.Add(delta)
+}
+
+// Get - Get the current value of the counter with the given label values
+func (counter *CounterVec) Get(labelValues ...string) uint64 {
+	counter.lock.RLock()
+	defer counter.lock.RUnlock()
+
+	if value, isOK := counter.values[strings.Join(labelValues, "\xff")]; isOK {
+		return value.value.Load()
+	}
+
+	return 0
+}
+
+func (counter *CounterVec) Write(buffer *bytes.Buffer) {
+	counter.lock.RLock()
+
+	samples := make([]Sample, 0, len(counter.values))
+
+	for _, value := range counter.values {
+		samples = append(samples, Sample{
+			LabelValues: value.labelValues,
+			Value:       float64(value.value.Load()),
+		})
+	}
+
+	counter.lock.RUnlock()
+
+	writeHeader(buffer, counter.name, counter.help, "counter")
+	writeSamples(buffer, counter.name, counter.labels, samples)
+}
+
+// GaugeFunc - Gauge whose values are collected when metrics are written
+type GaugeFunc struct {
+	name    string
+	help    string
+	labels  []string
+	collect func() []Sample
+}
+
+// NewGaugeFunc - Create and register a gauge without labels
+func NewGaugeFunc(name string, help string, collect func() float64) *GaugeFunc {
+	return NewGaugeVecFunc(name, help, nil, func() []Sample {
+		return []Sample{{Value: collect()}}
+	})
+}
+
+// NewGaugeVecFunc - Create and register a gauge with the given labels
+func NewGaugeVecFunc(name string, help string, labels []string, collect func() []Sample) *GaugeFunc {
+	gauge := &GaugeFunc{
+		name:    name,
+		help:    help,
+		labels:  labels,
+		collect: collect,
+	}
+
+	Register(gauge)
+
+	return gauge
+}
+
+func (gauge *GaugeFunc) Name() string {
+	return gauge.name
+}
+
+func (gauge *GaugeFunc) Write(buffer *bytes.Buffer) {
+	writeHeader(buffer, gauge.name, gauge.help, "gauge")
+	writeSamples(buffer, gauge.name, gauge.labels, gauge.collect())
+}
+
+// DefaultBuckets - Histogram buckets in seconds, from 5ms to 10s
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Histogram - Counts observed values in buckets
+type Histogram struct {
+	name    string
+	help    string
+	buckets []float64
+	lock    sync.Mutex
+	counts  []uint64
+	sum     float64
+	count   uint64
+}
+
+// NewHistogram - Create and register a histogram with the given upper bounds
+func NewHistogram(name string, help string, buckets []float64) *Histogram {
+	histogram := &Histogram{
+		name:    name,
+		help:    help,
+		buckets: buckets,
+		counts:  make([]uint64, len(buckets)),
+	}
+
+	Register(histogram)
+
+	return histogram
+}
+
+func (histogram *Histogram) Name() string {
+	return histogram.name
+}
+
+// Observe - Add value to the histogram
+func (histogram *Histogram) Observe(value float64) {
+	histogram.lock.Lock()
+	defer histogram.lock.Unlock()
+
+	for index, bound := range histogram.buckets {
+		if value <= bound {
+			histogram.counts[index]++
+		}
+	}
+
+	histogram.sum += value
+	histogram.count++
+}
+
+// ObserveSince - Add the seconds elapsed since start to the histogram
+func (histogram *Histogram) ObserveSince(start time.Time) {
+	histogram.Observe(time.Since(start).Seconds())
+}
+
+func (histogram *Histogram) Write(buffer *bytes.Buffer) {
+	histogram.lock.Lock()
+
+	counts := make([]uint64, len(histogram.counts))
+	copy(counts, histogram.counts)
+	sum := histogram.sum
+	count := histogram.count
+
+	histogram.lock.Unlock()
+
+	writeHeader(buffer, histogram.name, histogram.help, "histogram")
+
+	labels := []string{"le"}
+
+	for index, bound := range histogram.buckets {
+		writeSamples(buffer, histogram.name+"_bucket", labels, []Sample{{
+			LabelValues: []string{formatValue(bound)},
+			Value:       float64(counts[index]),
+		}})
+	}
+
+	writeSamples(buffer, histogram.name+"_bucket", labels, []Sample{{
+		LabelValues: []string{"+Inf"},
+		Value:       float64(count),
+	}})
+	writeSamples(buffer, histogram.name+"_sum", nil, []Sample{{Value: sum}})
+	writeSamples(buffer, histogram.name+"_count", nil, []Sample{{Value: float64(count)}})
+}
+
+func writeHeader(buffer *bytes.Buffer, name string, help string, metricType string) {
+	buffer.WriteString("# HELP ")
+	buffer.WriteString(name)
+	buffer.WriteByte(' ')
+	buffer.WriteString(strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help))
+	buffer.WriteString("\n# TYPE ")
+	buffer.WriteString(name)
+	buffer.WriteByte(' ')
+	buffer.WriteString(metricType)
+	buffer.WriteByte('\n')
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+func writeSamples(buffer *bytes.Buffer, name string, labels []string, samples []Sample) {
+	sort.Slice(samples, func(i, j int) bool {
+		return strings.Join(samples[i].LabelValues, "\xff") < strings.Join(samples[j].LabelValues, "\xff")
+	})
+
+	for _, sample := range samples {
+		buffer.WriteString(name)
+
+		if len(labels) != 0 {
+			buffer.WriteByte('{')
+
+			for index, label := range labels {
+				if index != 0 {
+					buffer.WriteByte(',')
+				}
+
+				value := ""
+
+				if index < len(sample.LabelValues) {
+					value = sample.LabelValues[index]
+				}
+
+				buffer.WriteString(label)
+				buffer.WriteString(`="`)
+				buffer.WriteString(labelValueReplacer.Replace(value))
+				buffer.WriteByte('"')
+			}
+
+			buffer.WriteByte('}')
+		}
+
+		buffer.WriteByte(' ')
+		buffer.WriteString(formatValue(sample.Value))
+		buffer.WriteByte('\n')
+	}
+}
+
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCounterVecWrite(t *testing.T) {
+	counter := NewCounterVec("test_events_total", "Test events", "app_id")
+
+	counter.Inc("b")
+	counter.Add(2, "a")
+	counter.Inc(`quote"d`)
+
+	var buffer bytes.Buffer
+	counter.Write(&buffer)
+
+	expected := `# HELP test_events_total Test events
+# TYPE test_events_total counter
+test_events_total{app_id="a"} 2
+test_events_total{app_id="b"} 1
+test_events_total{app_id="quote\"d"} 1
+`
+
+	if buffer.String() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, buffer.String())
+	}
+}
+
+func TestHistogramWrite(t *testing.T) {
+	histogram := NewHistogram("test_duration_seconds", "Test duration", []float64{0.1, 1})
+
+	histogram.Observe(0.05)
+	histogram.Observe(0.5)
+	histogram.Observe(2)
+
+	var buffer bytes.Buffer
+	histogram.Write(&buffer)
+
+	expected := `# HELP test_duration_seconds Test duration
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{le="0.1"} 1
+test_duration_seconds_bucket{le="1"} 2
+test_duration_seconds_bucket{le="+Inf"} 3
+test_duration_seconds_sum 2.55
+test_duration_seconds_count 3
+`
+
+	if buffer.String() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, buffer.String())
+	}
+}
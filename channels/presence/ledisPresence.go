@@ -2,10 +2,9 @@
 package presence
 
 import (
-	"fmt"
 	lediscfg "github.com/ledisdb/ledisdb/config"
 	"github.com/ledisdb/ledisdb/ledis"
-	"os"
+	log "github.com/sirupsen/logrus"
 	"strconv"
 	"strings"
 	"time"
@@ -23,7 +22,7 @@ func (presence *LedisPresence) UpdateClientTimestamp(clientID string) {
 	err := presence.client.Set(key, []byte(strconv.FormatInt(time.Now().Unix(), 10)))
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "LedisPresence: failed to get update client last heartbeat %v\n", err)
+		core.Logger().WithField("ClientID", clientID).WithError(err).Error("LedisPresence: failed to get update client last heartbeat")
 	}
 
 	_, _ = presence.client.ExpireAt(key, int64((time.Minute * 1).Seconds()))
@@ -33,14 +32,14 @@ func (presence *LedisPresence) GetClientTimestamp(clientID string) int64 {
 
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "LedisPresence: failed to get get client last heartbeast %v\n", err)
+		core.Logger().WithField("ClientID", clientID).WithError(err).Error("LedisPresence: failed to get get client last heartbeast")
 		return 0
 	}
 
 	timestamp, err := strconv.ParseInt(string(data), 10, 64)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "LedisPresence: failed to convert timestamp %v\n", err)
+		core.Logger().WithField("ClientID", clientID).WithError(err).Error("LedisPresence: failed to convert timestamp")
 		return 0
 	}
 
@@ -52,7 +51,7 @@ func (presence *LedisPresence) GetChannelClientsPresence(appID string, channelID
 	devicesPresences, err := presence.client.HGetAll([]byte(appID+":channel:"+channelID+":presence"))
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "LedisPresence: failed to get channel presences result %v\n", err)
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("LedisPresence: failed to get channel presences result")
 		return nil
 	}
 
@@ -70,7 +69,7 @@ func (presence *LedisPresence) GetChannelClientsPresence(appID string, channelID
 		timestamp, err := strconv.ParseInt(string(pair.Value), 10, 64)
 
 		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "LedisPresence: failed to convert timestamp %v\n", err)
+			core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "ClientID": clientID}).WithError(err).Error("LedisPresence: failed to convert timestamp")
 		}
 
 		if val, ok := lastClientPresences[clientID]; ok {
@@ -92,7 +91,7 @@ func (presence *LedisPresence) IsClientDeviceConnectToChannel(appID string, chan
 	result, err := presence.client.HGet([]byte(appID+":channel:"+channelID+":presence"), []byte(clientID+":"+deviceID))
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "LedisPresence: failed to check device is online in channel result %v\n", err)
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "ClientID": clientID, "DeviceID": deviceID}).WithError(err).Error("LedisPresence: failed to check device is online in channel result")
 		return false
 	}
 
@@ -104,7 +103,7 @@ func (presence *LedisPresence) AddOnlineChannelDevice(appID string, channelID st
 	_, err := presence.client.HSet( []byte(appID+":channel:"+channelID+":presence"), []byte(clientID+":"+deviceID), []byte(strconv.FormatInt(time.Now().Unix(), 10)))
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "LedisPresence: failed to add device to online channel %v\n", err)
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "ClientID": clientID, "DeviceID": deviceID}).WithError(err).Error("LedisPresence: failed to add device to online channel")
 		return
 	}
 }
@@ -115,7 +114,7 @@ func (presence *LedisPresence) RemoveOnlineChannelDevice(appID string, channelID
 	_, err := presence.client.HDel([]byte(appID+":channel:"+channelID+":presence"), []byte(clientID+":"+deviceID))
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "LedisPresence: failed to remove device from online channel %v\n", err)
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "ClientID": clientID, "DeviceID": deviceID}).WithError(err).Error("LedisPresence: failed to remove device from online channel")
 		return
 	}
 }
@@ -126,7 +125,7 @@ func (presence *LedisPresence) GetChannelAmountOfClientDevices(appID string, cha
 	pairs, err := presence.client.HScan([]byte(appID+":channel:"+channelID+":presence"), []byte{}, 0, true, clientID+":*")
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "LedisPresence: failed to get client devices connected to channel result %v\n", err)
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "ClientID": clientID}).WithError(err).Error("LedisPresence: failed to get client devices connected to channel result")
 		return 0
 	}
 
@@ -138,7 +137,7 @@ func (presence *LedisPresence) SetDeviceOnline(clientID string, deviceID string)
 	_, err := presence.client.SAdd([]byte(formatKeyOnline(clientID)), []byte(deviceID))
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "LedisPresence: failed to set client device online status %v\n", err)
+		core.Logger().WithFields(log.Fields{"ClientID": clientID, "DeviceID": deviceID}).WithError(err).Error("LedisPresence: failed to set client device online status")
 		return
 	}
 }
@@ -148,7 +147,7 @@ func (presence *LedisPresence) SetDeviceOffline(clientID string, deviceID string
 	_, err := presence.client.SRem([]byte(formatKeyOnline(clientID)), []byte(deviceID))
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "LedisPresence: failed to set client device online status %v\n", err)
+		core.Logger().WithFields(log.Fields{"ClientID": clientID, "DeviceID": deviceID}).WithError(err).Error("LedisPresence: failed to set client device online status")
 		return
 	}
 }
@@ -158,7 +157,7 @@ func (presence *LedisPresence) UpdateDeviceTimestamp(clientID string, deviceID s
 	_, err := presence.client.HSet([]byte(formatKeyPresence(clientID)), []byte(deviceID), []byte(strconv.FormatInt(time.Now().Unix(), 10)))
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "LedisPresence: failed to update client device online status %v\n", err)
+		core.Logger().WithFields(log.Fields{"ClientID": clientID, "DeviceID": deviceID}).WithError(err).Error("LedisPresence: failed to update client device online status")
 		return
 	}
 }
@@ -168,7 +167,7 @@ func (presence *LedisPresence) GetClientDevicesPresences(clientID string) ([]*co
 	result, err := presence.client.HGetAll([]byte(formatKeyPresence(clientID)))
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "LedisPresence: failed to retrieve client devices with timestamps result %v\n", err)
+		core.Logger().WithField("ClientID", clientID).WithError(err).Error("LedisPresence: failed to retrieve client devices with timestamps result")
 		return nil, err
 	}
 
@@ -179,7 +178,7 @@ func (presence *LedisPresence) GetClientDevicesPresences(clientID string) ([]*co
 		timestamp, err := strconv.ParseInt(string(keyValue.Value), 10, 64)
 
 		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "LedisPresence: failed to parse timestamp %v\n", err)
+			core.Logger().WithField("ClientID", clientID).WithError(err).Error("LedisPresence: failed to parse timestamp")
 			return nil, err
 		}
 
@@ -198,7 +197,7 @@ func (presence *LedisPresence) GetClientOnlineDevices(clientID string) ([]string
 	result, err := presence.client.SMembers([]byte(formatKeyOnline(clientID)))
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "LedisPresence: failed to retrieve client devices result %v\n", err)
+		core.Logger().WithField("ClientID", clientID).WithError(err).Error("LedisPresence: failed to retrieve client devices result")
 	}
 
 	devices := make([]string, len(result))
@@ -215,7 +214,7 @@ func (presence *LedisPresence) RemoveDevice(clientID string, deviceID string) {
 	_, err := presence.client.HDel([]byte(formatKeyPresence(clientID)), []byte(deviceID))
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "LedisPresence: failed to remove client device %v\n", err)
+		core.Logger().WithFields(log.Fields{"ClientID": clientID, "DeviceID": deviceID}).WithError(err).Error("LedisPresence: failed to remove client device")
 		return
 	}
 }
@@ -225,7 +224,7 @@ func (presence *LedisPresence) AddDevice(clientID string, deviceID string) {
 	_, err := presence.client.HSet([]byte(formatKeyPresence(clientID)), []byte(deviceID), []byte(strconv.FormatInt(time.Now().Unix(), 10)))
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "LedisPresence: failed to add client device %v\n", err)
+		core.Logger().WithFields(log.Fields{"ClientID": clientID, "DeviceID": deviceID}).WithError(err).Error("LedisPresence: failed to add client device")
 		return
 	}
 }
@@ -235,7 +234,7 @@ func (presence *LedisPresence) IsOnline(clientID string) bool {
 	result, err := presence.client.HLen([]byte(formatKeyPresence(clientID)))
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "LedisPresence: failed to retrieve client online presence result %v\n", err)
+		core.Logger().WithField("ClientID", clientID).WithError(err).Error("LedisPresence: failed to retrieve client online presence result")
 	}
 
 	return result > 0
@@ -3,20 +3,20 @@ package presence
 
 import (
 	"context"
-	"fmt"
-	"os"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/lisomatrix/channels/channels/core"
+	"github.com/lisomatrix/channels/channels/redisconfig"
 
 	"github.com/go-redis/redis/v8"
+	log "github.com/sirupsen/logrus"
 )
 
 // RedisPresence - Redis implementation of PresenceHandler
 type RedisPresence struct {
-	client *redis.Client
+	client redis.UniversalClient
 	ctx    context.Context
 }
 
@@ -31,7 +31,7 @@ func (presence *RedisPresence) UpdateClientTimestamp(clientID string) {
 	cmd := presence.client.Set(presence.ctx, key, time.Now().Unix(), time.Minute * 3)
 
 	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "LedisPresence: failed to get update client last heartbeat %v\n", cmd.Err())
+		core.Logger().WithField("ClientID", clientID).WithError(cmd.Err()).Error("LedisPresence: failed to get update client last heartbeat")
 	}
 }
 func (presence *RedisPresence) GetClientTimestamp(clientID string) int64 {
@@ -40,14 +40,14 @@ func (presence *RedisPresence) GetClientTimestamp(clientID string) int64 {
 	data, err := cmd.Result()
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "LedisPresence: failed to get get client last heartbeast %v\n", err)
+		core.Logger().WithField("ClientID", clientID).WithError(err).Error("LedisPresence: failed to get get client last heartbeast")
 		return 0
 	}
 
 	timestamp, err := strconv.ParseInt(data, 10, 64)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "LedisPresence: failed to convert timestamp %v\n", err)
+		core.Logger().WithField("ClientID", clientID).WithError(err).Error("LedisPresence: failed to convert timestamp")
 		return 0
 	}
 
@@ -59,14 +59,14 @@ func (presence *RedisPresence) GetChannelClientsPresence(appID string, channelID
 	cmd := presence.client.HGetAll(presence.ctx, appID+":channel:"+channelID+":presence")
 
 	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "RedisPresence: failed to get channel presences %v\n", cmd.Err())
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(cmd.Err()).Error("RedisPresence: failed to get channel presences")
 		return nil
 	}
 
 	devicesPresences, err := cmd.Result()
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "RedisPresence: failed to get channel presences result %v\n", cmd.Err())
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(cmd.Err()).Error("RedisPresence: failed to get channel presences result")
 		return nil
 	}
 
@@ -80,7 +80,7 @@ func (presence *RedisPresence) GetChannelClientsPresence(appID string, channelID
 		timestamp, err := strconv.ParseInt(timestampStr, 10, 64)
 
 		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "RedisPresence: failed to convert timestamp %v\n", err)
+			core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "ClientID": clientID}).WithError(err).Error("RedisPresence: failed to convert timestamp")
 		}
 
 		if val, ok := lastClientPresences[clientID]; ok {
@@ -103,14 +103,14 @@ func (presence *RedisPresence) IsClientDeviceConnectToChannel(appID string, chan
 	cmd := presence.client.HExists(presence.ctx, appID+":channel:"+channelID+":presence", clientID+":"+deviceID)
 
 	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "RedisPresence: failed to check device is online in channel %v\n", cmd.Err())
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "ClientID": clientID, "DeviceID": deviceID}).WithError(cmd.Err()).Error("RedisPresence: failed to check device is online in channel")
 		return false
 	}
 
 	result, err := cmd.Result()
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "RedisPresence: failed to check device is online in channel result %v\n", cmd.Err())
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "ClientID": clientID, "DeviceID": deviceID}).WithError(cmd.Err()).Error("RedisPresence: failed to check device is online in channel result")
 		return false
 	}
 
@@ -123,7 +123,7 @@ func (presence *RedisPresence) AddOnlineChannelDevice(appID string, channelID st
 	cmd := presence.client.HSet(presence.ctx, appID+":channel:"+channelID+":presence", clientID+":"+deviceID, time.Now().Unix())
 
 	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "RedisPresence: failed to add device to online channel %v\n", cmd.Err())
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "ClientID": clientID, "DeviceID": deviceID}).WithError(cmd.Err()).Error("RedisPresence: failed to add device to online channel")
 		return
 	}
 }
@@ -134,7 +134,7 @@ func (presence *RedisPresence) RemoveOnlineChannelDevice(appID string, channelID
 	cmd := presence.client.HDel(presence.ctx, appID+":channel:"+channelID+":presence", clientID+":"+deviceID)
 
 	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "RedisPresence: failed to remove device from online channel %v\n", cmd.Err())
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "ClientID": clientID, "DeviceID": deviceID}).WithError(cmd.Err()).Error("RedisPresence: failed to remove device from online channel")
 		return
 	}
 }
@@ -146,14 +146,14 @@ func (presence *RedisPresence) GetChannelAmountOfClientDevices(appID string, cha
 	cmd := presence.client.HScan(presence.ctx, key, 0, clientID+":*", 0)
 
 	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "RedisPresence: failed to get client devices connected to channel %v\n", cmd.Err())
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "ClientID": clientID}).WithError(cmd.Err()).Error("RedisPresence: failed to get client devices connected to channel")
 		return 0
 	}
 
 	result, _, err := cmd.Result()
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "RedisPresence: failed to get client devices connected to channel result %v\n", err)
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "ClientID": clientID}).WithError(err).Error("RedisPresence: failed to get client devices connected to channel result")
 		return 0
 	}
 
@@ -173,7 +173,7 @@ func (presence *RedisPresence) GetChannelAmountOfClientDevices(appID string, cha
 		timestamp, err := strconv.ParseInt(value, 10, 64)
 
 		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "RedisPresence: failed to parse timestamp %v\n", cmd.Err())
+			core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID, "ClientID": clientID}).WithError(cmd.Err()).Error("RedisPresence: failed to parse timestamp")
 			return 0
 		}
 		lastTimestamp := time.Unix(timestamp, 0)
@@ -201,7 +201,7 @@ func (presence *RedisPresence) SetDeviceOnline(clientID string, deviceID string)
 	cmd := presence.client.SAdd(presence.ctx, formatKeyOnline(clientID), deviceID)
 
 	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "RedisPresence: failed to set client device online status %v\n", cmd.Err())
+		core.Logger().WithFields(log.Fields{"ClientID": clientID, "DeviceID": deviceID}).WithError(cmd.Err()).Error("RedisPresence: failed to set client device online status")
 		return
 	}
 }
@@ -211,7 +211,7 @@ func (presence *RedisPresence) SetDeviceOffline(clientID string, deviceID string
 	cmd := presence.client.SRem(presence.ctx, formatKeyOnline(clientID), deviceID)
 
 	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "RedisPresence: failed to set client device online status %v\n", cmd.Err())
+		core.Logger().WithFields(log.Fields{"ClientID": clientID, "DeviceID": deviceID}).WithError(cmd.Err()).Error("RedisPresence: failed to set client device online status")
 		return
 	}
 }
@@ -221,7 +221,7 @@ func (presence *RedisPresence) UpdateDeviceTimestamp(clientID string, deviceID s
 	cmd := presence.client.HSet(presence.ctx, formatKeyPresence(clientID), deviceID, time.Now().Unix())
 
 	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "RedisPresence: failed to update client device online status %v\n", cmd.Err())
+		core.Logger().WithFields(log.Fields{"ClientID": clientID, "DeviceID": deviceID}).WithError(cmd.Err()).Error("RedisPresence: failed to update client device online status")
 		return
 	}
 }
@@ -231,14 +231,14 @@ func (presence *RedisPresence) GetClientDevicesPresences(clientID string) ([]*co
 	cmd := presence.client.HGetAll(presence.ctx, formatKeyPresence(clientID))
 
 	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "RedisPresence: failed to retrieve client devices with timestamps %v\n", cmd.Err())
+		core.Logger().WithField("ClientID", clientID).WithError(cmd.Err()).Error("RedisPresence: failed to retrieve client devices with timestamps")
 		return nil, cmd.Err()
 	}
 
 	result, err := cmd.Result()
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "RedisPresence: failed to retrieve client devices with timestamps result %v\n", cmd.Err())
+		core.Logger().WithField("ClientID", clientID).WithError(cmd.Err()).Error("RedisPresence: failed to retrieve client devices with timestamps result")
 		return nil, err
 	}
 
@@ -249,7 +249,7 @@ func (presence *RedisPresence) GetClientDevicesPresences(clientID string) ([]*co
 		timestamp, err := strconv.ParseInt(value, 10, 64)
 
 		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "RedisPresence: failed to parse timestamp %v\n", cmd.Err())
+			core.Logger().WithField("ClientID", clientID).WithError(cmd.Err()).Error("RedisPresence: failed to parse timestamp")
 			return nil, cmd.Err()
 		}
 
@@ -268,14 +268,14 @@ func (presence *RedisPresence) GetClientOnlineDevices(clientID string) ([]string
 	cmd := presence.client.SMembers(presence.ctx, formatKeyOnline(clientID))
 
 	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "RedisPresence: failed to retrieve client devices %v\n", cmd.Err())
+		core.Logger().WithField("ClientID", clientID).WithError(cmd.Err()).Error("RedisPresence: failed to retrieve client devices")
 		return nil, cmd.Err()
 	}
 
 	result, err := cmd.Result()
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "RedisPresence: failed to retrieve client devices result %v\n", cmd.Err())
+		core.Logger().WithField("ClientID", clientID).WithError(cmd.Err()).Error("RedisPresence: failed to retrieve client devices result")
 	}
 
 	return result, nil
@@ -286,7 +286,7 @@ func (presence *RedisPresence) RemoveDevice(clientID string, deviceID string) {
 	cmd := presence.client.HDel(presence.ctx, formatKeyPresence(clientID), deviceID)
 
 	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "RedisPresence: failed to remove client device %v\n", cmd.Err())
+		core.Logger().WithFields(log.Fields{"ClientID": clientID, "DeviceID": deviceID}).WithError(cmd.Err()).Error("RedisPresence: failed to remove client device")
 		return
 	}
 }
@@ -296,7 +296,7 @@ func (presence *RedisPresence) AddDevice(clientID string, deviceID string) {
 	cmd := presence.client.HSet(presence.ctx, formatKeyPresence(clientID), deviceID, time.Now().Unix())
 
 	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "RedisPresence: failed to add client device %v\n", cmd.Err())
+		core.Logger().WithFields(log.Fields{"ClientID": clientID, "DeviceID": deviceID}).WithError(cmd.Err()).Error("RedisPresence: failed to add client device")
 		return
 	}
 }
@@ -306,14 +306,14 @@ func (presence *RedisPresence) IsOnline(clientID string) bool {
 	cmd := presence.client.HLen(presence.ctx, formatKeyPresence(clientID))
 
 	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "RedisPresence: failed to verify client online presence %v\n", cmd.Err())
+		core.Logger().WithField("ClientID", clientID).WithError(cmd.Err()).Error("RedisPresence: failed to verify client online presence")
 		return false
 	}
 
 	result, err := cmd.Result()
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "RedisPresence: failed to retrieve client online presence result %v\n", cmd.Err())
+		core.Logger().WithField("ClientID", clientID).WithError(cmd.Err()).Error("RedisPresence: failed to retrieve client online presence result")
 	}
 
 	return result > 0
@@ -331,18 +331,23 @@ func formatKeyPresence(clientID string) string {
 	return "client" + ":" + clientID + ":presence"
 }
 
-// NewRedisPresence - Create new instance of RedisPresence
-func NewRedisPresence() *RedisPresence {
+// NewRedisPresence - Create new instance of RedisPresence connected with the given settings, use redisconfig.Default() for a local node
+func NewRedisPresence(config redisconfig.Config) (*RedisPresence, error) {
+	client, err := config.NewClient()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewRedisPresenceWithClient(client), nil
+}
+
+// NewRedisPresenceWithClient - Create new instance of RedisPresence with the given client
+func NewRedisPresenceWithClient(client redis.UniversalClient) *RedisPresence {
 	redisPresence := new(RedisPresence)
 
 	redisPresence.ctx = context.Background()
-
-	redisPresence.client = redis.NewClient(
-		&redis.Options{
-			Addr:     "127.0.0.1:6379",
-			DB:       0,
-			PoolSize: 5,
-		})
+	redisPresence.client = client
 
 	return redisPresence
 }
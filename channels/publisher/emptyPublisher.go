@@ -12,14 +12,42 @@ func (publisher *EmptyPublisher) PublishChannelAccessChange(appID string, channe
 
 }
 
+func (publisher *EmptyPublisher) PublishChannelRoleChange(appID string, channelID string, clientID string, role core.ChannelRole) {
+
+}
+
 func (publisher *EmptyPublisher) PublishChannelEvent(appID string, channelID string, channelEvent *core.ChannelEvent) {
 
 }
 
+func (publisher *EmptyPublisher) PublishChannelEventEdit(appID string, channelID string, channelEvent *core.ChannelEvent) {
+
+}
+
+func (publisher *EmptyPublisher) PublishChannelEventDelete(appID string, channelID string, eventID uint64) {
+
+}
+
+func (publisher *EmptyPublisher) PublishChannelRead(appID string, channelID string, receipt *core.ReadReceipt) {
+
+}
+
+func (publisher *EmptyPublisher) PublishChannelSignal(appID string, channelID string, signal *core.ChannelSignal) {
+
+}
+
+func (publisher *EmptyPublisher) PublishChannelUpdate(appID string, channel *core.Channel) {
+
+}
+
 func (publisher *EmptyPublisher) PublishChannelOnlineChange(appID string, channelID string, statusUpdate *core.OnlineStatusUpdate) {
 
 }
 
+func (publisher *EmptyPublisher) PublishClientDisconnect(appID string, clientID string, deviceID string) {
+
+}
+
 func (publisher *EmptyPublisher) Subscribe(appID string, channelID string) {
 
 }
@@ -25,10 +25,16 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 type ExternalNewEventType int32
 
 const (
-	ExternalNewEventType_OnlineStatus    ExternalNewEventType = 0
-	ExternalNewEventType_ChannelEvent    ExternalNewEventType = 1
-	ExternalNewEventType_ChannelPresence ExternalNewEventType = 2
-	ExternalNewEventType_ChannelAccess   ExternalNewEventType = 3
+	ExternalNewEventType_OnlineStatus       ExternalNewEventType = 0
+	ExternalNewEventType_ChannelEvent       ExternalNewEventType = 1
+	ExternalNewEventType_ChannelPresence    ExternalNewEventType = 2
+	ExternalNewEventType_ChannelAccess      ExternalNewEventType = 3
+	ExternalNewEventType_ChannelEventEdit   ExternalNewEventType = 4
+	ExternalNewEventType_ChannelEventDelete ExternalNewEventType = 5
+	ExternalNewEventType_ChannelRead        ExternalNewEventType = 6
+	ExternalNewEventType_ChannelSignal      ExternalNewEventType = 7
+	ExternalNewEventType_ChannelUpdate      ExternalNewEventType = 8
+	ExternalNewEventType_ClientDisconnect   ExternalNewEventType = 9
 )
 
 var ExternalNewEventType_name = map[int32]string{
@@ -36,13 +42,25 @@ var ExternalNewEventType_name = map[int32]string{
 	1: "ChannelEvent",
 	2: "ChannelPresence",
 	3: "ChannelAccess",
+	4: "ChannelEventEdit",
+	5: "ChannelEventDelete",
+	6: "ChannelRead",
+	7: "ChannelSignal",
+	8: "ChannelUpdate",
+	9: "ClientDisconnect",
 }
 
 var ExternalNewEventType_value = map[string]int32{
-	"OnlineStatus":    0,
-	"ChannelEvent":    1,
-	"ChannelPresence": 2,
-	"ChannelAccess":   3,
+	"OnlineStatus":       0,
+	"ChannelEvent":       1,
+	"ChannelPresence":    2,
+	"ChannelAccess":      3,
+	"ChannelEventEdit":   4,
+	"ChannelEventDelete": 5,
+	"ChannelRead":        6,
+	"ChannelSignal":      7,
+	"ChannelUpdate":      8,
+	"ClientDisconnect":   9,
 }
 
 func (x ExternalNewEventType) String() string {
@@ -83,16 +101,19 @@ type ExternalChannelAccessType int32
 const (
 	ExternalChannelAccessType_Add    ExternalChannelAccessType = 0
 	ExternalChannelAccessType_Remove ExternalChannelAccessType = 1
+	ExternalChannelAccessType_Role   ExternalChannelAccessType = 2
 )
 
 var ExternalChannelAccessType_name = map[int32]string{
 	0: "Add",
 	1: "Remove",
+	2: "Role",
 }
 
 var ExternalChannelAccessType_value = map[string]int32{
 	"Add":    0,
 	"Remove": 1,
+	"Role":   2,
 }
 
 func (x ExternalChannelAccessType) String() string {
@@ -107,6 +128,7 @@ type ExternalChannelAccessEvent struct {
 	ExternalAccessType   ExternalChannelAccessType `protobuf:"varint,1,opt,name=externalAccessType,proto3,enum=ExternalChannelAccessType" json:"externalAccessType,omitempty"`
 	ClientID             string                    `protobuf:"bytes,2,opt,name=clientID,proto3" json:"clientID,omitempty"`
 	ChannelID            string                    `protobuf:"bytes,3,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	Role                 string                    `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
 	XXX_unrecognized     []byte                    `json:"-"`
 	XXX_sizecache        int32                     `json:"-"`
@@ -166,11 +188,19 @@ func (m *ExternalChannelAccessEvent) GetChannelID() string {
 	return ""
 }
 
+func (m *ExternalChannelAccessEvent) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
 type ExternalPublishEvent struct {
 	SenderID             string   `protobuf:"bytes,1,opt,name=senderID,proto3" json:"senderID,omitempty"`
 	EventType            string   `protobuf:"bytes,2,opt,name=eventType,proto3" json:"eventType,omitempty"`
 	Payload              string   `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
 	Timestamp            int64    `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	ID                   uint64   `protobuf:"varint,5,opt,name=ID,proto3" json:"ID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -237,6 +267,13 @@ func (m *ExternalPublishEvent) GetTimestamp() int64 {
 	return 0
 }
 
+func (m *ExternalPublishEvent) GetID() uint64 {
+	if m != nil {
+		return m.ID
+	}
+	return 0
+}
+
 type ExternalOnlineStatusEvent struct {
 	ClientID             string   `protobuf:"bytes,1,opt,name=clientID,proto3" json:"clientID,omitempty"`
 	Status               bool     `protobuf:"varint,2,opt,name=status,proto3" json:"status,omitempty"`
@@ -363,23 +400,311 @@ func (m *ExternalJoinLeaveClientEvent) GetPresenceType() ExternalChannelPresence
 	return ExternalChannelPresenceType_Join
 }
 
+type ExternalReadEvent struct {
+	ClientID             string   `protobuf:"bytes,1,opt,name=clientID,proto3" json:"clientID,omitempty"`
+	EventID              uint64   `protobuf:"varint,2,opt,name=eventID,proto3" json:"eventID,omitempty"`
+	Timestamp            int64    `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExternalReadEvent) Reset()         { *m = ExternalReadEvent{} }
+func (m *ExternalReadEvent) String() string { return proto.CompactTextString(m) }
+func (*ExternalReadEvent) ProtoMessage()    {}
+func (*ExternalReadEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_34180b7635741fb2, []int{4}
+}
+func (m *ExternalReadEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExternalReadEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExternalReadEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ExternalReadEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExternalReadEvent.Merge(m, src)
+}
+func (m *ExternalReadEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExternalReadEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExternalReadEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExternalReadEvent proto.InternalMessageInfo
+
+func (m *ExternalReadEvent) GetClientID() string {
+	if m != nil {
+		return m.ClientID
+	}
+	return ""
+}
+
+func (m *ExternalReadEvent) GetEventID() uint64 {
+	if m != nil {
+		return m.EventID
+	}
+	return 0
+}
+
+func (m *ExternalReadEvent) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+type ExternalSignalEvent struct {
+	SenderID             string   `protobuf:"bytes,1,opt,name=senderID,proto3" json:"senderID,omitempty"`
+	SignalType           string   `protobuf:"bytes,2,opt,name=signalType,proto3" json:"signalType,omitempty"`
+	Payload              string   `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	Timestamp            int64    `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExternalSignalEvent) Reset()         { *m = ExternalSignalEvent{} }
+func (m *ExternalSignalEvent) String() string { return proto.CompactTextString(m) }
+func (*ExternalSignalEvent) ProtoMessage()    {}
+func (*ExternalSignalEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_34180b7635741fb2, []int{5}
+}
+func (m *ExternalSignalEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExternalSignalEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExternalSignalEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ExternalSignalEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExternalSignalEvent.Merge(m, src)
+}
+func (m *ExternalSignalEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExternalSignalEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExternalSignalEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExternalSignalEvent proto.InternalMessageInfo
+
+func (m *ExternalSignalEvent) GetSenderID() string {
+	if m != nil {
+		return m.SenderID
+	}
+	return ""
+}
+
+func (m *ExternalSignalEvent) GetSignalType() string {
+	if m != nil {
+		return m.SignalType
+	}
+	return ""
+}
+
+func (m *ExternalSignalEvent) GetPayload() string {
+	if m != nil {
+		return m.Payload
+	}
+	return ""
+}
+
+func (m *ExternalSignalEvent) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+type ExternalChannelUpdateEvent struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Extra                string   `protobuf:"bytes,2,opt,name=extra,proto3" json:"extra,omitempty"`
+	Persistent           bool     `protobuf:"varint,3,opt,name=persistent,proto3" json:"persistent,omitempty"`
+	Private              bool     `protobuf:"varint,4,opt,name=private,proto3" json:"private,omitempty"`
+	Presence             bool     `protobuf:"varint,5,opt,name=presence,proto3" json:"presence,omitempty"`
+	Push                 bool     `protobuf:"varint,6,opt,name=push,proto3" json:"push,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExternalChannelUpdateEvent) Reset()         { *m = ExternalChannelUpdateEvent{} }
+func (m *ExternalChannelUpdateEvent) String() string { return proto.CompactTextString(m) }
+func (*ExternalChannelUpdateEvent) ProtoMessage()    {}
+func (*ExternalChannelUpdateEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_34180b7635741fb2, []int{6}
+}
+func (m *ExternalChannelUpdateEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExternalChannelUpdateEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExternalChannelUpdateEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ExternalChannelUpdateEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExternalChannelUpdateEvent.Merge(m, src)
+}
+func (m *ExternalChannelUpdateEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExternalChannelUpdateEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExternalChannelUpdateEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExternalChannelUpdateEvent proto.InternalMessageInfo
+
+func (m *ExternalChannelUpdateEvent) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ExternalChannelUpdateEvent) GetExtra() string {
+	if m != nil {
+		return m.Extra
+	}
+	return ""
+}
+
+func (m *ExternalChannelUpdateEvent) GetPersistent() bool {
+	if m != nil {
+		return m.Persistent
+	}
+	return false
+}
+
+func (m *ExternalChannelUpdateEvent) GetPrivate() bool {
+	if m != nil {
+		return m.Private
+	}
+	return false
+}
+
+func (m *ExternalChannelUpdateEvent) GetPresence() bool {
+	if m != nil {
+		return m.Presence
+	}
+	return false
+}
+
+func (m *ExternalChannelUpdateEvent) GetPush() bool {
+	if m != nil {
+		return m.Push
+	}
+	return false
+}
+
+type ExternalDisconnectEvent struct {
+	AppID                string   `protobuf:"bytes,1,opt,name=appID,proto3" json:"appID,omitempty"`
+	ClientID             string   `protobuf:"bytes,2,opt,name=clientID,proto3" json:"clientID,omitempty"`
+	DeviceID             string   `protobuf:"bytes,3,opt,name=deviceID,proto3" json:"deviceID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExternalDisconnectEvent) Reset()         { *m = ExternalDisconnectEvent{} }
+func (m *ExternalDisconnectEvent) String() string { return proto.CompactTextString(m) }
+func (*ExternalDisconnectEvent) ProtoMessage()    {}
+func (*ExternalDisconnectEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_34180b7635741fb2, []int{7}
+}
+func (m *ExternalDisconnectEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExternalDisconnectEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExternalDisconnectEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ExternalDisconnectEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExternalDisconnectEvent.Merge(m, src)
+}
+func (m *ExternalDisconnectEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExternalDisconnectEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExternalDisconnectEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExternalDisconnectEvent proto.InternalMessageInfo
+
+func (m *ExternalDisconnectEvent) GetAppID() string {
+	if m != nil {
+		return m.AppID
+	}
+	return ""
+}
+
+func (m *ExternalDisconnectEvent) GetClientID() string {
+	if m != nil {
+		return m.ClientID
+	}
+	return ""
+}
+
+func (m *ExternalDisconnectEvent) GetDeviceID() string {
+	if m != nil {
+		return m.DeviceID
+	}
+	return ""
+}
+
 type ExternalNewEvent struct {
-	Type                 ExternalNewEventType          `protobuf:"varint,1,opt,name=type,proto3,enum=ExternalNewEventType" json:"type,omitempty"`
-	ServerID             string                        `protobuf:"bytes,2,opt,name=serverID,proto3" json:"serverID,omitempty"`
-	ExternalPublishEvent *ExternalPublishEvent         `protobuf:"bytes,3,opt,name=externalPublishEvent,proto3" json:"externalPublishEvent,omitempty"`
-	ExternalOnlineStatus *ExternalOnlineStatusEvent    `protobuf:"bytes,4,opt,name=externalOnlineStatus,proto3" json:"externalOnlineStatus,omitempty"`
-	ExternalJoinLeave    *ExternalJoinLeaveClientEvent `protobuf:"bytes,5,opt,name=externalJoinLeave,proto3" json:"externalJoinLeave,omitempty"`
-	ExternalAccessEvent  *ExternalChannelAccessEvent   `protobuf:"bytes,6,opt,name=externalAccessEvent,proto3" json:"externalAccessEvent,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
-	XXX_unrecognized     []byte                        `json:"-"`
-	XXX_sizecache        int32                         `json:"-"`
+	Type                       ExternalNewEventType          `protobuf:"varint,1,opt,name=type,proto3,enum=ExternalNewEventType" json:"type,omitempty"`
+	ServerID                   string                        `protobuf:"bytes,2,opt,name=serverID,proto3" json:"serverID,omitempty"`
+	ExternalPublishEvent       *ExternalPublishEvent         `protobuf:"bytes,3,opt,name=externalPublishEvent,proto3" json:"externalPublishEvent,omitempty"`
+	ExternalOnlineStatus       *ExternalOnlineStatusEvent    `protobuf:"bytes,4,opt,name=externalOnlineStatus,proto3" json:"externalOnlineStatus,omitempty"`
+	ExternalJoinLeave          *ExternalJoinLeaveClientEvent `protobuf:"bytes,5,opt,name=externalJoinLeave,proto3" json:"externalJoinLeave,omitempty"`
+	ExternalAccessEvent        *ExternalChannelAccessEvent   `protobuf:"bytes,6,opt,name=externalAccessEvent,proto3" json:"externalAccessEvent,omitempty"`
+	ExternalReadEvent          *ExternalReadEvent            `protobuf:"bytes,7,opt,name=externalReadEvent,proto3" json:"externalReadEvent,omitempty"`
+	ExternalSignalEvent        *ExternalSignalEvent          `protobuf:"bytes,8,opt,name=externalSignalEvent,proto3" json:"externalSignalEvent,omitempty"`
+	ExternalChannelUpdateEvent *ExternalChannelUpdateEvent   `protobuf:"bytes,9,opt,name=externalChannelUpdateEvent,proto3" json:"externalChannelUpdateEvent,omitempty"`
+	ExternalDisconnectEvent    *ExternalDisconnectEvent      `protobuf:"bytes,10,opt,name=externalDisconnectEvent,proto3" json:"externalDisconnectEvent,omitempty"`
+	XXX_NoUnkeyedLiteral       struct{}                      `json:"-"`
+	XXX_unrecognized           []byte                        `json:"-"`
+	XXX_sizecache              int32                         `json:"-"`
 }
 
 func (m *ExternalNewEvent) Reset()         { *m = ExternalNewEvent{} }
 func (m *ExternalNewEvent) String() string { return proto.CompactTextString(m) }
 func (*ExternalNewEvent) ProtoMessage()    {}
 func (*ExternalNewEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_34180b7635741fb2, []int{4}
+	return fileDescriptor_34180b7635741fb2, []int{8}
 }
 func (m *ExternalNewEvent) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -450,6 +775,34 @@ func (m *ExternalNewEvent) GetExternalAccessEvent() *ExternalChannelAccessEvent
 	return nil
 }
 
+func (m *ExternalNewEvent) GetExternalReadEvent() *ExternalReadEvent {
+	if m != nil {
+		return m.ExternalReadEvent
+	}
+	return nil
+}
+
+func (m *ExternalNewEvent) GetExternalSignalEvent() *ExternalSignalEvent {
+	if m != nil {
+		return m.ExternalSignalEvent
+	}
+	return nil
+}
+
+func (m *ExternalNewEvent) GetExternalChannelUpdateEvent() *ExternalChannelUpdateEvent {
+	if m != nil {
+		return m.ExternalChannelUpdateEvent
+	}
+	return nil
+}
+
+func (m *ExternalNewEvent) GetExternalDisconnectEvent() *ExternalDisconnectEvent {
+	if m != nil {
+		return m.ExternalDisconnectEvent
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("ExternalNewEventType", ExternalNewEventType_name, ExternalNewEventType_value)
 	proto.RegisterEnum("ExternalChannelPresenceType", ExternalChannelPresenceType_name, ExternalChannelPresenceType_value)
@@ -458,45 +811,68 @@ func init() {
 	proto.RegisterType((*ExternalPublishEvent)(nil), "ExternalPublishEvent")
 	proto.RegisterType((*ExternalOnlineStatusEvent)(nil), "ExternalOnlineStatusEvent")
 	proto.RegisterType((*ExternalJoinLeaveClientEvent)(nil), "ExternalJoinLeaveClientEvent")
+	proto.RegisterType((*ExternalReadEvent)(nil), "ExternalReadEvent")
+	proto.RegisterType((*ExternalSignalEvent)(nil), "ExternalSignalEvent")
+	proto.RegisterType((*ExternalChannelUpdateEvent)(nil), "ExternalChannelUpdateEvent")
+	proto.RegisterType((*ExternalDisconnectEvent)(nil), "ExternalDisconnectEvent")
 	proto.RegisterType((*ExternalNewEvent)(nil), "ExternalNewEvent")
 }
 
 func init() { proto.RegisterFile("publish.proto", fileDescriptor_34180b7635741fb2) }
 
 var fileDescriptor_34180b7635741fb2 = []byte{
-	// 510 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x54, 0xcd, 0x6e, 0xd3, 0x40,
-	0x10, 0xce, 0xc6, 0x69, 0x9a, 0x4c, 0x5b, 0xd8, 0x6e, 0x0b, 0x32, 0x69, 0x88, 0xa2, 0x9c, 0x42,
-	0x0e, 0x11, 0x2a, 0x2f, 0x40, 0x69, 0x7a, 0x48, 0x81, 0x52, 0x2d, 0xbc, 0x80, 0xe3, 0x8c, 0x54,
-	0x4b, 0xce, 0xda, 0xb2, 0xb7, 0x81, 0xbe, 0x01, 0x47, 0x8e, 0x88, 0x23, 0x4f, 0xc3, 0x91, 0x47,
-	0x40, 0xe1, 0x45, 0x90, 0x67, 0xe3, 0xf8, 0x27, 0xa6, 0x3d, 0xce, 0xec, 0x7c, 0xdf, 0x7c, 0xb3,
-	0xdf, 0xce, 0xc2, 0x41, 0x78, 0x3b, 0xf3, 0xbd, 0xf8, 0x66, 0x1c, 0x46, 0x81, 0x0e, 0x06, 0x3f,
-	0x19, 0x74, 0x2e, 0xbe, 0x68, 0x8c, 0x94, 0xe3, 0x9f, 0xdf, 0x38, 0x4a, 0xa1, 0x7f, 0xe6, 0xba,
-	0x18, 0xc7, 0x17, 0x4b, 0x54, 0x5a, 0x5c, 0x82, 0xc0, 0xf5, 0xa9, 0x49, 0x7f, 0xba, 0x0b, 0xd1,
-	0x66, 0x7d, 0x36, 0x7c, 0x74, 0xda, 0x19, 0x57, 0x02, 0x93, 0x0a, 0x59, 0x81, 0x12, 0x1d, 0x68,
-	0xb9, 0xbe, 0x87, 0x4a, 0x4f, 0x27, 0x76, 0xbd, 0xcf, 0x86, 0x6d, 0xb9, 0x89, 0x45, 0x17, 0xda,
-	0xae, 0x21, 0x99, 0x4e, 0x6c, 0x8b, 0x0e, 0xb3, 0xc4, 0xe0, 0x2b, 0x83, 0xe3, 0xb4, 0xd7, 0xb5,
-	0x91, 0x6f, 0xe4, 0x75, 0xa0, 0x15, 0xa3, 0x9a, 0x63, 0x34, 0x9d, 0x90, 0xa8, 0xb6, 0xdc, 0xc4,
-	0x09, 0x25, 0x26, 0x45, 0xa4, 0xd8, 0xf4, 0xcb, 0x12, 0xc2, 0x86, 0xdd, 0xd0, 0xb9, 0xf3, 0x03,
-	0x67, 0xbe, 0x6e, 0x97, 0x86, 0x09, 0x4e, 0x7b, 0x0b, 0x8c, 0xb5, 0xb3, 0x08, 0xed, 0x46, 0x9f,
-	0x0d, 0x2d, 0x99, 0x25, 0x06, 0x0b, 0x78, 0x96, 0x2a, 0xf9, 0xa0, 0x7c, 0x4f, 0xe1, 0x47, 0xed,
-	0xe8, 0xdb, 0x78, 0x23, 0x67, 0x33, 0x21, 0x2b, 0x4d, 0xf8, 0x14, 0x9a, 0x31, 0x95, 0x92, 0x96,
-	0x96, 0x5c, 0x47, 0xc5, 0x76, 0x56, 0xb9, 0xdd, 0x0f, 0x06, 0xdd, 0xb4, 0xdf, 0x65, 0xe0, 0xa9,
-	0x77, 0xe8, 0x2c, 0xf1, 0x9c, 0x38, 0x1f, 0x6e, 0x59, 0xb8, 0xd4, 0x7a, 0xe9, 0x52, 0xc5, 0x6b,
-	0xd8, 0x0f, 0x23, 0x8c, 0x51, 0xb9, 0x48, 0x57, 0x64, 0x91, 0xa9, 0xdd, 0xb2, 0xa9, 0xd7, 0xb9,
-	0x1a, 0x59, 0x40, 0x0c, 0xbe, 0x59, 0xc0, 0xd3, 0xea, 0x2b, 0xfc, 0x6c, 0x04, 0xbd, 0x80, 0x86,
-	0xce, 0xde, 0xc8, 0x93, 0x71, 0xb9, 0x80, 0x78, 0xa8, 0xc4, 0xb8, 0x17, 0x2d, 0xc9, 0xbd, 0x7a,
-	0xea, 0x9e, 0x89, 0xc5, 0x14, 0x8e, 0xb1, 0xc2, 0x71, 0x52, 0xb9, 0x97, 0xa3, 0xcd, 0x1f, 0xca,
-	0x4a, 0x88, 0xb8, 0xca, 0xa8, 0xf2, 0x96, 0x91, 0xb7, 0x7b, 0xb9, 0x57, 0xbc, 0xe5, 0xa7, 0xac,
-	0xc4, 0x89, 0xb7, 0x70, 0x88, 0x65, 0x4b, 0xec, 0x1d, 0x22, 0x7b, 0x3e, 0xbe, 0xcf, 0x2c, 0xb9,
-	0x8d, 0x13, 0xef, 0xe1, 0xa8, 0xb8, 0x2a, 0x66, 0xcc, 0x26, 0xd1, 0x9d, 0x8c, 0xff, 0xbf, 0x9a,
-	0xb2, 0x0a, 0x37, 0x9a, 0x65, 0x8b, 0x92, 0xbf, 0x70, 0xc1, 0x61, 0x3f, 0x3f, 0x03, 0xaf, 0x25,
-	0x99, 0x35, 0x29, 0x55, 0x71, 0x26, 0x8e, 0xe0, 0x71, 0xc9, 0x73, 0x5e, 0x17, 0x87, 0x70, 0x50,
-	0xe8, 0xcd, 0xad, 0xd1, 0x29, 0x9c, 0xdc, 0xf3, 0x46, 0x44, 0x0b, 0x1a, 0xc9, 0x78, 0xbc, 0x26,
-	0xda, 0xb0, 0x43, 0x43, 0x72, 0x36, 0x7a, 0x99, 0xad, 0xcd, 0xd6, 0x67, 0x21, 0x76, 0xc1, 0x3a,
-	0x9b, 0xcf, 0x79, 0x4d, 0x00, 0x34, 0x25, 0x2e, 0x82, 0x04, 0xf1, 0x86, 0xff, 0x5a, 0xf5, 0xd8,
-	0xef, 0x55, 0x8f, 0xfd, 0x59, 0xf5, 0xd8, 0xf7, 0xbf, 0xbd, 0xda, 0xac, 0x49, 0x3f, 0xd6, 0xab,
-	0x7f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xf6, 0xf3, 0x81, 0x95, 0xc2, 0x04, 0x00, 0x00,
+	// 812 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0xcd, 0x6e, 0xe3, 0x46,
+	0x0c, 0xb6, 0x6c, 0xd9, 0x96, 0xe9, 0xfd, 0x99, 0x4c, 0xdc, 0x5d, 0xad, 0x37, 0x35, 0x02, 0x9d,
+	0xd2, 0x1c, 0x74, 0x48, 0x6f, 0x3d, 0xed, 0x76, 0x9d, 0x02, 0xde, 0xb6, 0x69, 0x30, 0x69, 0x4f,
+	0x3d, 0x29, 0x12, 0x91, 0x08, 0x90, 0x25, 0x41, 0x9a, 0xb8, 0xc9, 0x23, 0xf4, 0x0d, 0x82, 0x3e,
+	0x45, 0x4f, 0x7d, 0x86, 0x02, 0xbd, 0xf4, 0x05, 0x0a, 0x14, 0xe9, 0x8b, 0x14, 0xa2, 0xfe, 0xc6,
+	0xb2, 0x6c, 0x03, 0x7b, 0x13, 0x39, 0xfc, 0xc8, 0x6f, 0x48, 0x7e, 0x63, 0xc3, 0xf3, 0xf8, 0xee,
+	0x3a, 0xf0, 0xd3, 0x5b, 0x3b, 0x4e, 0x22, 0x19, 0x59, 0x7f, 0x68, 0x30, 0x3d, 0xbf, 0x97, 0x98,
+	0x84, 0x4e, 0xf0, 0xe1, 0xd6, 0x09, 0x43, 0x0c, 0xde, 0xbb, 0x2e, 0xa6, 0xe9, 0xf9, 0x0a, 0x43,
+	0xc9, 0x3f, 0x02, 0xc7, 0xe2, 0x34, 0x77, 0xff, 0xf8, 0x10, 0xa3, 0xa9, 0x1d, 0x6b, 0x27, 0x2f,
+	0xce, 0xa6, 0x76, 0x2b, 0x30, 0x8b, 0x10, 0x2d, 0x28, 0x3e, 0x05, 0xc3, 0x0d, 0x7c, 0x0c, 0xe5,
+	0x62, 0x6e, 0x76, 0x8f, 0xb5, 0x93, 0x91, 0xa8, 0x6c, 0x7e, 0x04, 0x23, 0x37, 0x4f, 0xb2, 0x98,
+	0x9b, 0x3d, 0x3a, 0xac, 0x1d, 0x9c, 0x83, 0x9e, 0x44, 0x01, 0x9a, 0x3a, 0x1d, 0xd0, 0xb7, 0xf5,
+	0xa8, 0xc1, 0xa4, 0xac, 0x7f, 0x99, 0x5f, 0x29, 0xa7, 0x3c, 0x05, 0x23, 0xc5, 0xd0, 0xc3, 0x64,
+	0x31, 0x27, 0xa2, 0x23, 0x51, 0xd9, 0x59, 0x19, 0xcc, 0x82, 0xe8, 0x16, 0x39, 0x87, 0xda, 0xc1,
+	0x4d, 0x18, 0xc6, 0xce, 0x43, 0x10, 0x39, 0x5e, 0x41, 0xa1, 0x34, 0x33, 0x9c, 0xf4, 0x97, 0x98,
+	0x4a, 0x67, 0x19, 0x13, 0x8b, 0x9e, 0xa8, 0x1d, 0xfc, 0x05, 0x74, 0x17, 0x73, 0xb3, 0x7f, 0xac,
+	0x9d, 0xe8, 0xa2, 0xbb, 0x98, 0x5b, 0x4b, 0x78, 0x53, 0x32, 0xfb, 0x21, 0x0c, 0xfc, 0x10, 0xaf,
+	0xa4, 0x23, 0xef, 0xd2, 0x8a, 0x5e, 0xd5, 0x05, 0xad, 0xd1, 0x85, 0x57, 0x30, 0x48, 0x29, 0x94,
+	0xb8, 0x19, 0xa2, 0xb0, 0xd6, 0xcb, 0xf7, 0x1a, 0xe5, 0xad, 0xdf, 0x34, 0x38, 0x2a, 0xeb, 0x7d,
+	0x8c, 0xfc, 0xf0, 0x3b, 0x74, 0x56, 0xf8, 0x81, 0x72, 0xee, 0x2f, 0xb9, 0xd6, 0xf8, 0x6e, 0xb3,
+	0xf1, 0xef, 0xe0, 0x59, 0x9c, 0x60, 0x8a, 0xa1, 0x8b, 0xd4, 0xb2, 0x1e, 0x0d, 0xfe, 0xa8, 0x39,
+	0xf8, 0x4b, 0x25, 0x46, 0xac, 0x21, 0xac, 0x1b, 0x38, 0x28, 0x83, 0x05, 0x3a, 0xde, 0x7e, 0x42,
+	0x26, 0x0c, 0x69, 0x22, 0x05, 0x1d, 0x5d, 0x94, 0xe6, 0x9e, 0x2e, 0xfc, 0xaa, 0xc1, 0x61, 0x59,
+	0xe9, 0xca, 0xbf, 0x09, 0x9d, 0x60, 0xff, 0x3a, 0xcc, 0x00, 0x52, 0x0a, 0x55, 0xf6, 0x41, 0xf1,
+	0x7c, 0xea, 0x42, 0x58, 0xbf, 0x6f, 0x8a, 0xea, 0xa7, 0xd8, 0x73, 0x24, 0xe6, 0x94, 0x38, 0xe8,
+	0xa1, 0xb3, 0xc4, 0x82, 0x0e, 0x7d, 0xf3, 0x09, 0xf4, 0xf1, 0x5e, 0x26, 0x4e, 0xc1, 0x22, 0x37,
+	0x32, 0x82, 0x31, 0x26, 0xa9, 0x9f, 0x4a, 0x0c, 0x25, 0x71, 0x30, 0x84, 0xe2, 0x21, 0x82, 0x89,
+	0xbf, 0x72, 0x64, 0xae, 0x0d, 0x43, 0x94, 0x66, 0x76, 0xed, 0x72, 0x0e, 0xb4, 0x99, 0x86, 0xa8,
+	0xec, 0xac, 0x7e, 0x7c, 0x97, 0xde, 0x9a, 0x03, 0xf2, 0xd3, 0xb7, 0x75, 0x03, 0xaf, 0x4b, 0xc6,
+	0x73, 0x3f, 0x75, 0xa3, 0x30, 0x44, 0xb7, 0x58, 0x9f, 0x09, 0xf4, 0x9d, 0x38, 0xae, 0xda, 0x97,
+	0x1b, 0x3b, 0xd5, 0x3c, 0x05, 0xc3, 0xc3, 0x95, 0xef, 0x62, 0x25, 0xe6, 0xca, 0xb6, 0xfe, 0xea,
+	0x03, 0x2b, 0x2b, 0x5d, 0xe0, 0x2f, 0x79, 0x89, 0x2f, 0x40, 0x97, 0xf5, 0xc3, 0xf2, 0x99, 0xdd,
+	0x0c, 0xa0, 0xc5, 0xa2, 0x90, 0x7c, 0x9e, 0xc9, 0x0a, 0x93, 0xba, 0x6e, 0x69, 0xf3, 0x05, 0x4c,
+	0xb0, 0xe5, 0x49, 0x20, 0x0e, 0x63, 0x25, 0xad, 0x7a, 0x28, 0x5a, 0x21, 0xfc, 0xa2, 0x4e, 0xa5,
+	0x6a, 0x98, 0xda, 0x3c, 0x56, 0x9e, 0xbe, 0x0d, 0x81, 0x8b, 0x56, 0x1c, 0xff, 0x16, 0x0e, 0xb0,
+	0xa9, 0x51, 0x1a, 0xcc, 0xf8, 0xec, 0x73, 0x7b, 0x97, 0x7a, 0xc5, 0x26, 0x8e, 0x7f, 0x0f, 0x87,
+	0xeb, 0xef, 0x6b, 0x7e, 0xcd, 0x01, 0xa5, 0x7b, 0x6b, 0x6f, 0x7f, 0xcf, 0x45, 0x1b, 0x8e, 0xbf,
+	0xab, 0xb9, 0x55, 0x1a, 0x35, 0x87, 0x94, 0x8c, 0xdb, 0x1b, 0xea, 0x15, 0x9b, 0xc1, 0xfc, 0x9b,
+	0x9a, 0x90, 0xa2, 0x3d, 0xd3, 0xa0, 0x1c, 0x13, 0xbb, 0x45, 0x97, 0xa2, 0x0d, 0xc0, 0x7f, 0x86,
+	0x29, 0x6e, 0xd5, 0x8d, 0x39, 0x6a, 0xbf, 0x9f, 0x12, 0x22, 0x76, 0xc0, 0xb9, 0x80, 0xd7, 0xd8,
+	0xbe, 0xe2, 0x26, 0x50, 0x66, 0xd3, 0xde, 0x22, 0x01, 0xb1, 0x0d, 0x78, 0xfa, 0x8f, 0xf2, 0x2b,
+	0xa4, 0x2e, 0x2b, 0x67, 0xf0, 0x4c, 0x9d, 0x3f, 0xeb, 0x64, 0x9e, 0x82, 0x14, 0x45, 0x31, 0x8d,
+	0x1f, 0xc2, 0xcb, 0xc6, 0x03, 0xca, 0xba, 0xfc, 0x00, 0x9e, 0xaf, 0xcd, 0x8d, 0xf5, 0xf8, 0x04,
+	0x98, 0x8a, 0x3c, 0xf7, 0x7c, 0xc9, 0x74, 0xfe, 0x0a, 0xb8, 0xea, 0x9d, 0x63, 0x80, 0x12, 0x59,
+	0x9f, 0xbf, 0x84, 0x71, 0xe1, 0xcf, 0xe6, 0xc3, 0x06, 0x4a, 0xc6, 0xbc, 0xd5, 0x6c, 0xa8, 0xb8,
+	0xf2, 0x06, 0x31, 0x83, 0x8a, 0xd0, 0xd6, 0xd5, 0x57, 0x64, 0xa3, 0xd3, 0x33, 0x78, 0xbb, 0xe3,
+	0xad, 0xe7, 0x06, 0xe8, 0xd9, 0x56, 0xb2, 0x0e, 0x1f, 0x41, 0x9f, 0x76, 0x93, 0x69, 0xa7, 0x5f,
+	0xc1, 0x9b, 0x06, 0x46, 0xf9, 0x13, 0x30, 0x84, 0xde, 0x7b, 0xcf, 0x63, 0x1d, 0x0e, 0x30, 0x10,
+	0xb8, 0x8c, 0x32, 0x44, 0x96, 0x46, 0x44, 0x01, 0xb2, 0xee, 0xd7, 0xec, 0xcf, 0xa7, 0x99, 0xf6,
+	0xf7, 0xd3, 0x4c, 0xfb, 0xf7, 0x69, 0xa6, 0x3d, 0xfe, 0x37, 0xeb, 0x5c, 0x0f, 0xe8, 0x7f, 0xca,
+	0x97, 0xff, 0x0f, 0x00, 0xc8, 0xb3, 0x9a, 0xd3, 0xb8, 0x08, 0x00, 0x00,
 }
 
 func (m *ExternalChannelAccessEvent) Marshal() (dAtA []byte, err error) {
@@ -523,6 +899,13 @@ func (m *ExternalChannelAccessEvent) MarshalToSizedBuffer(dAtA []byte) (int, err
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.Role) > 0 {
+		i -= len(m.Role)
+		copy(dAtA[i:], m.Role)
+		i = encodeVarintPublish(dAtA, i, uint64(len(m.Role)))
+		i--
+		dAtA[i] = 0x22
+	}
 	if len(m.ChannelID) > 0 {
 		i -= len(m.ChannelID)
 		copy(dAtA[i:], m.ChannelID)
@@ -569,6 +952,11 @@ func (m *ExternalPublishEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.ID != 0 {
+		i = encodeVarintPublish(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x28
+	}
 	if m.Timestamp != 0 {
 		i = encodeVarintPublish(dAtA, i, uint64(m.Timestamp))
 		i--
@@ -693,7 +1081,7 @@ func (m *ExternalJoinLeaveClientEvent) MarshalToSizedBuffer(dAtA []byte) (int, e
 	return len(dAtA) - i, nil
 }
 
-func (m *ExternalNewEvent) Marshal() (dAtA []byte, err error) {
+func (m *ExternalReadEvent) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -703,12 +1091,12 @@ func (m *ExternalNewEvent) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ExternalNewEvent) MarshalTo(dAtA []byte) (int, error) {
+func (m *ExternalReadEvent) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ExternalNewEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ExternalReadEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -717,35 +1105,309 @@ func (m *ExternalNewEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.ExternalAccessEvent != nil {
-		{
-			size, err := m.ExternalAccessEvent.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintPublish(dAtA, i, uint64(size))
-		}
+	if m.Timestamp != 0 {
+		i = encodeVarintPublish(dAtA, i, uint64(m.Timestamp))
 		i--
-		dAtA[i] = 0x32
+		dAtA[i] = 0x18
 	}
-	if m.ExternalJoinLeave != nil {
-		{
-			size, err := m.ExternalJoinLeave.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintPublish(dAtA, i, uint64(size))
-		}
+	if m.EventID != 0 {
+		i = encodeVarintPublish(dAtA, i, uint64(m.EventID))
 		i--
-		dAtA[i] = 0x2a
+		dAtA[i] = 0x10
 	}
-	if m.ExternalOnlineStatus != nil {
-		{
-			size, err := m.ExternalOnlineStatus.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
+	if len(m.ClientID) > 0 {
+		i -= len(m.ClientID)
+		copy(dAtA[i:], m.ClientID)
+		i = encodeVarintPublish(dAtA, i, uint64(len(m.ClientID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ExternalSignalEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExternalSignalEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExternalSignalEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Timestamp != 0 {
+		i = encodeVarintPublish(dAtA, i, uint64(m.Timestamp))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Payload) > 0 {
+		i -= len(m.Payload)
+		copy(dAtA[i:], m.Payload)
+		i = encodeVarintPublish(dAtA, i, uint64(len(m.Payload)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.SignalType) > 0 {
+		i -= len(m.SignalType)
+		copy(dAtA[i:], m.SignalType)
+		i = encodeVarintPublish(dAtA, i, uint64(len(m.SignalType)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.SenderID) > 0 {
+		i -= len(m.SenderID)
+		copy(dAtA[i:], m.SenderID)
+		i = encodeVarintPublish(dAtA, i, uint64(len(m.SenderID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ExternalChannelUpdateEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExternalChannelUpdateEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExternalChannelUpdateEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Push {
+		i--
+		if m.Push {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.Presence {
+		i--
+		if m.Presence {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Private {
+		i--
+		if m.Private {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Persistent {
+		i--
+		if m.Persistent {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Extra) > 0 {
+		i -= len(m.Extra)
+		copy(dAtA[i:], m.Extra)
+		i = encodeVarintPublish(dAtA, i, uint64(len(m.Extra)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintPublish(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ExternalDisconnectEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExternalDisconnectEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExternalDisconnectEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.DeviceID) > 0 {
+		i -= len(m.DeviceID)
+		copy(dAtA[i:], m.DeviceID)
+		i = encodeVarintPublish(dAtA, i, uint64(len(m.DeviceID)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.ClientID) > 0 {
+		i -= len(m.ClientID)
+		copy(dAtA[i:], m.ClientID)
+		i = encodeVarintPublish(dAtA, i, uint64(len(m.ClientID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.AppID) > 0 {
+		i -= len(m.AppID)
+		copy(dAtA[i:], m.AppID)
+		i = encodeVarintPublish(dAtA, i, uint64(len(m.AppID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ExternalNewEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExternalNewEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExternalNewEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.ExternalDisconnectEvent != nil {
+		{
+			size, err := m.ExternalDisconnectEvent.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPublish(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x52
+	}
+	if m.ExternalChannelUpdateEvent != nil {
+		{
+			size, err := m.ExternalChannelUpdateEvent.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPublish(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4a
+	}
+	if m.ExternalSignalEvent != nil {
+		{
+			size, err := m.ExternalSignalEvent.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPublish(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.ExternalReadEvent != nil {
+		{
+			size, err := m.ExternalReadEvent.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPublish(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.ExternalAccessEvent != nil {
+		{
+			size, err := m.ExternalAccessEvent.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPublish(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.ExternalJoinLeave != nil {
+		{
+			size, err := m.ExternalJoinLeave.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPublish(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.ExternalOnlineStatus != nil {
+		{
+			size, err := m.ExternalOnlineStatus.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
 			}
 			i -= size
 			i = encodeVarintPublish(dAtA, i, uint64(size))
@@ -808,6 +1470,10 @@ func (m *ExternalChannelAccessEvent) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovPublish(uint64(l))
 	}
+	l = len(m.Role)
+	if l > 0 {
+		n += 1 + l + sovPublish(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -835,6 +1501,9 @@ func (m *ExternalPublishEvent) Size() (n int) {
 	if m.Timestamp != 0 {
 		n += 1 + sovPublish(uint64(m.Timestamp))
 	}
+	if m.ID != 0 {
+		n += 1 + sovPublish(uint64(m.ID))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -886,24 +1555,129 @@ func (m *ExternalJoinLeaveClientEvent) Size() (n int) {
 	return n
 }
 
-func (m *ExternalNewEvent) Size() (n int) {
+func (m *ExternalReadEvent) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.Type != 0 {
-		n += 1 + sovPublish(uint64(m.Type))
-	}
-	l = len(m.ServerID)
+	l = len(m.ClientID)
 	if l > 0 {
 		n += 1 + l + sovPublish(uint64(l))
 	}
-	if m.ExternalPublishEvent != nil {
-		l = m.ExternalPublishEvent.Size()
-		n += 1 + l + sovPublish(uint64(l))
+	if m.EventID != 0 {
+		n += 1 + sovPublish(uint64(m.EventID))
 	}
-	if m.ExternalOnlineStatus != nil {
+	if m.Timestamp != 0 {
+		n += 1 + sovPublish(uint64(m.Timestamp))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ExternalSignalEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.SenderID)
+	if l > 0 {
+		n += 1 + l + sovPublish(uint64(l))
+	}
+	l = len(m.SignalType)
+	if l > 0 {
+		n += 1 + l + sovPublish(uint64(l))
+	}
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovPublish(uint64(l))
+	}
+	if m.Timestamp != 0 {
+		n += 1 + sovPublish(uint64(m.Timestamp))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ExternalChannelUpdateEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovPublish(uint64(l))
+	}
+	l = len(m.Extra)
+	if l > 0 {
+		n += 1 + l + sovPublish(uint64(l))
+	}
+	if m.Persistent {
+		n += 2
+	}
+	if m.Private {
+		n += 2
+	}
+	if m.Presence {
+		n += 2
+	}
+	if m.Push {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ExternalDisconnectEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.AppID)
+	if l > 0 {
+		n += 1 + l + sovPublish(uint64(l))
+	}
+	l = len(m.ClientID)
+	if l > 0 {
+		n += 1 + l + sovPublish(uint64(l))
+	}
+	l = len(m.DeviceID)
+	if l > 0 {
+		n += 1 + l + sovPublish(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ExternalNewEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != 0 {
+		n += 1 + sovPublish(uint64(m.Type))
+	}
+	l = len(m.ServerID)
+	if l > 0 {
+		n += 1 + l + sovPublish(uint64(l))
+	}
+	if m.ExternalPublishEvent != nil {
+		l = m.ExternalPublishEvent.Size()
+		n += 1 + l + sovPublish(uint64(l))
+	}
+	if m.ExternalOnlineStatus != nil {
 		l = m.ExternalOnlineStatus.Size()
 		n += 1 + l + sovPublish(uint64(l))
 	}
@@ -915,6 +1689,22 @@ func (m *ExternalNewEvent) Size() (n int) {
 		l = m.ExternalAccessEvent.Size()
 		n += 1 + l + sovPublish(uint64(l))
 	}
+	if m.ExternalReadEvent != nil {
+		l = m.ExternalReadEvent.Size()
+		n += 1 + l + sovPublish(uint64(l))
+	}
+	if m.ExternalSignalEvent != nil {
+		l = m.ExternalSignalEvent.Size()
+		n += 1 + l + sovPublish(uint64(l))
+	}
+	if m.ExternalChannelUpdateEvent != nil {
+		l = m.ExternalChannelUpdateEvent.Size()
+		n += 1 + l + sovPublish(uint64(l))
+	}
+	if m.ExternalDisconnectEvent != nil {
+		l = m.ExternalDisconnectEvent.Size()
+		n += 1 + l + sovPublish(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1011,7 +1801,614 @@ func (m *ExternalChannelAccessEvent) Unmarshal(dAtA []byte) error {
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field ChannelID", wireType)
 			}
-			var stringLen uint64
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublish
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublish
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublish
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChannelID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Role", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublish
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublish
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublish
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Role = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPublish(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPublish
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExternalPublishEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPublish
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExternalPublishEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExternalPublishEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SenderID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublish
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublish
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublish
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SenderID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublish
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublish
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublish
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EventType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublish
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublish
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublish
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublish
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublish
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPublish(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPublish
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExternalOnlineStatusEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPublish
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExternalOnlineStatusEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExternalOnlineStatusEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublish
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublish
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublish
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublish
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Status = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublish
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPublish(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPublish
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExternalJoinLeaveClientEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPublish
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExternalJoinLeaveClientEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExternalJoinLeaveClientEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublish
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublish
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublish
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChannelID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublish
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublish
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublish
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChannelID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PresenceType", wireType)
+			}
+			m.PresenceType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublish
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PresenceType |= ExternalChannelPresenceType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPublish(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPublish
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExternalReadEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPublish
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExternalReadEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExternalReadEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublish
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublish
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublish
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventID", wireType)
+			}
+			m.EventID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublish
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EventID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPublish
@@ -1021,24 +2418,11 @@ func (m *ExternalChannelAccessEvent) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Timestamp |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPublish
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthPublish
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ChannelID = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPublish(dAtA[iNdEx:])
@@ -1061,7 +2445,7 @@ func (m *ExternalChannelAccessEvent) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ExternalPublishEvent) Unmarshal(dAtA []byte) error {
+func (m *ExternalSignalEvent) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1084,10 +2468,10 @@ func (m *ExternalPublishEvent) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ExternalPublishEvent: wiretype end group for non-group")
+			return fmt.Errorf("proto: ExternalSignalEvent: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ExternalPublishEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ExternalSignalEvent: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -1124,7 +2508,7 @@ func (m *ExternalPublishEvent) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EventType", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SignalType", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1152,7 +2536,7 @@ func (m *ExternalPublishEvent) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.EventType = string(dAtA[iNdEx:postIndex])
+			m.SignalType = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
@@ -1227,7 +2611,7 @@ func (m *ExternalPublishEvent) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ExternalOnlineStatusEvent) Unmarshal(dAtA []byte) error {
+func (m *ExternalChannelUpdateEvent) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1250,15 +2634,15 @@ func (m *ExternalOnlineStatusEvent) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ExternalOnlineStatusEvent: wiretype end group for non-group")
+			return fmt.Errorf("proto: ExternalChannelUpdateEvent: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ExternalOnlineStatusEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ExternalChannelUpdateEvent: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ClientID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1286,11 +2670,43 @@ func (m *ExternalOnlineStatusEvent) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ClientID = string(dAtA[iNdEx:postIndex])
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Extra", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublish
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublish
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublish
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Extra = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Persistent", wireType)
 			}
 			var v int
 			for shift := uint(0); ; shift += 7 {
@@ -1307,12 +2723,12 @@ func (m *ExternalOnlineStatusEvent) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			m.Status = bool(v != 0)
-		case 3:
+			m.Persistent = bool(v != 0)
+		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Private", wireType)
 			}
-			m.Timestamp = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPublish
@@ -1322,11 +2738,52 @@ func (m *ExternalOnlineStatusEvent) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Timestamp |= int64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Private = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Presence", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublish
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Presence = bool(v != 0)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Push", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublish
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.Push = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPublish(dAtA[iNdEx:])
@@ -1349,7 +2806,7 @@ func (m *ExternalOnlineStatusEvent) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ExternalJoinLeaveClientEvent) Unmarshal(dAtA []byte) error {
+func (m *ExternalDisconnectEvent) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1372,15 +2829,15 @@ func (m *ExternalJoinLeaveClientEvent) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ExternalJoinLeaveClientEvent: wiretype end group for non-group")
+			return fmt.Errorf("proto: ExternalDisconnectEvent: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ExternalJoinLeaveClientEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ExternalDisconnectEvent: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ClientID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AppID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1408,11 +2865,11 @@ func (m *ExternalJoinLeaveClientEvent) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ClientID = string(dAtA[iNdEx:postIndex])
+			m.AppID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChannelID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1440,13 +2897,13 @@ func (m *ExternalJoinLeaveClientEvent) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ChannelID = string(dAtA[iNdEx:postIndex])
+			m.ClientID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PresenceType", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeviceID", wireType)
 			}
-			m.PresenceType = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPublish
@@ -1456,11 +2913,24 @@ func (m *ExternalJoinLeaveClientEvent) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.PresenceType |= ExternalChannelPresenceType(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublish
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublish
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeviceID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPublish(dAtA[iNdEx:])
@@ -1707,6 +3177,150 @@ func (m *ExternalNewEvent) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExternalReadEvent", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublish
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPublish
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublish
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ExternalReadEvent == nil {
+				m.ExternalReadEvent = &ExternalReadEvent{}
+			}
+			if err := m.ExternalReadEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExternalSignalEvent", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublish
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPublish
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublish
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ExternalSignalEvent == nil {
+				m.ExternalSignalEvent = &ExternalSignalEvent{}
+			}
+			if err := m.ExternalSignalEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExternalChannelUpdateEvent", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublish
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPublish
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublish
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ExternalChannelUpdateEvent == nil {
+				m.ExternalChannelUpdateEvent = &ExternalChannelUpdateEvent{}
+			}
+			if err := m.ExternalChannelUpdateEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExternalDisconnectEvent", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublish
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPublish
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPublish
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ExternalDisconnectEvent == nil {
+				m.ExternalDisconnectEvent = &ExternalDisconnectEvent{}
+			}
+			if err := m.ExternalDisconnectEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPublish(dAtA[iNdEx:])
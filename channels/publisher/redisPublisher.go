@@ -3,20 +3,28 @@ package publisher
 
 import (
 	"context"
-	"fmt"
-	"log"
-	"os"
 	"strings"
 
 	"github.com/lisomatrix/channels/channels/core"
+	"github.com/lisomatrix/channels/channels/metrics"
+	"github.com/lisomatrix/channels/channels/redisconfig"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/rs/xid"
+	log "github.com/sirupsen/logrus"
 )
 
+var (
+	publisherMessages = metrics.NewCounterVec("channels_publisher_messages_total", "Events sent to and received from other servers", "direction")
+	publisherErrors   = metrics.NewCounterVec("channels_publisher_errors_total", "Redis publisher errors by operation", "operation")
+)
+
+// disconnectChannel - Redis channel every server subscribes to, for client disconnects
+const disconnectChannel = "channels:disconnect"
+
 // RedisPublisher - Implementation of PublishHandler interface
 type RedisPublisher struct {
-	client *redis.Client
+	client redis.UniversalClient
 	pubsub *redis.PubSub
 	ctx    context.Context
 }
@@ -41,19 +49,7 @@ func (publisher *RedisPublisher) PublishChannelPresenceChange(appID string, chan
 		ExternalJoinLeave: &channelPresenceChange,
 	}
 
-	data, err := newEvent.Marshal()
-
-	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Publisher: failed to marshal event %v\n", err)
-		return
-	}
-
-	cmd := publisher.client.Publish(publisher.ctx, appID+":"+channelID, data)
-
-	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Publisher: failed to publish event %v\n", err)
-		return
-	}
+	publisher.publish(appID, channelID, &newEvent)
 }
 
 func (publisher *RedisPublisher) PublishChannelAccessChange(appID string, channelID string, clientID string, isAdd bool) {
@@ -76,19 +72,24 @@ func (publisher *RedisPublisher) PublishChannelAccessChange(appID string, channe
 		ExternalAccessEvent: &accessEvent,
 	}
 
-	data, err := newEvent.Marshal()
-
-	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Publisher: failed to marshal event %v\n", err)
-		return
-	}
+	publisher.publish(appID, channelID, &newEvent)
+}
 
-	cmd := publisher.client.Publish(publisher.ctx, appID+":"+channelID, data)
+// PublishChannelRoleChange - Send client new channel role for other servers listening for this channel
+func (publisher *RedisPublisher) PublishChannelRoleChange(appID string, channelID string, clientID string, role core.ChannelRole) {
 
-	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Publisher: failed to publish event %v\n", err)
-		return
+	newEvent := ExternalNewEvent{
+		Type:     ExternalNewEventType_ChannelAccess,
+		ServerID: core.GetEngine().GetServerID(),
+		ExternalAccessEvent: &ExternalChannelAccessEvent{
+			ExternalAccessType: ExternalChannelAccessType_Role,
+			ClientID:           clientID,
+			ChannelID:          channelID,
+			Role:               string(role),
+		},
 	}
+
+	publisher.publish(appID, channelID, &newEvent)
 }
 
 // PublishChannelOnlineChange - Publish Online status change to other servers
@@ -106,50 +107,164 @@ func (publisher *RedisPublisher) PublishChannelOnlineChange(appID string, channe
 		ExternalOnlineStatus: &onlineStatusEvent,
 	}
 
-	data, err := newEvent.Marshal()
+	publisher.publish(appID, channelID, &newEvent)
+}
 
-	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Publisher: failed to marshal event %v\n", err)
-		return
-	}
+// PublishChannelEvent - Send event for other servers listening for this event
+func (publisher *RedisPublisher) PublishChannelEvent(appID string, channelID string, channelEvent *core.ChannelEvent) {
 
-	cmd := publisher.client.Publish(publisher.ctx, appID+":"+channelID, data)
+	publishEvent := ExternalPublishEvent{
+		SenderID:  channelEvent.SenderID,
+		Payload:   channelEvent.Payload,
+		Timestamp: channelEvent.Timestamp,
+		EventType: channelEvent.EventType,
+		ID:        channelEvent.ID,
+	}
 
-	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Publisher: failed to publish event %v\n", err)
-		return
+	newEvent := ExternalNewEvent{
+		Type:                 ExternalNewEventType_ChannelEvent,
+		ServerID:             core.GetEngine().GetServerID(),
+		ExternalPublishEvent: &publishEvent,
 	}
+
+	publisher.publish(appID, channelID, &newEvent)
 }
 
-// PublishChannelEvent - Send event for other servers listening for this event
-func (publisher *RedisPublisher) PublishChannelEvent(appID string, channelID string, channelEvent *core.ChannelEvent) {
+// PublishChannelEventEdit - Send edited event for other servers listening for this channel
+func (publisher *RedisPublisher) PublishChannelEventEdit(appID string, channelID string, channelEvent *core.ChannelEvent) {
 
 	publishEvent := ExternalPublishEvent{
 		SenderID:  channelEvent.SenderID,
 		Payload:   channelEvent.Payload,
 		Timestamp: channelEvent.Timestamp,
 		EventType: channelEvent.EventType,
+		ID:        channelEvent.ID,
 	}
 
 	newEvent := ExternalNewEvent{
-		Type:                 ExternalNewEventType_ChannelEvent,
+		Type:                 ExternalNewEventType_ChannelEventEdit,
 		ServerID:             core.GetEngine().GetServerID(),
 		ExternalPublishEvent: &publishEvent,
 	}
 
+	publisher.publish(appID, channelID, &newEvent)
+}
+
+// PublishChannelEventDelete - Send deleted event ID for other servers listening for this channel
+func (publisher *RedisPublisher) PublishChannelEventDelete(appID string, channelID string, eventID uint64) {
+
+	newEvent := ExternalNewEvent{
+		Type:                 ExternalNewEventType_ChannelEventDelete,
+		ServerID:             core.GetEngine().GetServerID(),
+		ExternalPublishEvent: &ExternalPublishEvent{ID: eventID},
+	}
+
+	publisher.publish(appID, channelID, &newEvent)
+}
+
+// PublishChannelRead - Send client read marker for other servers listening for this channel
+func (publisher *RedisPublisher) PublishChannelRead(appID string, channelID string, receipt *core.ReadReceipt) {
+
+	newEvent := ExternalNewEvent{
+		Type:     ExternalNewEventType_ChannelRead,
+		ServerID: core.GetEngine().GetServerID(),
+		ExternalReadEvent: &ExternalReadEvent{
+			ClientID:  receipt.ClientID,
+			EventID:   receipt.EventID,
+			Timestamp: receipt.Timestamp,
+		},
+	}
+
+	publisher.publish(appID, channelID, &newEvent)
+}
+
+// PublishChannelSignal - Send ephemeral signal for other servers listening for this channel
+func (publisher *RedisPublisher) PublishChannelSignal(appID string, channelID string, signal *core.ChannelSignal) {
+
+	newEvent := ExternalNewEvent{
+		Type:     ExternalNewEventType_ChannelSignal,
+		ServerID: core.GetEngine().GetServerID(),
+		ExternalSignalEvent: &ExternalSignalEvent{
+			SenderID:   signal.SenderID,
+			SignalType: signal.SignalType,
+			Payload:    signal.Payload,
+			Timestamp:  signal.Timestamp,
+		},
+	}
+
+	publisher.publish(appID, channelID, &newEvent)
+}
+
+// PublishChannelUpdate - Send updated channel settings for other servers listening for this channel
+func (publisher *RedisPublisher) PublishChannelUpdate(appID string, channel *core.Channel) {
+
+	newEvent := ExternalNewEvent{
+		Type:     ExternalNewEventType_ChannelUpdate,
+		ServerID: core.GetEngine().GetServerID(),
+		ExternalChannelUpdateEvent: &ExternalChannelUpdateEvent{
+			Name:       channel.Name,
+			Extra:      channel.Extra,
+			Persistent: channel.Persistent,
+			Private:    channel.Private,
+			Presence:   channel.Presence,
+			Push:       channel.Push,
+		},
+	}
+
+	publisher.publish(appID, channel.ID, &newEvent)
+}
+
+// PublishClientDisconnect - Let every server close the client sessions
+func (publisher *RedisPublisher) PublishClientDisconnect(appID string, clientID string, deviceID string) {
+
+	newEvent := ExternalNewEvent{
+		Type:     ExternalNewEventType_ClientDisconnect,
+		ServerID: core.GetEngine().GetServerID(),
+		ExternalDisconnectEvent: &ExternalDisconnectEvent{
+			AppID:    appID,
+			ClientID: clientID,
+			DeviceID: deviceID,
+		},
+	}
+
 	data, err := newEvent.Marshal()
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Publisher: failed to marshal event %v\n", err)
+		publisherErrors.Inc("marshal")
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ClientID": clientID}).WithError(err).Error("Redis Publisher: failed to marshal disconnect event")
+		return
+	}
+
+	cmd := publisher.client.Publish(publisher.ctx, disconnectChannel, data)
+
+	if cmd.Err() != nil {
+		publisherErrors.Inc("publish")
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ClientID": clientID}).WithError(cmd.Err()).Error("Redis Publisher: failed to publish disconnect event")
+		return
+	}
+
+	publisherMessages.Inc("sent")
+}
+
+// publish - Send event to the other servers listening for the channel
+func (publisher *RedisPublisher) publish(appID string, channelID string, newEvent *ExternalNewEvent) {
+	data, err := newEvent.Marshal()
+
+	if err != nil {
+		publisherErrors.Inc("marshal")
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Redis Publisher: failed to marshal event")
 		return
 	}
 
 	cmd := publisher.client.Publish(publisher.ctx, appID+":"+channelID, data)
 
 	if cmd.Err() != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Publisher: failed to publish event %v\n", err)
+		publisherErrors.Inc("publish")
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(cmd.Err()).Error("Redis Publisher: failed to publish event")
 		return
 	}
+
+	publisherMessages.Inc("sent")
 }
 
 // Unsubscribe - Unsubscribe from a channel in redis
@@ -157,7 +272,8 @@ func (publisher *RedisPublisher) Unsubscribe(appID string, channelID string) {
 	err := publisher.pubsub.Unsubscribe(publisher.ctx, appID+":"+channelID)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Publisher: failed to unsubscribe from channel %v\n", err)
+		publisherErrors.Inc("unsubscribe")
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Redis Publisher: failed to unsubscribe from channel")
 	}
 }
 
@@ -166,13 +282,14 @@ func (publisher *RedisPublisher) Subscribe(appID string, channelID string) {
 	err := publisher.pubsub.Subscribe(publisher.ctx, appID+":"+channelID)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Publisher: failed get subscribe to channel %v\n", err)
+		publisherErrors.Inc("subscribe")
+		core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Redis Publisher: failed get subscribe to channel")
 	}
 }
 
 func (publisher *RedisPublisher) handleSubscribeMessages() {
 
-	defer log.Println("Stopped handling redis messages")
+	defer core.Logger().Info("Redis Publisher: stopped handling redis messages")
 
 	ch := publisher.pubsub.Channel()
 
@@ -188,7 +305,8 @@ func (publisher *RedisPublisher) handleSubscribeMessages() {
 		err := newEvent.Unmarshal([]byte(data.Payload))
 
 		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "Redis Publisher: failed umarshal external event %v\n", err)
+			publisherErrors.Inc("unmarshal")
+			core.Logger().WithError(err).Error("Redis Publisher: failed umarshal external event")
 			continue
 		}
 
@@ -197,7 +315,19 @@ func (publisher *RedisPublisher) handleSubscribeMessages() {
 			continue
 		}
 
-		_, _ = fmt.Fprintf(os.Stderr, "Redis Publisher: received event with size: %d bytes\n", len([]byte(data.Payload)))
+		publisherMessages.Inc("received")
+
+		core.Logger().WithField("Channel", data.Channel).Debugf("Redis Publisher: received event with size: %d bytes", len([]byte(data.Payload)))
+
+		if newEvent.Type == ExternalNewEventType_ClientDisconnect {
+			event := newEvent.GetExternalDisconnectEvent()
+
+			if hub := core.GetEngine().GetHubsHandler().ContainsHub(event.GetAppID()); hub != nil {
+				hub.DisconnectClient(event.GetClientID(), event.GetDeviceID(), "disconnected")
+			}
+
+			continue
+		}
 
 		parts := strings.Split(data.Channel, ":")
 
@@ -228,6 +358,65 @@ func (publisher *RedisPublisher) handleSubscribeMessages() {
 				EventType: event.EventType,
 				Timestamp: event.Timestamp,
 				ChannelID: channelID,
+				ID:        event.ID,
+			})
+
+		} else if newEvent.Type == ExternalNewEventType_ChannelEventEdit {
+
+			event := newEvent.GetExternalPublishEvent()
+
+			channel.PublishEdit(&core.ChannelEvent{
+				SenderID:  event.SenderID,
+				Payload:   event.Payload,
+				EventType: event.EventType,
+				Timestamp: event.Timestamp,
+				ChannelID: channelID,
+				ID:        event.ID,
+			})
+
+		} else if newEvent.Type == ExternalNewEventType_ChannelEventDelete {
+
+			channel.PublishDelete(newEvent.GetExternalPublishEvent().GetID())
+
+		} else if newEvent.Type == ExternalNewEventType_ChannelSignal {
+
+			event := newEvent.GetExternalSignalEvent()
+
+			channel.ExternalPublishSignal(&core.ChannelSignal{
+				SenderID:   event.SenderID,
+				ChannelID:  channelID,
+				SignalType: event.SignalType,
+				Payload:    event.Payload,
+				Timestamp:  event.Timestamp,
+			})
+
+		} else if newEvent.Type == ExternalNewEventType_ChannelUpdate {
+
+			event := newEvent.GetExternalChannelUpdateEvent()
+
+			// Cache might be local to this server
+			core.GetEngine().GetCacheStorage().RemoveChannel(appID, channelID)
+
+			channel.UpdateData(&core.Channel{
+				ID:         channelID,
+				AppID:      appID,
+				Name:       event.Name,
+				Extra:      event.Extra,
+				Persistent: event.Persistent,
+				Private:    event.Private,
+				Presence:   event.Presence,
+				Push:       event.Push,
+			})
+
+		} else if newEvent.Type == ExternalNewEventType_ChannelRead {
+
+			event := newEvent.GetExternalReadEvent()
+
+			channel.PublishReadReceipt(&core.ReadReceipt{
+				ChannelID: channelID,
+				ClientID:  event.ClientID,
+				EventID:   event.EventID,
+				Timestamp: event.Timestamp,
 			})
 
 		} else if newEvent.Type == ExternalNewEventType_OnlineStatus {
@@ -243,6 +432,14 @@ func (publisher *RedisPublisher) handleSubscribeMessages() {
 		} else if newEvent.Type == ExternalNewEventType_ChannelAccess {
 			event := newEvent.GetExternalAccessEvent()
 
+			if event.ExternalAccessType == ExternalChannelAccessType_Role {
+				channel.SetClientRole(event.ClientID, core.ChannelRole(event.Role))
+				continue
+			}
+
+			// Role is loaded again on next use
+			channel.ForgetClientRole(event.ClientID)
+
 			if event.ExternalAccessType == ExternalChannelAccessType_Add {
 				hub.RemoveChannelFromClient(event.ClientID, event.ChannelID)
 			} else if event.ExternalAccessType == ExternalChannelAccessType_Remove {
@@ -261,7 +458,7 @@ func (publisher *RedisPublisher) handleSubscribeMessages() {
 				}
 
 				if data, err := clientJoined.Marshal(); err != nil {
-					_, _ = fmt.Fprintf(os.Stderr, "Redis Publisher: failed to marshal external channel presence event (JOIN TYPE) \n")
+					core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Redis Publisher: failed to marshal external channel presence event (JOIN TYPE)")
 				} else {
 					channel.PublishJoinLeave(core.NewEvent_JOIN_CHANNEL, data)
 				}
@@ -272,35 +469,39 @@ func (publisher *RedisPublisher) handleSubscribeMessages() {
 				}
 
 				if data, err := clientLeave.Marshal(); err != nil {
-					_, _ = fmt.Fprintf(os.Stderr, "Redis Publisher: failed to marshal external channel presence event (LEAVE TYPE) \n")
+					core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).WithError(err).Error("Redis Publisher: failed to marshal external channel presence event (LEAVE TYPE)")
 				} else {
 					channel.PublishJoinLeave(core.NewEvent_LEAVE_CHANNEL, data)
 				}
 			}
 
 		} else {
-			_, _ = fmt.Fprintf(os.Stderr, "Redis Publisher: received Unknown event type \n")
+			core.Logger().WithFields(log.Fields{"AppID": appID, "ChannelID": channelID}).Error("Redis Publisher: received Unknown event type")
 		}
 
 	}
 }
 
-// NewRedisPublisher - Create a new instance of redis publisher
-func NewRedisPublisher() *RedisPublisher {
+// NewRedisPublisher - Create a new instance of redis publisher connected with the given settings, use redisconfig.Default() for a local node
+func NewRedisPublisher(config redisconfig.Config) (*RedisPublisher, error) {
+	client, err := config.NewClient()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewRedisPublisherWithClient(client), nil
+}
+
+// NewRedisPublisherWithClient - Create a new instance of redis publisher with the given client
+// Subscriptions use their own connection, so the client can be shared with cache and presence
+func NewRedisPublisherWithClient(client redis.UniversalClient) *RedisPublisher {
 	redisPublisher := new(RedisPublisher)
 	redisPublisher.ctx = context.Background()
 
-	client := redis.NewClient(
-		&redis.Options{
-			Addr: "127.0.0.1:6379",
-			// Password: "CulP3gnpgSAxFlbjO/JrNCR/uTKFKvTLbW7gJoVQfg1sh1BmzeNBUs5TsXy0Q7YDgGbfazSZy5LKnU3l", // no password set
-			DB:       0,
-			PoolSize: 5,
-		})
-
 	redisPublisher.client = client
 
-	redisPublisher.pubsub = client.Subscribe(redisPublisher.ctx, xid.New().String())
+	redisPublisher.pubsub = client.Subscribe(redisPublisher.ctx, xid.New().String(), disconnectChannel)
 
 	go redisPublisher.handleSubscribeMessages()
 
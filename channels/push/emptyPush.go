@@ -1,9 +1,19 @@
 package push
 
 import (
+	"context"
+
 	"github.com/lisomatrix/channels/channels/core"
 )
 
 type EmptyPushNotificationHandler struct {}
 
-func (handler *EmptyPushNotificationHandler) EnqueueRequest(*core.PushRequestItem) {}
\ No newline at end of file
+func (handler *EmptyPushNotificationHandler) EnqueueRequest(*core.PushRequestItem) {}
+
+func (handler *EmptyPushNotificationHandler) Stop(context.Context) error {
+	return nil
+}
+
+func (handler *EmptyPushNotificationHandler) Len() int {
+	return 0
+}
\ No newline at end of file
@@ -4,12 +4,10 @@ import (
 	"context"
 	firebase "firebase.google.com/go"
 	"firebase.google.com/go/messaging"
-	"fmt"
 	"github.com/lisomatrix/channels/channels/core"
 	"google.golang.org/api/option"
-	"log"
-	"os"
 	"strconv"
+	"sync"
 )
 
 func InitializeAppDefault(filePath string) *firebase.App {
@@ -17,7 +15,7 @@ func InitializeAppDefault(filePath string) *firebase.App {
 
 	app, err := firebase.NewApp(context.Background(), nil, opt)
 	if err != nil {
-		log.Fatalf("error initializing app: %v\n", err)
+		core.Logger().WithError(err).Fatal("Firebase Push: error initializing app")
 	}
 
 	return app
@@ -27,16 +25,50 @@ type FirePushNotificationHandler struct {
 	firebaseApp *firebase.App
 	client *messaging.Client
 	queue chan *core.PushRequestItem
+	lock sync.RWMutex
+	isStopped bool
+	done chan struct{}
 }
 
 func (handler *FirePushNotificationHandler) EnqueueRequest(request *core.PushRequestItem) {
+	handler.lock.RLock()
+	defer handler.lock.RUnlock()
+
+	if handler.isStopped {
+		return
+	}
+
 	handler.queue <- request
 }
 
+// Stop - Stop accepting requests and wait until the queued ones are sent
+func (handler *FirePushNotificationHandler) Stop(ctx context.Context) error {
+	handler.lock.Lock()
+
+	if !handler.isStopped {
+		handler.isStopped = true
+		close(handler.queue)
+	}
+
+	handler.lock.Unlock()
+
+	select {
+	case <-handler.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Len - Requests waiting to be sent
+func (handler *FirePushNotificationHandler) Len() int {
+	return len(handler.queue)
+}
+
 func (handler *FirePushNotificationHandler) sendMessages() {
+	defer close(handler.done)
 
-	for {
-		request, _ := <-handler.queue
+	for request := range handler.queue {
 		sendMulticast(request, handler.client)
 	}
 }
@@ -46,11 +78,12 @@ func NewFirePushNotificationHandler(filePath string) *FirePushNotificationHandle
 
 	handler.firebaseApp = InitializeAppDefault(filePath)
 	handler.queue = make(chan *core.PushRequestItem, 100)
+	handler.done = make(chan struct{})
 
 	client, err := handler.firebaseApp.Messaging(context.Background())
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Failed to get messaging client %v\n", err)
+		core.Logger().WithError(err).Error("Failed to get messaging client")
 		return nil
 	}
 
@@ -68,7 +101,7 @@ func sendMulticast(pushRequestItem *core.PushRequestItem, client *messaging.Clie
 	tokens, err := core.GetEngine().GetDeviceRepository().GetClientsDeviceTokens(pushRequestItem.ClientIDs, 500)
 
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Failed to get client device tokens %v\n", err)
+		core.Logger().WithError(err).Error("Failed to get client device tokens")
 	}
 
 	if len(tokens) == 0 {
@@ -92,6 +125,6 @@ func sendMulticast(pushRequestItem *core.PushRequestItem, client *messaging.Clie
 
 	_, err = client.SendMulticast(context.Background(), message)
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Failed to send push notifications %v\n", err)
+		core.Logger().WithField("ChannelID", pushRequestItem.ChannelID).WithError(err).Error("Failed to send push notifications")
 	}
 }
\ No newline at end of file
@@ -0,0 +1,136 @@
+// This package holds the Redis connection settings shared by the Redis cache, presence and publisher
+package redisconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	DefaultAddress  = "127.0.0.1:6379"
+	DefaultPoolSize = 5
+)
+
+// Config - Redis connection settings
+// With a Sentinel master name the client connects through Sentinel, with Cluster set it connects to a Redis Cluster,
+// otherwise it connects to a single node
+type Config struct {
+	Address   string   `yaml:"address"`   // Single node address, defaults to 127.0.0.1:6379
+	Addresses []string `yaml:"addresses"` // Sentinel or Cluster addresses, if empty Address is used
+	Username  string   `yaml:"username"`  // For Redis 6 ACL
+	Password  string   `yaml:"password"`
+	DB        int      `yaml:"db"`        // Ignored in Cluster mode
+	PoolSize  int      `yaml:"pool_size"` // Connections per node, defaults to 5
+	TLS       struct {
+		Enabled            bool   `yaml:"enabled"`
+		ServerName         string `yaml:"server_name"`
+		InsecureSkipVerify bool   `yaml:"insecure_skip_verify"`
+		CAFile             string `yaml:"ca_file"`   // CA used to verify the server, defaults to the system ones
+		CertFile           string `yaml:"cert_file"` // Client certificate, when the server requires one
+		KeyFile            string `yaml:"key_file"`
+	} `yaml:"tls"`
+	Sentinel struct {
+		MasterName string `yaml:"master_name"`
+		Password   string `yaml:"password"`
+	} `yaml:"sentinel"`
+	Cluster bool `yaml:"cluster"`
+}
+
+// Default - Settings used when none are given, a single local node
+func Default() Config {
+	return Config{
+		Address:  DefaultAddress,
+		PoolSize: DefaultPoolSize,
+	}
+}
+
+// NewClient - Create a Redis client with the given settings
+func (config Config) NewClient() (redis.UniversalClient, error) {
+	addresses := config.Addresses
+
+	if len(addresses) == 0 {
+		address := config.Address
+
+		if address == "" {
+			address = DefaultAddress
+		}
+
+		addresses = []string{address}
+	}
+
+	poolSize := config.PoolSize
+
+	if poolSize <= 0 {
+		poolSize = DefaultPoolSize
+	}
+
+	options := &redis.UniversalOptions{
+		Addrs:            addresses,
+		DB:               config.DB,
+		Username:         config.Username,
+		Password:         config.Password,
+		PoolSize:         poolSize,
+		MasterName:       config.Sentinel.MasterName,
+		SentinelPassword: config.Sentinel.Password,
+	}
+
+	if config.TLS.Enabled {
+		tlsConfig, err := config.tlsConfig()
+
+		if err != nil {
+			return nil, err
+		}
+
+		options.TLSConfig = tlsConfig
+	}
+
+	if config.Cluster && config.Sentinel.MasterName != "" {
+		return nil, errors.New("redis config: cluster and sentinel can't be used together")
+	}
+
+	// NewUniversalClient only uses a cluster client with more than one address
+	if config.Cluster {
+		return redis.NewClusterClient(options.Cluster()), nil
+	}
+
+	return redis.NewUniversalClient(options), nil
+}
+
+func (config Config) tlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName:         config.TLS.ServerName,
+		InsecureSkipVerify: config.TLS.InsecureSkipVerify,
+	}
+
+	if config.TLS.CAFile != "" {
+		caData, err := ioutil.ReadFile(config.TLS.CAFile)
+
+		if err != nil {
+			return nil, err
+		}
+
+		pool := x509.NewCertPool()
+
+		if !pool.AppendCertsFromPEM(caData) {
+			return nil, errors.New("redis config: no certificates found in CA file")
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if config.TLS.CertFile != "" || config.TLS.KeyFile != "" {
+		certificate, err := tls.LoadX509KeyPair(config.TLS.CertFile, config.TLS.KeyFile)
+
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,39 @@
+package redisconfig
+
+import (
+	"testing"
+
+	"github.com/go-redis/redis/v8"
+)
+
+func TestNewClient(t *testing.T) {
+	config := Default()
+
+	client, err := config.NewClient()
+
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	if _, isOK := client.(*redis.Client); !isOK {
+		t.Errorf("Expected single node client, got %T", client)
+	}
+
+	config.Cluster = true
+
+	client, err = config.NewClient()
+
+	if err != nil {
+		t.Fatalf("Failed to create cluster client: %v", err)
+	}
+
+	if _, isOK := client.(*redis.ClusterClient); !isOK {
+		t.Errorf("Expected cluster client, got %T", client)
+	}
+
+	config.Sentinel.MasterName = "master"
+
+	if _, err := config.NewClient(); err == nil {
+		t.Errorf("Expected error when using cluster and sentinel together")
+	}
+}
@@ -0,0 +1,92 @@
+package gormsql
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/lisomatrix/channels/channels/core"
+	"gorm.io/gorm"
+)
+
+type ChannelsAPIKey struct {
+	ID        string `gorm:"column:id;primaryKey;not null"`
+	AppID     string `gorm:"column:app_id;index;not null"`
+	Name      string `gorm:"column:name;not null"`
+	KeyHash   string `gorm:"column:key_hash;uniqueIndex;size:64;not null"`
+	Scopes    string `gorm:"column:scopes;not null"`
+	CreatedAt int64  `gorm:"column:created_at;not null"`
+}
+
+func (key *ChannelsAPIKey) toAPIKey() *core.APIKey {
+	return &core.APIKey{
+		ID:        key.ID,
+		AppID:     key.AppID,
+		Name:      key.Name,
+		KeyHash:   key.KeyHash,
+		Scopes:    strings.Split(key.Scopes, ","),
+		CreatedAt: key.CreatedAt,
+	}
+}
+
+type GormAPIKeyRepository struct {
+	gormDB *gorm.DB
+}
+
+func (repo *GormAPIKeyRepository) Migrate() error {
+	return repo.gormDB.AutoMigrate(&ChannelsAPIKey{})
+}
+
+func (repo *GormAPIKeyRepository) CreateAPIKey(key *core.APIKey) error {
+	return repo.gormDB.Create(&ChannelsAPIKey{
+		ID:        key.ID,
+		AppID:     key.AppID,
+		Name:      key.Name,
+		KeyHash:   key.KeyHash,
+		Scopes:    strings.Join(key.Scopes, ","),
+		CreatedAt: key.CreatedAt,
+	}).Error
+}
+
+func (repo *GormAPIKeyRepository) GetAPIKeyByHash(keyHash string) (*core.APIKey, error) {
+	var key ChannelsAPIKey
+
+	tx := repo.gormDB.Where("key_hash = ?", keyHash).First(&key)
+
+	if tx.Error != nil {
+		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, tx.Error
+	}
+
+	return key.toAPIKey(), nil
+}
+
+func (repo *GormAPIKeyRepository) GetAppAPIKeys(appID string) ([]*core.APIKey, error) {
+	stored := make([]ChannelsAPIKey, 0)
+
+	tx := repo.gormDB.Where("app_id = ?", appID).Order("created_at").Find(&stored)
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	keys := make([]*core.APIKey, 0, len(stored))
+
+	for i := range stored {
+		keys = append(keys, stored[i].toAPIKey())
+	}
+
+	return keys, nil
+}
+
+func (repo *GormAPIKeyRepository) DeleteAPIKey(appID string, keyID string) (bool, error) {
+	tx := repo.gormDB.Where("app_id = ? AND id = ?", appID, keyID).Delete(&ChannelsAPIKey{})
+
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+
+	return tx.RowsAffected > 0, nil
+}
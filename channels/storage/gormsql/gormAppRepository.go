@@ -53,6 +53,28 @@ func (repo *GormAppRepository) GetApps() ([]*core.App, error) {
 	return coreApps, nil
 }
 
+func (repo *GormAppRepository) GetAppsPage(options core.ListOptions) ([]*core.App, error) {
+	apps := make([]ChannelsApp, 0)
+
+	tx := paginate(repo.gormDB.Model(&ChannelsApp{}), "app_id", "name", options).Find(&apps)
+
+	if tx.Error != nil {
+		log.Println(tx.Error)
+		return nil, tx.Error
+	}
+
+	coreApps := make([]*core.App, 0, len(apps))
+
+	for _, a := range apps {
+		coreApps = append(coreApps, &core.App{
+			AppID: a.AppID,
+			Name:  a.Name,
+		})
+	}
+
+	return coreApps, nil
+}
+
 func (repo *GormAppRepository) GetApp(id string) (*core.App, error) {
 	var channelApp ChannelsApp
 
@@ -5,6 +5,7 @@ import (
 
 	"github.com/lisomatrix/channels/channels/core"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ChannelsChannel struct {
@@ -19,6 +20,8 @@ type ChannelsChannel struct {
 	Presence   bool             `gorm:"column:presence;not null"`
 	Push       bool             `gorm:"column:push;not null"`
 	Clients    []ChannelsClient `gorm:"many2many:channel_client;"`
+
+	LastEventID uint64 `gorm:"column:last_event_id;not null;default:0"` // Highest deleted event ID, so it isn't given again
 }
 
 type ChannelsChannelEvent struct {
@@ -28,6 +31,33 @@ type ChannelsChannelEvent struct {
 	TimeStamp int64  `gorm:"column:timestamp;not null"`
 	Payload   string `gorm:"column:payload"`
 	ChannelID string `gorm:"column:channel_id"`
+	EventID   uint64 `gorm:"column:event_id;not null;default:0"`
+}
+
+// channelEventIDIndex - Unique index of the event IDs of each channel, created after old events are given IDs
+const channelEventIDIndex = "channel_event_id_index"
+
+type ChannelsChannelRead struct {
+	ChannelID string `gorm:"column:channel_id;primaryKey;not null"`
+	ClientID  string `gorm:"column:client_id;primaryKey;not null"`
+	EventID   uint64 `gorm:"column:event_id;not null;default:0"`
+	TimeStamp int64  `gorm:"column:timestamp;not null"`
+}
+
+// ChannelsChannelRole - Client roles in channels, the channel_client join table is managed by gorm so they are kept apart
+// Members without a row have the member role
+type ChannelsChannelRole struct {
+	ChannelID string `gorm:"column:channel_id;primaryKey;not null"`
+	ClientID  string `gorm:"column:client_id;primaryKey;not null"`
+	Role      string `gorm:"column:role;not null"`
+}
+
+// ChannelsEventTypeRule - Event types allowed in a channel, rules with an empty channel ID apply to the whole app
+type ChannelsEventTypeRule struct {
+	AppID         string `gorm:"column:app_id;primaryKey;not null"`
+	ChannelID     string `gorm:"column:channel_id;primaryKey;not null;default:''"`
+	EventType     string `gorm:"column:event_type;primaryKey;not null"`
+	PayloadSchema string `gorm:"column:payload_schema;not null;default:''"`
 }
 
 func (c *ChannelsChannel) TableName() string {
@@ -47,9 +77,80 @@ func (repo *GormChannelRepository) Migrate() error {
 		return err
 	}
 
+	if err := repo.gormDB.AutoMigrate(&ChannelsChannelRead{}); err != nil {
+		return err
+	}
+
+	if err := repo.migrateEventIDs(); err != nil {
+		return err
+	}
+
+	if err := repo.gormDB.AutoMigrate(&ChannelsChannelRole{}); err != nil {
+		return err
+	}
+
+	if err := repo.gormDB.AutoMigrate(&ChannelsEventTypeRule{}); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// migrateEventIDs - Give IDs to the events stored before they had one, and make them unique in each channel
+// Old events come first in each channel, so the events with ID are moved after them
+func (repo *GormChannelRepository) migrateEventIDs() error {
+	migrator := repo.gormDB.Migrator()
+
+	if migrator.HasIndex(&ChannelsChannelEvent{}, channelEventIDIndex) {
+		return nil
+	}
+
+	var channelIDs []string
+
+	tx := repo.gormDB.Model(&ChannelsChannelEvent{}).Distinct("channel_id").Where("event_id = 0").Pluck("channel_id", &channelIDs)
+
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	for _, channelID := range channelIDs {
+		err := repo.gormDB.Transaction(func(tx *gorm.DB) error {
+			var events []ChannelsChannelEvent
+
+			if err := tx.Select("id", "event_id").Where("channel_id = ?", channelID).Order("event_id <> 0, event_id, timestamp, id").Find(&events).Error; err != nil {
+				return err
+			}
+
+			var legacyCount uint64
+
+			for index, event := range events {
+				if event.EventID == 0 {
+					legacyCount++
+				}
+
+				if err := tx.Model(&ChannelsChannelEvent{}).Where("id = ?", event.ID).UpdateColumn("event_id", index+1).Error; err != nil {
+					return err
+				}
+			}
+
+			// Keep reads and deleted IDs pointing after the same events
+			if err := tx.Model(&ChannelsChannelRead{}).Where("channel_id = ? and event_id > 0", channelID).UpdateColumn("event_id", gorm.Expr("event_id + ?", legacyCount)).Error; err != nil {
+				return err
+			}
+
+			return tx.Model(&ChannelsChannel{}).Where("id = ? and last_event_id > 0", channelID).UpdateColumn("last_event_id", gorm.Expr("last_event_id + ?", legacyCount)).Error
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	table := repo.gormDB.NamingStrategy.TableName("ChannelsChannelEvent")
+
+	return repo.gormDB.Exec("CREATE UNIQUE INDEX ? ON ? (channel_id, event_id)", clause.Table{Name: channelEventIDIndex}, clause.Table{Name: table}).Error
+}
+
 func (repo *GormChannelRepository) CreateChannel(id string, appID string, name string, createdAt int64, isClosed bool, extra string, persistent bool, private bool, presence bool, push bool) error {
 	return repo.gormDB.Create(&ChannelsChannel{
 		ID:         id,
@@ -97,14 +198,194 @@ func (repo *GormChannelRepository) JoinClient(appID string, channelID string, cl
 }
 
 func (repo *GormChannelRepository) LeaveClient(appID string, channelID string, clientID string) error {
+	return repo.LeaveClients(appID, channelID, []string{clientID})
+}
+
+func (repo *GormChannelRepository) JoinClients(appID string, channelID string, clientIDs []string) error {
+	channel := ChannelsChannel{ID: channelID, AppID: appID}
+	clients := make([]ChannelsClient, 0, len(clientIDs))
+
+	for _, clientID := range clientIDs {
+		clients = append(clients, ChannelsClient{ID: clientID})
+	}
+
+	return repo.gormDB.Transaction(func(tx *gorm.DB) error {
+		return tx.Model(&channel).Association("Clients").Append(&clients)
+	})
+}
+
+func (repo *GormChannelRepository) LeaveClients(appID string, channelID string, clientIDs []string) error {
 	channel := ChannelsChannel{ID: channelID, AppID: appID}
-	return repo.gormDB.Model(&channel).Association("Clients").Delete(&ChannelsClient{ID: clientID})
+	clients := make([]ChannelsClient, 0, len(clientIDs))
+
+	for _, clientID := range clientIDs {
+		clients = append(clients, ChannelsClient{ID: clientID})
+	}
+
+	return repo.gormDB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&channel).Association("Clients").Delete(&clients); err != nil {
+			return err
+		}
+
+		return tx.Where("channel_id = ? AND client_id IN ?", channelID, clientIDs).Delete(&ChannelsChannelRole{}).Error
+	})
+}
+
+func (repo *GormChannelRepository) GetChannelClientsPage(appID string, channelID string, options core.ListOptions) ([]*core.ChannelClient, error) {
+	clients := make([]ChannelsClient, 0)
+
+	tx := repo.gormDB.Model(&ChannelsClient{}).
+		Where("app_id = ? AND id IN (?)", appID, repo.gormDB.Table("channel_client").Select("channels_client_id").Where("channels_channel_id = ?", channelID))
+
+	tx = paginate(tx, "id", "username", options).Find(&clients)
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	clientIDs := make([]string, 0, len(clients))
+
+	for _, c := range clients {
+		clientIDs = append(clientIDs, c.ID)
+	}
+
+	roles := make([]ChannelsChannelRole, 0)
+
+	if len(clientIDs) > 0 {
+		if err := repo.gormDB.Where("channel_id = ? AND client_id IN ?", channelID, clientIDs).Find(&roles).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	clientRoles := make(map[string]core.ChannelRole, len(roles))
+
+	for _, r := range roles {
+		clientRoles[r.ClientID] = core.ChannelRole(r.Role)
+	}
+
+	channelClients := make([]*core.ChannelClient, 0, len(clients))
+
+	for _, c := range clients {
+		role, found := clientRoles[c.ID]
+
+		if !found {
+			role = core.ChannelRoleMember
+		}
+
+		channelClients = append(channelClients, &core.ChannelClient{
+			Client: &core.Client{
+				ID:       c.ID,
+				Username: c.Username,
+				AppID:    c.AppID,
+				Extra:    c.Extra,
+			},
+			Role: role,
+		})
+	}
+
+	return channelClients, nil
+}
+
+func (repo *GormChannelRepository) GetChannelClientRole(appID string, channelID string, clientID string) (core.ChannelRole, error) {
+	var members int64
+
+	tx := repo.gormDB.Table("channel_client").Where("channels_channel_id = ? AND channels_client_id = ?", channelID, clientID).Count(&members)
+
+	if tx.Error != nil {
+		return "", tx.Error
+	}
+
+	if members == 0 {
+		return "", nil
+	}
+
+	var role ChannelsChannelRole
+
+	tx = repo.gormDB.Where("channel_id = ? AND client_id = ?", channelID, clientID).First(&role)
+
+	if tx.Error != nil {
+		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			return core.ChannelRoleMember, nil
+		}
+
+		return "", tx.Error
+	}
+
+	return core.ChannelRole(role.Role), nil
+}
+
+func (repo *GormChannelRepository) SetChannelClientRole(appID string, channelID string, clientID string, role core.ChannelRole) error {
+	return repo.gormDB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "channel_id"}, {Name: "client_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"role"}),
+	}).Create(&ChannelsChannelRole{
+		ChannelID: channelID,
+		ClientID:  clientID,
+		Role:      string(role),
+	}).Error
 }
 
 func (repo *GormChannelRepository) SetChannelCloseStatus(appID string, channelID string, isClosed bool) error {
 	return repo.gormDB.Model(&ChannelsChannel{ID: channelID, AppID: appID}).UpdateColumn("is_closed", isClosed).Error
 }
 
+func (repo *GormChannelRepository) UpdateChannel(appID string, channel *core.Channel) error {
+	return repo.gormDB.Model(&ChannelsChannel{ID: channel.ID, AppID: appID}).UpdateColumns(map[string]interface{}{
+		"name":       channel.Name,
+		"extra":      channel.Extra,
+		"persistent": channel.Persistent,
+		"private":    channel.Private,
+		"presence":   channel.Presence,
+		"push":       channel.Push,
+	}).Error
+}
+
+func (repo *GormChannelRepository) GetEventTypeRules(appID string, channelID string) ([]*core.EventTypeRule, error) {
+	stored := make([]ChannelsEventTypeRule, 0)
+
+	tx := repo.gormDB.Where("app_id = ? AND channel_id = ?", appID, channelID).Order("event_type").Find(&stored)
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	rules := make([]*core.EventTypeRule, 0, len(stored))
+
+	for _, rule := range stored {
+		rules = append(rules, &core.EventTypeRule{
+			EventType: rule.EventType,
+			Schema:    rule.PayloadSchema,
+		})
+	}
+
+	return rules, nil
+}
+
+func (repo *GormChannelRepository) SetEventTypeRules(appID string, channelID string, rules []*core.EventTypeRule) error {
+	stored := make([]ChannelsEventTypeRule, 0, len(rules))
+
+	for _, rule := range rules {
+		stored = append(stored, ChannelsEventTypeRule{
+			AppID:         appID,
+			ChannelID:     channelID,
+			EventType:     rule.EventType,
+			PayloadSchema: rule.Schema,
+		})
+	}
+
+	return repo.gormDB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("app_id = ? AND channel_id = ?", appID, channelID).Delete(&ChannelsEventTypeRule{}).Error; err != nil {
+			return err
+		}
+
+		if len(stored) == 0 {
+			return nil
+		}
+
+		return tx.Create(&stored).Error
+	})
+}
+
 func (repo *GormChannelRepository) GetAppChannel(appID string, channelID string) (*core.Channel, error) {
 	var channel ChannelsChannel
 
@@ -258,6 +539,47 @@ func (repo *GormChannelRepository) GetAppPublicChannels(appID string) ([]*core.C
 	return privChannels, nil
 }
 
+func (repo *GormChannelRepository) GetAppChannelsPage(appID string, private bool, options core.ListOptions) ([]*core.Channel, error) {
+	tx := repo.gormDB.Model(&ChannelsChannel{}).Where("app_id = ? AND private = ?", appID, private)
+
+	return repo.findChannelsPage(tx, options)
+}
+
+func (repo *GormChannelRepository) GetClientChannelsPage(clientID string, private bool, options core.ListOptions) ([]*core.Channel, error) {
+	tx := repo.gormDB.Model(&ChannelsChannel{}).
+		Where("private = ? AND id IN (?)", private, repo.gormDB.Table("channel_client").Select("channels_channel_id").Where("channels_client_id = ?", clientID))
+
+	return repo.findChannelsPage(tx, options)
+}
+
+func (repo *GormChannelRepository) findChannelsPage(tx *gorm.DB, options core.ListOptions) ([]*core.Channel, error) {
+	channels := make([]ChannelsChannel, 0)
+	tx = paginate(tx, "id", "name", options).Find(&channels)
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	pageChannels := make([]*core.Channel, 0, len(channels))
+
+	for _, c := range channels {
+		pageChannels = append(pageChannels, &core.Channel{
+			ID:         c.ID,
+			AppID:      c.AppID,
+			Name:       c.Name,
+			CreatedAt:  c.CreatedAt,
+			IsClosed:   c.IsClosed,
+			Extra:      c.Extra,
+			Persistent: c.Persistent,
+			Private:    c.Private,
+			Presence:   c.Presence,
+			Push:       c.Push,
+		})
+	}
+
+	return pageChannels, nil
+}
+
 func (repo *GormChannelRepository) ExistsAppChannel(appID string, channelID string) (bool, error) {
 	channel, err := repo.GetAppChannel(appID, channelID)
 
@@ -280,6 +602,7 @@ func (repo *GormChannelRepository) AddChannelEvent(appID string, channelID strin
 		TimeStamp: event.Timestamp,
 		Payload:   event.Payload,
 		ChannelID: channelID,
+		EventID:   event.ID,
 	}).Error
 }
 
@@ -294,6 +617,7 @@ func (repo *GormChannelRepository) AddChannelEvents(items []core.InsertItem) err
 			TimeStamp: item.Event.Timestamp,
 			Payload:   item.Event.Payload,
 			ChannelID: item.Event.ChannelID,
+			EventID:   item.Event.ID,
 		})
 	}
 
@@ -319,6 +643,7 @@ func (repo *GormChannelRepository) GetChannelEventsAfter(appID string, channelID
 			Payload:   e.Payload,
 			ChannelID: e.ChannelID,
 			Timestamp: e.TimeStamp,
+			ID:        e.EventID,
 		})
 	}
 
@@ -344,6 +669,7 @@ func (repo *GormChannelRepository) GetChannelEventsAfterAndBefore(appID string,
 			Payload:   e.Payload,
 			ChannelID: e.ChannelID,
 			Timestamp: e.TimeStamp,
+			ID:        e.EventID,
 		})
 	}
 
@@ -369,6 +695,7 @@ func (repo *GormChannelRepository) GetChannelLastEvents(appID string, channelID
 			Payload:   e.Payload,
 			ChannelID: e.ChannelID,
 			Timestamp: e.TimeStamp,
+			ID:        e.EventID,
 		})
 	}
 
@@ -394,6 +721,7 @@ func (repo *GormChannelRepository) GetChannelLastEventsAfter(appID string, chann
 			Payload:   e.Payload,
 			ChannelID: e.ChannelID,
 			Timestamp: e.TimeStamp,
+			ID:        e.EventID,
 		})
 	}
 
@@ -419,8 +747,190 @@ func (repo *GormChannelRepository) GetChannelLastEventsBefore(appID string, chan
 			Payload:   e.Payload,
 			ChannelID: e.ChannelID,
 			Timestamp: e.TimeStamp,
+			ID:        e.EventID,
+		})
+	}
+
+	return coreEvents, nil
+}
+
+func (repo *GormChannelRepository) GetChannelLastEventID(appID string, channelID string) (uint64, error) {
+	var eventID uint64
+	subQuery := repo.gormDB.Select("id").Where(map[string]interface{}{"app_id": appID, "channel_id": channelID})
+
+	tx := repo.gormDB.Model(&ChannelsChannelEvent{}).Select("COALESCE(MAX(event_id), 0)").Where("channel_id = ?", subQuery).Scan(&eventID)
+
+	if tx.Error != nil {
+		return 0, tx.Error
+	}
+
+	var deletedID uint64
+
+	tx = repo.gormDB.Model(&ChannelsChannel{}).Select("last_event_id").Where("id = ? and app_id = ?", channelID, appID).Scan(&deletedID)
+
+	if tx.Error != nil {
+		return 0, tx.Error
+	}
+
+	if deletedID > eventID {
+		return deletedID, nil
+	}
+
+	return eventID, nil
+}
+
+func (repo *GormChannelRepository) GetChannelEventsAfterID(appID string, channelID string, eventID uint64) ([]*core.ChannelEvent, error) {
+	events := make([]ChannelsChannelEvent, 0)
+	subQuery := repo.gormDB.Select("id").Where(map[string]interface{}{"app_id": appID, "channel_id": channelID})
+
+	tx := repo.gormDB.Where("channel_id = ? and event_id > ?", subQuery, eventID).Order("event_id asc").Find(&events)
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	coreEvents := make([]*core.ChannelEvent, 0, len(events))
+
+	for _, e := range events {
+		coreEvents = append(coreEvents, &core.ChannelEvent{
+			SenderID:  e.SenderID,
+			EventType: e.EventType,
+			Payload:   e.Payload,
+			ChannelID: e.ChannelID,
+			Timestamp: e.TimeStamp,
+			ID:        e.EventID,
+		})
+	}
+
+	return coreEvents, nil
+}
+
+func (repo *GormChannelRepository) GetChannelLastEventsAfterID(appID string, channelID string, amount int64, eventID uint64) ([]*core.ChannelEvent, error) {
+	events := make([]ChannelsChannelEvent, 0)
+	subQuery := repo.gormDB.Select("id").Where(map[string]interface{}{"app_id": appID, "channel_id": channelID})
+
+	tx := repo.gormDB.Where("channel_id = ? and event_id > ?", subQuery, eventID).Order("event_id asc").Limit(int(amount)).Find(&events)
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	coreEvents := make([]*core.ChannelEvent, 0, len(events))
+
+	for _, e := range events {
+		coreEvents = append(coreEvents, &core.ChannelEvent{
+			SenderID:  e.SenderID,
+			EventType: e.EventType,
+			Payload:   e.Payload,
+			ChannelID: e.ChannelID,
+			Timestamp: e.TimeStamp,
+			ID:        e.EventID,
 		})
 	}
 
 	return coreEvents, nil
 }
+
+func (repo *GormChannelRepository) GetChannelEvent(appID string, channelID string, eventID uint64) (*core.ChannelEvent, error) {
+	var event ChannelsChannelEvent
+
+	// Channel IDs are the primary key, so events don't need to be filtered by app
+	tx := repo.gormDB.Where("channel_id = ? and event_id = ?", channelID, eventID).First(&event)
+
+	if tx.Error != nil {
+		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, tx.Error
+	}
+
+	return &core.ChannelEvent{
+		SenderID:  event.SenderID,
+		EventType: event.EventType,
+		Payload:   event.Payload,
+		ChannelID: event.ChannelID,
+		Timestamp: event.TimeStamp,
+		ID:        event.EventID,
+	}, nil
+}
+
+func (repo *GormChannelRepository) UpdateChannelEvent(appID string, channelID string, eventID uint64, payload string) error {
+	return repo.gormDB.Model(&ChannelsChannelEvent{}).Where("channel_id = ? and event_id = ?", channelID, eventID).UpdateColumn("payload", payload).Error
+}
+
+func (repo *GormChannelRepository) DeleteChannelEvent(appID string, channelID string, eventID uint64) error {
+	tx := repo.gormDB.Model(&ChannelsChannel{}).Where("id = ? and app_id = ? and last_event_id < ?", channelID, appID, eventID).UpdateColumn("last_event_id", eventID)
+
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	return repo.gormDB.Where("channel_id = ? and event_id = ?", channelID, eventID).Delete(&ChannelsChannelEvent{}).Error
+}
+
+func (repo *GormChannelRepository) SetReadMarker(appID string, channelID string, clientID string, eventID uint64, timestamp int64) error {
+	return repo.gormDB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "channel_id"}, {Name: "client_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"event_id", "timestamp"}),
+	}).Create(&ChannelsChannelRead{
+		ChannelID: channelID,
+		ClientID:  clientID,
+		EventID:   eventID,
+		TimeStamp: timestamp,
+	}).Error
+}
+
+func (repo *GormChannelRepository) GetReadMarker(appID string, channelID string, clientID string) (*core.ReadMarker, error) {
+	var read ChannelsChannelRead
+
+	tx := repo.gormDB.Where("channel_id = ? and client_id = ?", channelID, clientID).First(&read)
+
+	if tx.Error != nil {
+		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, tx.Error
+	}
+
+	return &core.ReadMarker{
+		ClientID:  read.ClientID,
+		EventID:   read.EventID,
+		Timestamp: read.TimeStamp,
+	}, nil
+}
+
+func (repo *GormChannelRepository) GetReadMarkers(appID string, channelID string) ([]*core.ReadMarker, error) {
+	reads := make([]ChannelsChannelRead, 0)
+
+	tx := repo.gormDB.Where("channel_id = ?", channelID).Find(&reads)
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	markers := make([]*core.ReadMarker, 0, len(reads))
+
+	for _, read := range reads {
+		markers = append(markers, &core.ReadMarker{
+			ClientID:  read.ClientID,
+			EventID:   read.EventID,
+			Timestamp: read.TimeStamp,
+		})
+	}
+
+	return markers, nil
+}
+
+func (repo *GormChannelRepository) CountUnreadChannelEvents(appID string, channelID string, clientID string, eventID uint64) (uint64, error) {
+	var count int64
+
+	tx := repo.gormDB.Model(&ChannelsChannelEvent{}).Where("channel_id = ? and event_id > ? and sender_id <> ?", channelID, eventID, clientID).Count(&count)
+
+	if tx.Error != nil {
+		return 0, tx.Error
+	}
+
+	return uint64(count), nil
+}
@@ -169,3 +169,31 @@ func (repo *GormClientRepository) GetAllClients() ([]*core.Client, error) {
 
 	return coreClients, nil
 }
+
+func (repo *GormClientRepository) GetClientsPage(appID string, options core.ListOptions) ([]*core.Client, error) {
+	clients := make([]ChannelsClient, 0)
+	tx := repo.gormDB.Model(&ChannelsClient{})
+
+	if appID != "" {
+		tx = tx.Where("app_id = ?", appID)
+	}
+
+	tx = paginate(tx, "id", "username", options).Find(&clients)
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	coreClients := make([]*core.Client, 0, len(clients))
+
+	for _, c := range clients {
+		coreClients = append(coreClients, &core.Client{
+			ID:       c.ID,
+			Username: c.Username,
+			AppID:    c.AppID,
+			Extra:    c.Extra,
+		})
+	}
+
+	return coreClients, nil
+}
@@ -11,6 +11,7 @@ var appStorage *GormAppRepository = nil
 var clientStorage *GormClientRepository = nil
 var deviceStorage *GormDeviceRepository = nil
 var channelStorage *GormChannelRepository = nil
+var apiKeyStorage *GormAPIKeyRepository = nil
 
 type GormDatabaseStorage struct {
 	gormDB *gorm.DB
@@ -39,6 +40,10 @@ func (storage *GormDatabaseStorage) Migrate() {
 	if err := storage.GetChannelRepository().(*GormChannelRepository).Migrate(); err != nil {
 		log.Fatal(err)
 	}
+
+	if err := storage.GetAPIKeyRepository().(*GormAPIKeyRepository).Migrate(); err != nil {
+		log.Fatal(err)
+	}
 }
 
 func (storage *GormDatabaseStorage) GetDeviceRepository() core.DeviceRepository {
@@ -72,3 +77,11 @@ func (storage *GormDatabaseStorage) GetChannelRepository() core.ChannelRepositor
 
 	return channelStorage
 }
+
+func (storage *GormDatabaseStorage) GetAPIKeyRepository() core.APIKeyRepository {
+	if apiKeyStorage == nil {
+		apiKeyStorage = &GormAPIKeyRepository{gormDB: storage.gormDB}
+	}
+
+	return apiKeyStorage
+}
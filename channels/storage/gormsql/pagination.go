@@ -0,0 +1,49 @@
+package gormsql
+
+import (
+	"strings"
+
+	"github.com/lisomatrix/channels/channels/core"
+	"gorm.io/gorm"
+)
+
+// likeEscaper - Escape LIKE wildcards so name filters match them literally, with ! as escape character
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// paginate - Add the name filter, cursor, order and limit of the listing options to the query
+// Conditions are written without row comparisons and ILIKE so they work with every dialect
+func paginate(tx *gorm.DB, idColumn string, nameColumn string, options core.ListOptions) *gorm.DB {
+	// Rows without name are sorted as an empty one
+	nameSQL := "COALESCE(" + nameColumn + ", '')"
+
+	if options.Name != "" {
+		tx = tx.Where("LOWER("+nameSQL+") LIKE ? ESCAPE '!'", "%"+likeEscaper.Replace(strings.ToLower(options.Name))+"%")
+	}
+
+	comparison, direction := ">", " ASC"
+
+	if options.Descending {
+		comparison, direction = "<", " DESC"
+	}
+
+	if options.After != nil {
+		if options.SortByName() {
+			tx = tx.Where("("+nameSQL+" "+comparison+" ? OR ("+nameSQL+" = ? AND "+idColumn+" "+comparison+" ?))",
+				options.After.Name, options.After.Name, options.After.ID)
+		} else {
+			tx = tx.Where(idColumn+" "+comparison+" ?", options.After.ID)
+		}
+	}
+
+	if options.SortByName() {
+		tx = tx.Order(nameSQL + direction).Order(idColumn + direction)
+	} else {
+		tx = tx.Order(idColumn + direction)
+	}
+
+	if options.Limit > 0 {
+		tx = tx.Limit(options.Limit)
+	}
+
+	return tx
+}
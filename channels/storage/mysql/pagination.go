@@ -0,0 +1,57 @@
+package mysql
+
+import (
+	"strings"
+
+	"github.com/lisomatrix/channels/channels/core"
+)
+
+// likeEscaper - Escape LIKE wildcards so name filters match them literally, with ! as escape character
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// pageSQL - Append the name filter, cursor, order and limit of the listing options to a query ending in a WHERE clause
+// Name filters are case insensitive with the default collations
+func pageSQL(query string, args []interface{}, idColumn string, nameColumn string, options core.ListOptions) (string, []interface{}) {
+	var builder strings.Builder
+
+	builder.WriteString(strings.TrimSuffix(query, ";"))
+
+	// Rows without name are sorted as an empty one
+	nameSQL := "COALESCE(" + nameColumn + ", '')"
+
+	if options.Name != "" {
+		builder.WriteString(" AND " + nameSQL + " LIKE ? ESCAPE '!'")
+		args = append(args, "%"+likeEscaper.Replace(options.Name)+"%")
+	}
+
+	comparison, direction := ">", "ASC"
+
+	if options.Descending {
+		comparison, direction = "<", "DESC"
+	}
+
+	if options.After != nil {
+		if options.SortByName() {
+			builder.WriteString(" AND (" + nameSQL + ", " + idColumn + ") " + comparison + " (?, ?)")
+			args = append(args, options.After.Name, options.After.ID)
+		} else {
+			builder.WriteString(" AND " + idColumn + " " + comparison + " ?")
+			args = append(args, options.After.ID)
+		}
+	}
+
+	if options.SortByName() {
+		builder.WriteString(" ORDER BY " + nameSQL + " " + direction + ", " + idColumn + " " + direction)
+	} else {
+		builder.WriteString(" ORDER BY " + idColumn + " " + direction)
+	}
+
+	if options.Limit > 0 {
+		builder.WriteString(" LIMIT ?")
+		args = append(args, options.Limit)
+	}
+
+	builder.WriteString(";")
+
+	return builder.String(), args
+}
@@ -11,6 +11,7 @@ import (
 var createAppSQL = `INSERT INTO App(AppID, Name) VALUES ( ? , ? );`
 var deleteAppSQL = `DELETE FROM App WHERE AppID = ? ;`
 var getAppsSQL = `SELECT AppID, Name FROM App;`
+var getAppsPageSQL = `SELECT AppID, Name FROM App WHERE TRUE;`
 var getAppSQL = `SELECT AppID, Name FROM App WHERE AppID = ? ;`
 var updateAppSQL = `UPDATE App SET Name = ? WHERE AppID = ? ;`
 var appExistsSQL = `SELECT COUNT(AppID) AS "EXISTS" FROM App WHERE AppID = ? LIMIT 1;`
@@ -125,6 +126,44 @@ func (storage *AppRepository) GetApps() ([]*core.App, error) {
 	return apps, nil
 }
 
+// GetAppsPage - Get a page of the stored apps, filtered and sorted by name or ID
+func (storage *AppRepository) GetAppsPage(options core.ListOptions) ([]*core.App, error) {
+	query, args := pageSQL(getAppsPageSQL, nil, `AppID`, `Name`, options)
+
+	stmt, err := storage.dbHolder.db.Prepare(query)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetAppsPage: preparing statement failed: %v\n", err)
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	rows, err := stmt.Query(args...)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetAppsPage: query failed: %v\n", err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	apps := make([]*core.App, 0)
+
+	for rows.Next() {
+		app := &core.App{}
+
+		if err := rows.Scan(&app.AppID, &app.Name); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "GetAppsPage: row scan failed: %v\n", err)
+			return nil, err
+		}
+
+		apps = append(apps, app)
+	}
+
+	return apps, nil
+}
+
 // UpdateApp - Update App Row in the database
 func (storage *AppRepository) UpdateApp(id string, name string) error {
 	stmt, err := storage.dbHolder.db.Prepare(updateAppSQL)
@@ -16,7 +16,15 @@ var deleteChannelSQL = `DELETE FROM Channel WHERE ChannelID = ? AND AppID = ?;`
 var deleteAppChannelsSQL = `DELETE FROM Channel WHERE AppID = ?;`
 var joinChannelSQL = `INSERT INTO Channel_Client(clientID, channelID) VALUES (?, (SELECT ID FROM Channel WHERE ChannelID = ? AND AppID = ? LIMIT 1));`
 var leaveChannelSQL = `DELETE FROM Channel_Client WHERE channelID = (SELECT ID FROM Channel WHERE ChannelID = ? AND AppID = ? LIMIT 1) AND clientID = ?;`
+var joinChannelIgnoreSQL = `INSERT IGNORE INTO Channel_Client(clientID, channelID) VALUES (?, (SELECT ID FROM Channel WHERE ChannelID = ? AND AppID = ? LIMIT 1));`
+var selectChannelClientsPageSQL = `SELECT ID, Username, AppID, Extra, Role FROM Client JOIN Channel_Client ON clientID = ID WHERE channelID = (SELECT ID FROM Channel WHERE ChannelID = ? AND AppID = ? LIMIT 1);`
+var selectChannelClientRoleSQL = `SELECT Role FROM Channel_Client WHERE channelID = (SELECT ID FROM Channel WHERE ChannelID = ? AND AppID = ? LIMIT 1) AND clientID = ?;`
+var updateChannelClientRoleSQL = `UPDATE Channel_Client SET Role = ? WHERE channelID = (SELECT ID FROM Channel WHERE ChannelID = ? AND AppID = ? LIMIT 1) AND clientID = ?;`
 var setCloseStatusSQL = `UPDATE Channel SET IsClosed = ? WHERE ChannelID = ? AND AppID = ?;`
+var updateChannelSQL = `UPDATE Channel SET Name = ?, Extra = ?, Persistent = ?, Private = ?, Presence = ?, Push = ? WHERE ChannelID = ? AND AppID = ?;`
+var selectEventTypeRulesSQL = `SELECT EventType, PayloadSchema FROM Event_Type_Rule WHERE AppID = ? AND ChannelID = ? ORDER BY EventType;`
+var deleteEventTypeRulesSQL = `DELETE FROM Event_Type_Rule WHERE AppID = ? AND ChannelID = ?;`
+var insertEventTypeRuleSQL = `INSERT INTO Event_Type_Rule(AppID, ChannelID, EventType, PayloadSchema) VALUES (?, ?, ?, ?);`
 var selectClientAllowedChannelsSQL = `SELECT ChannelID FROM Channel WHERE ID IN (SELECT channelID FROM Channel_Client WHERE clientID = ?);`
 var selectClientOpenOrPrivateChannels = `SELECT ChannelID, AppID, Name, Created_At, IsClosed, Extra, Persistent, Private, Presence, Push FROM Channel WHERE Private = ? AND ID IN (SELECT channelID FROM Channel_Client WHERE clientID = ?);`
 var selectOpenOrPrivateAppChannels = `SELECT ChannelID, AppID, Name, Created_At, IsClosed, Extra, Persistent, Private, Presence, Push FROM Channel WHERE Private = ? AND AppID = ?;`
@@ -27,16 +35,29 @@ var selectAllOpenOrPrivateChannels = `SELECT ChannelID, AppID, Name, Created_At,
 var selectAllChannels = `SELECT ChannelID, AppID, Name, Created_At, IsClosed, Extra, Persistent, Private, Presence, Push FROM Channel;`
 var selectAllChannelsAmount = `SELECT COUNT(ChannelID) FROM Channel`
 var selectAppChannel = `SELECT ChannelID, AppID, Name, Created_At, IsClosed, Extra, Persistent, Private, Presence, Push FROM Channel WHERE AppID = ? AND ChannelID = ?;`
-var addChannelEventSQL = `INSERT INTO Channel_Event(SenderID, EventType, Payload, ChannelID, TimeStamp) VALUES ( ? , ? , ? , (SELECT ID FROM Channel WHERE ChannelID = ? AND AppID = ? LIMIT 1) , ? );`
+var addChannelEventSQL = `INSERT INTO Channel_Event(SenderID, EventType, Payload, ChannelID, TimeStamp, EventID) VALUES ( ? , ? , ? , (SELECT ID FROM Channel WHERE ChannelID = ? AND AppID = ? LIMIT 1) , ? , ? );`
 
-var selectEventsSinceTimeStampSQL = `SELECT SenderID, EventType, Payload, TimeStamp FROM Channel_Event WHERE ChannelID = (SELECT ID FROM Channel WHERE ChannelID = ? AND AppID = ?) AND TimeStamp >= ?;`
-var selectEventsBetweenTimeStampsSQL = `SELECT SenderID, EventType, Payload, TimeStamp FROM Channel_Event WHERE ChannelID = (SELECT ID FROM Channel WHERE ChannelID = ? AND AppID = ?) AND TimeStamp >= ? AND TimeStamp <= ?;`
+var selectEventsSinceTimeStampSQL = `SELECT SenderID, EventType, Payload, TimeStamp, EventID FROM Channel_Event WHERE ChannelID = (SELECT ID FROM Channel WHERE ChannelID = ? AND AppID = ?) AND TimeStamp >= ?;`
+var selectEventsBetweenTimeStampsSQL = `SELECT SenderID, EventType, Payload, TimeStamp, EventID FROM Channel_Event WHERE ChannelID = (SELECT ID FROM Channel WHERE ChannelID = ? AND AppID = ?) AND TimeStamp >= ? AND TimeStamp <= ?;`
 
 // * The new one is based on primary key since its auto incremented to it's way faster
-var selectLastEventsSQL = `SELECT SenderID, EventType, Payload, TimeStamp FROM Channel_Event WHERE ChannelID = (SELECT ID FROM Channel WHERE ChannelID = ? AND AppID = ?) ORDER BY ID DESC LIMIT ?;`
-
-var selectLastEventsSinceTimeStampSQL = `SELECT SenderID, EventType, Payload, TimeStamp FROM (SELECT SenderID, EventType, Payload, TimeStamp FROM Channel_Event WHERE ChannelID = (SELECT ID FROM Channel WHERE ChannelID = ? AND AppID = ?) AND TimeStamp >= ?) as t ORDER BY TimeStamp ASC LIMIT ?;`
-var selectLastEventsBeforeTimeStampSQL = `SELECT SenderID, EventType, Payload, TimeStamp FROM (SELECT SenderID, EventType, Payload, TimeStamp FROM Channel_Event WHERE ChannelID = (SELECT ID FROM Channel WHERE ChannelID = ? AND AppID = ?) AND TimeStamp <= ?) as t ORDER BY TimeStamp DESC LIMIT ?;`
+var selectLastEventsSQL = `SELECT SenderID, EventType, Payload, TimeStamp, EventID FROM Channel_Event WHERE ChannelID = (SELECT ID FROM Channel WHERE ChannelID = ? AND AppID = ?) ORDER BY ID DESC LIMIT ?;`
+
+var selectLastEventsSinceTimeStampSQL = `SELECT SenderID, EventType, Payload, TimeStamp, EventID FROM (SELECT SenderID, EventType, Payload, TimeStamp, EventID FROM Channel_Event WHERE ChannelID = (SELECT ID FROM Channel WHERE ChannelID = ? AND AppID = ?) AND TimeStamp >= ?) as t ORDER BY TimeStamp ASC LIMIT ?;`
+var selectLastEventIDSQL = `SELECT GREATEST(COALESCE(MAX(EventID), 0), COALESCE((SELECT LastEventID FROM Channel WHERE ChannelID = ? AND AppID = ?), 0)) FROM Channel_Event WHERE ChannelID = (SELECT ID FROM Channel WHERE ChannelID = ? AND AppID = ?);`
+var selectEventsAfterEventIDSQL = `SELECT SenderID, EventType, Payload, TimeStamp, EventID FROM Channel_Event WHERE ChannelID = (SELECT ID FROM Channel WHERE ChannelID = ? AND AppID = ?) AND EventID > ? ORDER BY EventID ASC;`
+var selectLastEventsAfterEventIDSQL = `SELECT SenderID, EventType, Payload, TimeStamp, EventID FROM Channel_Event WHERE ChannelID = (SELECT ID FROM Channel WHERE ChannelID = ? AND AppID = ?) AND EventID > ? ORDER BY EventID ASC LIMIT ?;`
+var selectLastEventsBeforeTimeStampSQL = `SELECT SenderID, EventType, Payload, TimeStamp, EventID FROM (SELECT SenderID, EventType, Payload, TimeStamp, EventID FROM Channel_Event WHERE ChannelID = (SELECT ID FROM Channel WHERE ChannelID = ? AND AppID = ?) AND TimeStamp <= ?) as t ORDER BY TimeStamp DESC LIMIT ?;`
+var selectEventByIDSQL = `SELECT SenderID, EventType, Payload, TimeStamp, EventID FROM Channel_Event WHERE ChannelID = (SELECT ID FROM Channel WHERE ChannelID = ? AND AppID = ?) AND EventID = ? LIMIT 1;`
+var updateEventPayloadSQL = `UPDATE Channel_Event SET Payload = ? WHERE ChannelID = (SELECT ID FROM Channel WHERE ChannelID = ? AND AppID = ?) AND EventID = ?;`
+var deleteEventSQL = `DELETE FROM Channel_Event WHERE ChannelID = (SELECT ID FROM Channel WHERE ChannelID = ? AND AppID = ?) AND EventID = ?;`
+var updateLastEventIDSQL = `UPDATE Channel SET LastEventID = ? WHERE ChannelID = ? AND AppID = ? AND LastEventID < ?;`
+
+// Read marker SQL
+var setReadMarkerSQL = `INSERT INTO Channel_Read(ChannelID, ClientID, EventID, TimeStamp) VALUES ((SELECT ID FROM Channel WHERE ChannelID = ? AND AppID = ? LIMIT 1), ?, ?, ?) ON DUPLICATE KEY UPDATE EventID = VALUES(EventID), TimeStamp = VALUES(TimeStamp);`
+var selectReadMarkerSQL = `SELECT ClientID, EventID, TimeStamp FROM Channel_Read WHERE ChannelID = (SELECT ID FROM Channel WHERE ChannelID = ? AND AppID = ?) AND ClientID = ?;`
+var selectReadMarkersSQL = `SELECT ClientID, EventID, TimeStamp FROM Channel_Read WHERE ChannelID = (SELECT ID FROM Channel WHERE ChannelID = ? AND AppID = ?);`
+var countUnreadEventsSQL = `SELECT COUNT(*) FROM Channel_Event WHERE ChannelID = (SELECT ID FROM Channel WHERE ChannelID = ? AND AppID = ?) AND EventID > ? AND SenderID <> ?;`
 
 // NewSQLChannelRepository - Create a new instance of SQLChannelRepository
 func NewSQLChannelRepository(db *DatabaseStorage) *ChannelRepository {
@@ -196,6 +217,150 @@ func (repo *ChannelRepository) LeaveClient(appID string, channelID string, clien
 	return nil
 }
 
+// JoinClients - Add clients to channel in a single transaction, clients already in the channel are ignored
+func (repo *ChannelRepository) JoinClients(appID string, channelID string, clientIDs []string) error {
+	return repo.execClientsTransaction("JoinClients", joinChannelIgnoreSQL, clientIDs, func(clientID string) []interface{} {
+		return []interface{}{clientID, channelID, appID}
+	})
+}
+
+// LeaveClients - Remove clients from channel in a single transaction
+func (repo *ChannelRepository) LeaveClients(appID string, channelID string, clientIDs []string) error {
+	return repo.execClientsTransaction("LeaveClients", leaveChannelSQL, clientIDs, func(clientID string) []interface{} {
+		return []interface{}{channelID, appID, clientID}
+	})
+}
+
+// execClientsTransaction - Run the statement for each client in a single transaction, method is used in logs
+func (repo *ChannelRepository) execClientsTransaction(method string, query string, clientIDs []string, args func(clientID string) []interface{}) error {
+	tx, err := repo.dbHolder.db.Begin()
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "%s: failed to begin transaction: %v\n", method, err)
+		return err
+	}
+
+	stmt, err := tx.Prepare(query)
+
+	if err != nil {
+		_ = tx.Rollback()
+		_, _ = fmt.Fprintf(os.Stderr, "%s: preparing statement failed: %v\n", method, err)
+		return err
+	}
+
+	defer stmt.Close()
+
+	for _, clientID := range clientIDs {
+		if _, err = stmt.Exec(args(clientID)...); err != nil {
+			_ = tx.Rollback()
+			_, _ = fmt.Fprintf(os.Stderr, "%s: statement execution failed: %v\n", method, err)
+			return err
+		}
+	}
+
+	err = tx.Commit()
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "%s: failed to commit transaction: %v\n", method, err)
+		return err
+	}
+
+	return nil
+}
+
+// GetChannelClientsPage - Get a page of the channel clients, filtered and sorted by username or ID
+func (repo *ChannelRepository) GetChannelClientsPage(appID string, channelID string, options core.ListOptions) ([]*core.ChannelClient, error) {
+	query, args := pageSQL(selectChannelClientsPageSQL, []interface{}{channelID, appID}, `ID`, `Username`, options)
+
+	stmt, err := repo.dbHolder.db.Prepare(query)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetChannelClientsPage: preparing statement failed: %v\n", err)
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	rows, err := stmt.Query(args...)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetChannelClientsPage: query failed: %v\n", err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	clients := make([]*core.ChannelClient, 0)
+
+	for rows.Next() {
+		client := &core.Client{}
+		var role string
+
+		if err := rows.Scan(&client.ID, &client.Username, &client.AppID, &client.Extra, &role); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "GetChannelClientsPage: row scan failed: %v\n", err)
+			return nil, err
+		}
+
+		clients = append(clients, &core.ChannelClient{Client: client, Role: core.ChannelRole(role)})
+	}
+
+	return clients, nil
+}
+
+// GetChannelClientRole - Get the client role in the channel, empty if the client isn't in the channel
+func (repo *ChannelRepository) GetChannelClientRole(appID string, channelID string, clientID string) (core.ChannelRole, error) {
+	stmt, err := repo.dbHolder.db.Prepare(selectChannelClientRoleSQL)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetChannelClientRole: preparing statement failed: %v\n", err)
+		return "", err
+	}
+
+	defer stmt.Close()
+
+	rows, err := stmt.Query(channelID, appID, clientID)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetChannelClientRole: query failed: %v\n", err)
+		return "", err
+	}
+
+	defer rows.Close()
+
+	if !rows.Next() {
+		return "", rows.Err()
+	}
+
+	var role string
+
+	if err := rows.Scan(&role); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetChannelClientRole: row scan failed: %v\n", err)
+		return "", err
+	}
+
+	return core.ChannelRole(role), nil
+}
+
+// SetChannelClientRole - Set the client role in the channel
+func (repo *ChannelRepository) SetChannelClientRole(appID string, channelID string, clientID string, role core.ChannelRole) error {
+	stmt, err := repo.dbHolder.db.Prepare(updateChannelClientRoleSQL)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "SetChannelClientRole: preparing statement failed: %v\n", err)
+		return err
+	}
+
+	defer stmt.Close()
+
+	_, err = stmt.Exec(string(role), channelID, appID, clientID)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "SetChannelClientRole: statement execution failed: %v\n", err)
+		return err
+	}
+
+	return nil
+}
 // SetChannelCloseStatus - Set channel closed or open
 func (repo *ChannelRepository) SetChannelCloseStatus(appID string, channelID string, isClosed bool) error {
 	stmt, err := repo.dbHolder.db.Prepare(setCloseStatusSQL)
@@ -217,6 +382,106 @@ func (repo *ChannelRepository) SetChannelCloseStatus(appID string, channelID str
 	return nil
 }
 
+// UpdateChannel - Update channel name, extra, persistent, private, presence and push
+func (repo *ChannelRepository) UpdateChannel(appID string, channel *core.Channel) error {
+	stmt, err := repo.dbHolder.db.Prepare(updateChannelSQL)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "UpdateChannel: preparing statement failed: %v\n", err)
+		return err
+	}
+
+	defer stmt.Close()
+
+	_, err = stmt.Exec(channel.Name, channel.Extra, channel.Persistent, channel.Private, channel.Presence, channel.Push, channel.ID, appID)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "UpdateChannel: statement execution failed: %v\n", err)
+		return err
+	}
+
+	return nil
+}
+
+// GetEventTypeRules - Get the event type rules of the channel, or of the app if channelID is empty
+func (repo *ChannelRepository) GetEventTypeRules(appID string, channelID string) ([]*core.EventTypeRule, error) {
+	stmt, err := repo.dbHolder.db.Prepare(selectEventTypeRulesSQL)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetEventTypeRules: preparing statement failed: %v\n", err)
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	rows, err := stmt.Query(appID, channelID)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetEventTypeRules: query failed: %v\n", err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	rules := make([]*core.EventTypeRule, 0)
+
+	for rows.Next() {
+		rule := &core.EventTypeRule{}
+
+		if err := rows.Scan(&rule.EventType, &rule.Schema); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "GetEventTypeRules: row scan failed: %v\n", err)
+			return nil, err
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, rows.Err()
+}
+
+// SetEventTypeRules - Replace the event type rules of the channel, or of the app if channelID is empty, in a single transaction
+func (repo *ChannelRepository) SetEventTypeRules(appID string, channelID string, rules []*core.EventTypeRule) error {
+	tx, err := repo.dbHolder.db.Begin()
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "SetEventTypeRules: failed to begin transaction: %v\n", err)
+		return err
+	}
+
+	if _, err = tx.Exec(deleteEventTypeRulesSQL, appID, channelID); err != nil {
+		_ = tx.Rollback()
+		_, _ = fmt.Fprintf(os.Stderr, "SetEventTypeRules: statement execution failed: %v\n", err)
+		return err
+	}
+
+	stmt, err := tx.Prepare(insertEventTypeRuleSQL)
+
+	if err != nil {
+		_ = tx.Rollback()
+		_, _ = fmt.Fprintf(os.Stderr, "SetEventTypeRules: preparing statement failed: %v\n", err)
+		return err
+	}
+
+	defer stmt.Close()
+
+	for _, rule := range rules {
+		if _, err = stmt.Exec(appID, channelID, rule.EventType, rule.Schema); err != nil {
+			_ = tx.Rollback()
+			_, _ = fmt.Fprintf(os.Stderr, "SetEventTypeRules: statement execution failed: %v\n", err)
+			return err
+		}
+	}
+
+	err = tx.Commit()
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "SetEventTypeRules: failed to commit transaction: %v\n", err)
+		return err
+	}
+
+	return nil
+}
+
 // GetClientAllowedChannels - Get all allowed channels for the given client, including public and private
 func (repo *ChannelRepository) GetClientAllowedChannels(clientID string) ([]string, error) {
 	stmt, err := repo.dbHolder.db.Prepare(selectClientAllowedChannelsSQL)
@@ -423,6 +688,56 @@ func (repo *ChannelRepository) GetAppPrivateChannels(appID string) ([]*core.Chan
 	return channels, nil
 }
 
+// GetAppChannelsPage - Get a page of the app private or public channels, filtered and sorted by name or ID
+func (repo *ChannelRepository) GetAppChannelsPage(appID string, private bool, options core.ListOptions) ([]*core.Channel, error) {
+	query, args := pageSQL(selectOpenOrPrivateAppChannels, []interface{}{private, appID}, `ChannelID`, `Name`, options)
+
+	return repo.queryChannelsPage("GetAppChannelsPage", query, args)
+}
+
+// GetClientChannelsPage - Get a page of the private or public channels the client joined, filtered and sorted by name or ID
+func (repo *ChannelRepository) GetClientChannelsPage(clientID string, private bool, options core.ListOptions) ([]*core.Channel, error) {
+	query, args := pageSQL(selectClientOpenOrPrivateChannels, []interface{}{private, clientID}, `ChannelID`, `Name`, options)
+
+	return repo.queryChannelsPage("GetClientChannelsPage", query, args)
+}
+
+// queryChannelsPage - Run a channels page query, method is used in logs
+func (repo *ChannelRepository) queryChannelsPage(method string, query string, args []interface{}) ([]*core.Channel, error) {
+	stmt, err := repo.dbHolder.db.Prepare(query)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "%s: preparing statement failed: %v\n", method, err)
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	rows, err := stmt.Query(args...)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "%s: query failed: %v\n", method, err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	channels := make([]*core.Channel, 0)
+
+	for rows.Next() {
+		chann, err := repo.rowToChannel(rows)
+
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "%s: row scan failed: %v\n", method, err)
+			return nil, err
+		}
+
+		channels = append(channels, chann)
+	}
+
+	return channels, nil
+}
+
 // GetAppPublicChannels - Get all app public channels without joined users
 func (repo *ChannelRepository) GetAppPublicChannels(appID string) ([]*core.Channel, error) {
 	stmt, err := repo.dbHolder.db.Prepare(selectOpenOrPrivateAppChannels)
@@ -628,8 +943,44 @@ func (repo *ChannelRepository) GetAllChannelsCount() (uint64, error) {
 	return amount, nil
 }
 
-// AddChannelEvents - UNSUPPORTED
+// AddChannelEvents - Add a batch of events in a single transaction
 func (repo *ChannelRepository) AddChannelEvents(items []core.InsertItem) error {
+	tx, err := repo.dbHolder.db.Begin()
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "AddChannelEvents: failed to begin transaction: %v\n", err)
+		return err
+	}
+
+	stmt, err := tx.Prepare(addChannelEventSQL)
+
+	if err != nil {
+		_ = tx.Rollback()
+		_, _ = fmt.Fprintf(os.Stderr, "AddChannelEvents: preparing statement failed: %v\n", err)
+		return err
+	}
+
+	defer stmt.Close()
+
+	for _, item := range items {
+		event := item.Event
+
+		_, err = stmt.Exec(event.SenderID, event.EventType, event.Payload, event.ChannelID, item.AppID, event.Timestamp, event.ID)
+
+		if err != nil {
+			_ = tx.Rollback()
+			_, _ = fmt.Fprintf(os.Stderr, "AddChannelEvents: statement execution failed: %v\n", err)
+			return err
+		}
+	}
+
+	err = tx.Commit()
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "AddChannelEvents: failed to commit transaction: %v\n", err)
+		return err
+	}
+
 	return nil
 }
 
@@ -643,7 +994,7 @@ func (repo *ChannelRepository) AddChannelEvent(appID string, channelID string, e
 		return err
 	}
 
-	_, err = stmt.Exec(event.SenderID, event.EventType, event.Payload, channelID, appID, event.Timestamp)
+	_, err = stmt.Exec(event.SenderID, event.EventType, event.Payload, channelID, appID, event.Timestamp, event.ID)
 
 	if err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, "AddChannelEvent: statement execution failed: %v\n", err)
@@ -825,6 +1176,303 @@ func (repo *ChannelRepository) GetChannelLastEvents(appID string, channelID stri
 	return channelEvents, nil
 }
 
+// GetChannelLastEventID - Get the highest event ID stored or deleted for the channel, 0 if it has none
+func (repo *ChannelRepository) GetChannelLastEventID(appID string, channelID string) (uint64, error) {
+	stmt, err := repo.dbHolder.db.Prepare(selectLastEventIDSQL)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetChannelLastEventID: preparing statement failed: %v\n", err)
+		return 0, err
+	}
+
+	defer stmt.Close()
+
+	var eventID uint64
+
+	err = stmt.QueryRow(channelID, appID, channelID, appID).Scan(&eventID)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetChannelLastEventID: row scan failed: %v\n", err)
+		return 0, err
+	}
+
+	return eventID, nil
+}
+
+// GetChannelEventsAfterID - Get all events after given event ID
+func (repo *ChannelRepository) GetChannelEventsAfterID(appID string, channelID string, eventID uint64) ([]*core.ChannelEvent, error) {
+	stmt, err := repo.dbHolder.db.Prepare(selectEventsAfterEventIDSQL)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetChannelEventsAfterID: preparing statement failed: %v\n", err)
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	rows, err := stmt.Query(channelID, appID, eventID)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetChannelEventsAfterID: query failed: %v\n", err)
+		return nil, err
+	}
+
+	channelEvents := make([]*core.ChannelEvent, 0)
+
+	for rows.Next() {
+		event, err := repo.rowToChannelEvent(channelID, rows)
+
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "GetChannelEventsAfterID: row scan failed: %v\n", err)
+			return nil, err
+		}
+
+		channelEvents = append(channelEvents, event)
+	}
+
+	return channelEvents, nil
+}
+
+// GetChannelLastEventsAfterID - Get an given amount events after given event ID
+func (repo *ChannelRepository) GetChannelLastEventsAfterID(appID string, channelID string, amount int64, eventID uint64) ([]*core.ChannelEvent, error) {
+	stmt, err := repo.dbHolder.db.Prepare(selectLastEventsAfterEventIDSQL)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetChannelLastEventsAfterID: preparing statement failed: %v\n", err)
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	rows, err := stmt.Query(channelID, appID, eventID, amount)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetChannelLastEventsAfterID: query failed: %v\n", err)
+		return nil, err
+	}
+
+	channelEvents := make([]*core.ChannelEvent, 0)
+
+	for rows.Next() {
+		event, err := repo.rowToChannelEvent(channelID, rows)
+
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "GetChannelLastEventsAfterID: row scan failed: %v\n", err)
+			return nil, err
+		}
+
+		channelEvents = append(channelEvents, event)
+	}
+
+	return channelEvents, nil
+}
+
+// GetChannelEvent - Get the event with the given event ID, nil if it doesn't exist
+func (repo *ChannelRepository) GetChannelEvent(appID string, channelID string, eventID uint64) (*core.ChannelEvent, error) {
+	stmt, err := repo.dbHolder.db.Prepare(selectEventByIDSQL)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetChannelEvent: preparing statement failed: %v\n", err)
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	rows, err := stmt.Query(channelID, appID, eventID)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetChannelEvent: query failed: %v\n", err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+
+	event, err := repo.rowToChannelEvent(channelID, rows)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetChannelEvent: row scan failed: %v\n", err)
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// UpdateChannelEvent - Replace the payload of the event with the given event ID
+func (repo *ChannelRepository) UpdateChannelEvent(appID string, channelID string, eventID uint64, payload string) error {
+	stmt, err := repo.dbHolder.db.Prepare(updateEventPayloadSQL)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "UpdateChannelEvent: preparing statement failed: %v\n", err)
+		return err
+	}
+
+	defer stmt.Close()
+
+	_, err = stmt.Exec(payload, channelID, appID, eventID)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "UpdateChannelEvent: statement execution failed: %v\n", err)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteChannelEvent - Remove the event with the given event ID, remembering it so the ID isn't given again
+func (repo *ChannelRepository) DeleteChannelEvent(appID string, channelID string, eventID uint64) error {
+	updateStmt, err := repo.dbHolder.db.Prepare(updateLastEventIDSQL)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "DeleteChannelEvent: preparing statement failed: %v\n", err)
+		return err
+	}
+
+	defer updateStmt.Close()
+
+	_, err = updateStmt.Exec(eventID, channelID, appID, eventID)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "DeleteChannelEvent: last event ID update failed: %v\n", err)
+		return err
+	}
+
+	stmt, err := repo.dbHolder.db.Prepare(deleteEventSQL)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "DeleteChannelEvent: preparing statement failed: %v\n", err)
+		return err
+	}
+
+	defer stmt.Close()
+
+	_, err = stmt.Exec(channelID, appID, eventID)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "DeleteChannelEvent: statement execution failed: %v\n", err)
+		return err
+	}
+
+	return nil
+}
+
+// SetReadMarker - Store the last event read by the client, replacing the previous one
+func (repo *ChannelRepository) SetReadMarker(appID string, channelID string, clientID string, eventID uint64, timestamp int64) error {
+	stmt, err := repo.dbHolder.db.Prepare(setReadMarkerSQL)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "SetReadMarker: preparing statement failed: %v\n", err)
+		return err
+	}
+
+	defer stmt.Close()
+
+	_, err = stmt.Exec(channelID, appID, clientID, eventID, timestamp)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "SetReadMarker: statement execution failed: %v\n", err)
+		return err
+	}
+
+	return nil
+}
+
+// GetReadMarker - Get the last event read by the client, nil if there is none
+func (repo *ChannelRepository) GetReadMarker(appID string, channelID string, clientID string) (*core.ReadMarker, error) {
+	stmt, err := repo.dbHolder.db.Prepare(selectReadMarkerSQL)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetReadMarker: preparing statement failed: %v\n", err)
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	rows, err := stmt.Query(channelID, appID, clientID)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetReadMarker: query failed: %v\n", err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+
+	marker := &core.ReadMarker{}
+
+	if err := rows.Scan(&marker.ClientID, &marker.EventID, &marker.Timestamp); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetReadMarker: row scan failed: %v\n", err)
+		return nil, err
+	}
+
+	return marker, nil
+}
+
+// GetReadMarkers - Get the last event read by each client of the channel
+func (repo *ChannelRepository) GetReadMarkers(appID string, channelID string) ([]*core.ReadMarker, error) {
+	stmt, err := repo.dbHolder.db.Prepare(selectReadMarkersSQL)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetReadMarkers: preparing statement failed: %v\n", err)
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	rows, err := stmt.Query(channelID, appID)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetReadMarkers: query failed: %v\n", err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	markers := make([]*core.ReadMarker, 0)
+
+	for rows.Next() {
+		marker := &core.ReadMarker{}
+
+		if err := rows.Scan(&marker.ClientID, &marker.EventID, &marker.Timestamp); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "GetReadMarkers: row scan failed: %v\n", err)
+			return nil, err
+		}
+
+		markers = append(markers, marker)
+	}
+
+	return markers, nil
+}
+
+// CountUnreadChannelEvents - Count the events after the given event ID not sent by the client
+func (repo *ChannelRepository) CountUnreadChannelEvents(appID string, channelID string, clientID string, eventID uint64) (uint64, error) {
+	stmt, err := repo.dbHolder.db.Prepare(countUnreadEventsSQL)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "CountUnreadChannelEvents: preparing statement failed: %v\n", err)
+		return 0, err
+	}
+
+	defer stmt.Close()
+
+	var count uint64
+
+	err = stmt.QueryRow(channelID, appID, eventID, clientID).Scan(&count)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "CountUnreadChannelEvents: row scan failed: %v\n", err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
 // rowToChannelEvent - Small helper to keep code cleaner
 func (repo *ChannelRepository) rowToChannelEvent(channelID string, rows *sql.Rows) (*core.ChannelEvent, error) {
 	//var id string
@@ -833,9 +1481,10 @@ func (repo *ChannelRepository) rowToChannelEvent(channelID string, rows *sql.Row
 	var payload string
 	//var channelID string
 	var timestamp int64
+	var eventID uint64
 
 	//err := rows.Scan(&id, &senderID, &eventType, &payload, &channelID, &timestamp)
-	err := rows.Scan(&senderID, &eventType, &payload, &timestamp)
+	err := rows.Scan(&senderID, &eventType, &payload, &timestamp, &eventID)
 
 	channEvent := &core.ChannelEvent{
 		SenderID:  senderID,
@@ -843,6 +1492,7 @@ func (repo *ChannelRepository) rowToChannelEvent(channelID string, rows *sql.Row
 		Payload:   payload,
 		ChannelID: channelID,
 		Timestamp: timestamp,
+		ID:        eventID,
 	}
 
 	/*
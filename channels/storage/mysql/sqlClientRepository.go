@@ -19,6 +19,8 @@ var selectAppClientSQL = `SELECT ID, Username, AppID, Extra FROM Client WHERE Ap
 var selectAppClientExistsSQL = `SELECT COUNT(ID) FROM Client WHERE AppID = ? AND ID = ? LIMIT 1;`
 var selectAppClientsAmountSQL = `SELECT COUNT(ID) FROM Client WHERE AppID = ?;`
 var selectAllClientsSQL = `SELECT ID, Username, AppID, Extra FROM Client;`
+var selectAppClientsPageSQL = `SELECT ID, Username, AppID, Extra FROM Client WHERE AppID = ?;`
+var selectAllClientsPageSQL = `SELECT ID, Username, AppID, Extra FROM Client WHERE TRUE;`
 var selectAllClientsAmountSQL = `SELECT COUNT(ID) FROM Client;`
 var selectClientExtraSQL = `SELECT Extra FROM Client WHERE ID = ?;`
 
@@ -317,6 +319,48 @@ func (repo *ClientRepository) GetAppClientsCount(appID string) (uint64, error) {
 	return amount, nil
 }
 
+// GetClientsPage - Get a page of the app clients, or of all clients if no app is given, filtered and sorted by username or ID
+func (repo *ClientRepository) GetClientsPage(appID string, options core.ListOptions) ([]*core.Client, error) {
+	query, args := pageSQL(selectAllClientsPageSQL, nil, `ID`, `Username`, options)
+
+	if appID != "" {
+		query, args = pageSQL(selectAppClientsPageSQL, []interface{}{appID}, `ID`, `Username`, options)
+	}
+
+	stmt, err := repo.dbHolder.db.Prepare(query)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetClientsPage: preparing statement failed: %v\n", err)
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	rows, err := stmt.Query(args...)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetClientsPage: query failed: %v\n", err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	clients := make([]*core.Client, 0)
+
+	for rows.Next() {
+		client := &core.Client{}
+
+		if err := rows.Scan(&client.ID, &client.Username, &client.AppID, &client.Extra); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "GetClientsPage: row scan failed: %v\n", err)
+			return nil, err
+		}
+
+		clients = append(clients, client)
+	}
+
+	return clients, nil
+}
+
 // GetAllClients - Get all Apps clients
 func (repo *ClientRepository) GetAllClients() ([]*core.Client, error) {
 	stmt, err := repo.dbHolder.db.Prepare(selectAllClientsSQL)
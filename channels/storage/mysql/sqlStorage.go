@@ -19,6 +19,7 @@ var appStorage *AppRepository = nil
 var clientStorage *ClientRepository = nil
 var channelStorage *ChannelRepository = nil
 var deviceStorage *DeviceRepository = nil
+var apiKeyStorage *APIKeyRepository = nil
 
 func (storage *DatabaseStorage) GetDB() *sql.DB {
 	return storage.db
@@ -64,6 +65,16 @@ func (storage *DatabaseStorage) GetChannelRepository() core.ChannelRepository {
 	return channelStorage
 }
 
+// GetAPIKeyRepository - Get SQL implementation of APIKeyRepository
+func (storage *DatabaseStorage) GetAPIKeyRepository() core.APIKeyRepository {
+
+	if apiKeyStorage == nil {
+		apiKeyStorage = NewSQLAPIKeyRepository(storage)
+	}
+
+	return apiKeyStorage
+}
+
 var postgresDriver = ""
 var user = ""
 var host = ""
@@ -0,0 +1,111 @@
+package pgxsql
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/lisomatrix/channels/channels/core"
+)
+
+// API Key SQL
+var createAPIKeySQL = `INSERT INTO "API_Key"("ID", "AppID", "Name", "KeyHash", "Scopes", "CreatedAt") VALUES ( $1 , $2 , $3 , $4 , $5 , $6 );`
+var getAPIKeyByHashSQL = `SELECT "ID", "AppID", "Name", "KeyHash", "Scopes", "CreatedAt" FROM "API_Key" WHERE "KeyHash" = $1 ;`
+var getAppAPIKeysSQL = `SELECT "ID", "AppID", "Name", "KeyHash", "Scopes", "CreatedAt" FROM "API_Key" WHERE "AppID" = $1 ORDER BY "CreatedAt";`
+var deleteAPIKeySQL = `DELETE FROM "API_Key" WHERE "AppID" = $1 AND "ID" = $2 ;`
+
+// PGXAPIKeyRepository - SQL repository for table API_Key
+type PGXAPIKeyRepository struct {
+	dbHolder *PGXDatabaseStorage
+	ctx      context.Context
+}
+
+// CreateAPIKey - Create a new API_Key row in the database
+func (repo *PGXAPIKeyRepository) CreateAPIKey(key *core.APIKey) error {
+	_, err := repo.dbHolder.db.Exec(repo.ctx, createAPIKeySQL, key.ID, key.AppID, key.Name, key.KeyHash, strings.Join(key.Scopes, ","), key.CreatedAt)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "CreateAPIKey: statement execution failed: %v\n", err)
+		return err
+	}
+
+	return nil
+}
+
+// GetAPIKeyByHash - Get the API key with the given hash
+func (repo *PGXAPIKeyRepository) GetAPIKeyByHash(keyHash string) (*core.APIKey, error) {
+	row := repo.dbHolder.db.QueryRow(repo.ctx, getAPIKeyByHashSQL, keyHash)
+
+	key, err := scanAPIKey(row)
+
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetAPIKeyByHash: query scan failed: %v\n", err)
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// GetAppAPIKeys - Get all API keys of the app
+func (repo *PGXAPIKeyRepository) GetAppAPIKeys(appID string) ([]*core.APIKey, error) {
+	rows, err := repo.dbHolder.db.Query(repo.ctx, getAppAPIKeysSQL, appID)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetAppAPIKeys: query failed: %v\n", err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	keys := make([]*core.APIKey, 0)
+
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "GetAppAPIKeys: row scan failed: %v\n", err)
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// DeleteAPIKey - Delete the app API key row
+func (repo *PGXAPIKeyRepository) DeleteAPIKey(appID string, keyID string) (bool, error) {
+	tag, err := repo.dbHolder.db.Exec(repo.ctx, deleteAPIKeySQL, appID, keyID)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "DeleteAPIKey: statement execution failed: %v\n", err)
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+func scanAPIKey(row pgx.Row) (*core.APIKey, error) {
+	key := &core.APIKey{}
+
+	var scopes string
+
+	if err := row.Scan(&key.ID, &key.AppID, &key.Name, &key.KeyHash, &scopes, &key.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	key.Scopes = strings.Split(scopes, ",")
+
+	return key, nil
+}
+
+// NewPGXAPIKeyRepository - Create a new instance of PGXAPIKeyRepository
+func NewPGXAPIKeyRepository(db *PGXDatabaseStorage) *PGXAPIKeyRepository {
+	return &PGXAPIKeyRepository{dbHolder: db, ctx: context.Background()}
+}
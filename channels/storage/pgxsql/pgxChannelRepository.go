@@ -18,7 +18,15 @@ var deleteChannelSQL = `DELETE FROM "Channel" WHERE "ChannelID" = $1 AND "AppID"
 var deleteAppChannelsSQL = `DELETE FROM "Channel" WHERE "AppID" = $1;`
 var joinChannelSQL = `INSERT INTO public."Channel_Client"("clientID", "channelID") VALUES ($2, (SELECT "ID" FROM "Channel" WHERE "ChannelID" = $1 AND "AppID" = $3 LIMIT 1));`
 var leaveChannelSQL = `DELETE FROM "Channel_Client" WHERE "channelID" = (SELECT "ID" FROM "Channel" WHERE "ChannelID" = $1 AND "AppID" = $3 LIMIT 1) AND "clientID" = $2;`
+var joinChannelIgnoreSQL = `INSERT INTO "Channel_Client"("clientID", "channelID") VALUES ($2, (SELECT "ID" FROM "Channel" WHERE "ChannelID" = $1 AND "AppID" = $3 LIMIT 1)) ON CONFLICT DO NOTHING;`
+var selectChannelClientsPageSQL = `SELECT "ID", "Username", "AppID", "Extra", "Role" FROM "Client" JOIN "Channel_Client" ON "clientID" = "ID" WHERE "channelID" = (SELECT "ID" FROM "Channel" WHERE "ChannelID" = $1 AND "AppID" = $2 LIMIT 1);`
+var selectChannelClientRoleSQL = `SELECT "Role" FROM "Channel_Client" WHERE "channelID" = (SELECT "ID" FROM "Channel" WHERE "ChannelID" = $1 AND "AppID" = $2 LIMIT 1) AND "clientID" = $3;`
+var updateChannelClientRoleSQL = `UPDATE "Channel_Client" SET "Role" = $4 WHERE "channelID" = (SELECT "ID" FROM "Channel" WHERE "ChannelID" = $1 AND "AppID" = $2 LIMIT 1) AND "clientID" = $3;`
 var setCloseStatusSQL = `UPDATE "Channel" SET "IsClosed" = $1 WHERE "ChannelID" = $2 AND "AppID" = $3;`
+var updateChannelSQL = `UPDATE "Channel" SET "Name" = $1, "Extra" = $2, "Persistent" = $3, "Private" = $4, "Presence" = $5, "Push" = $6 WHERE "ChannelID" = $7 AND "AppID" = $8;`
+var selectEventTypeRulesSQL = `SELECT "EventType", "PayloadSchema" FROM "Event_Type_Rule" WHERE "AppID" = $1 AND "ChannelID" = $2 ORDER BY "EventType";`
+var deleteEventTypeRulesSQL = `DELETE FROM "Event_Type_Rule" WHERE "AppID" = $1 AND "ChannelID" = $2;`
+var insertEventTypeRuleSQL = `INSERT INTO "Event_Type_Rule"("AppID", "ChannelID", "EventType", "PayloadSchema") VALUES ($1, $2, $3, $4);`
 var selectClientAllowedChannelsSQL = `SELECT "ChannelID" FROM "Channel" WHERE "ID" IN (SELECT "channelID" FROM "Channel_Client" WHERE "clientID" = $1);`
 var selectClientOpenOrPrivateChannels = `SELECT "ChannelID", "AppID", "Name", "Created_At", "IsClosed", "Extra", "Persistent", "Private", "Presence", "Push" FROM "Channel" WHERE "Private" = $1 AND "ID" IN (SELECT "channelID" FROM "Channel_Client" WHERE "clientID" = $2);`
 var selectOpenOrPrivateAppChannels = `SELECT "ChannelID", "AppID", "Name", "Created_At", "IsClosed", "Extra", "Persistent", "Private", "Presence", "Push" FROM "Channel" WHERE "Private" = $1 AND "AppID" = $2;`
@@ -29,16 +37,29 @@ var selectAllOpenOrPrivateChannels = `SELECT "ChannelID", "AppID", "Name", "Crea
 var selectAllChannels = `SELECT "ChannelID", "AppID", "Name", "Created_At", "IsClosed", "Extra", "Persistent", "Private", "Presence", "Push" FROM "Channel";`
 var selectAllChannelsAmount = `SELECT COUNT("ChannelID") FROM "Channel"`
 var selectAppChannel = `SELECT "ChannelID", "AppID", "Name", "Created_At", "IsClosed", "Extra", "Persistent", "Private", "Presence", "Push" FROM "Channel" WHERE "AppID" = $1 AND "ChannelID" = $2;`
-var addChannelEventSQL = `INSERT INTO "Channel_Event"("SenderID", "EventType", "Payload", "ChannelID", "TimeStamp") VALUES ( $1 , $2 , $3 , (SELECT "ID" FROM "Channel" WHERE "ChannelID" = $4 AND "AppID" = $6 LIMIT 1) , $5 );`
+var addChannelEventSQL = `INSERT INTO "Channel_Event"("SenderID", "EventType", "Payload", "ChannelID", "TimeStamp", "EventID") VALUES ( $1 , $2 , $3 , (SELECT "ID" FROM "Channel" WHERE "ChannelID" = $4 AND "AppID" = $6 LIMIT 1) , $5 , $7 );`
 
-var selectEventsSinceTimeStampSQL = `SELECT "SenderID", "EventType", "Payload", "TimeStamp" FROM "Channel_Event" WHERE "ChannelID" = (SELECT "ID" FROM "Channel" WHERE "ChannelID" = $1 AND "AppID" = $2) AND "TimeStamp" >= $3;`
-var selectEventsBetweenTimeStampsSQL = `SELECT "SenderID", "EventType", "Payload", "TimeStamp" FROM "Channel_Event" WHERE "ChannelID" = (SELECT "ID" FROM "Channel" WHERE "ChannelID" = $1 AND "AppID" = $2) AND "TimeStamp" >= $3 AND "TimeStamp" <= $4;`
+var selectEventsSinceTimeStampSQL = `SELECT "SenderID", "EventType", "Payload", "TimeStamp", "EventID" FROM "Channel_Event" WHERE "ChannelID" = (SELECT "ID" FROM "Channel" WHERE "ChannelID" = $1 AND "AppID" = $2) AND "TimeStamp" >= $3;`
+var selectEventsBetweenTimeStampsSQL = `SELECT "SenderID", "EventType", "Payload", "TimeStamp", "EventID" FROM "Channel_Event" WHERE "ChannelID" = (SELECT "ID" FROM "Channel" WHERE "ChannelID" = $1 AND "AppID" = $2) AND "TimeStamp" >= $3 AND "TimeStamp" <= $4;`
 
 // * The new one is based on primary key since its auto incremented to it's way faster
-var selectLastEventsSQL = `SELECT "SenderID", "EventType", "Payload", "TimeStamp" FROM "Channel_Event" WHERE "ChannelID" = (SELECT "ID" FROM "Channel" WHERE "ChannelID" = $1 AND "AppID" = $2) ORDER BY "ID" DESC LIMIT $3;`
-
-var selectLastEventsSinceTimeStampSQL = `SELECT "SenderID", "EventType", "Payload", "TimeStamp" FROM (SELECT "SenderID", "EventType", "Payload", "TimeStamp" FROM "Channel_Event" WHERE "ChannelID" = (SELECT "ID" FROM "Channel" WHERE "ChannelID" = $1 AND "AppID" = $2) AND "TimeStamp" >= $3) as "t" ORDER BY "TimeStamp" ASC LIMIT $4;`
-var selectLastEventsBeforeTimeStampSQL = `SELECT "SenderID", "EventType", "Payload", "TimeStamp" FROM (SELECT "SenderID", "EventType", "Payload", "TimeStamp" FROM "Channel_Event" WHERE "ChannelID" = (SELECT "ID" FROM "Channel" WHERE "ChannelID" = $1 AND "AppID" = $2) AND "TimeStamp" <= $3) as "t" ORDER BY "TimeStamp" DESC LIMIT $4;`
+var selectLastEventsSQL = `SELECT "SenderID", "EventType", "Payload", "TimeStamp", "EventID" FROM "Channel_Event" WHERE "ChannelID" = (SELECT "ID" FROM "Channel" WHERE "ChannelID" = $1 AND "AppID" = $2) ORDER BY "ID" DESC LIMIT $3;`
+
+var selectLastEventsSinceTimeStampSQL = `SELECT "SenderID", "EventType", "Payload", "TimeStamp", "EventID" FROM (SELECT "SenderID", "EventType", "Payload", "TimeStamp", "EventID" FROM "Channel_Event" WHERE "ChannelID" = (SELECT "ID" FROM "Channel" WHERE "ChannelID" = $1 AND "AppID" = $2) AND "TimeStamp" >= $3) as "t" ORDER BY "TimeStamp" ASC LIMIT $4;`
+var selectLastEventIDSQL = `SELECT GREATEST(COALESCE(MAX("EventID"), 0), COALESCE((SELECT "LastEventID" FROM "Channel" WHERE "ChannelID" = $1 AND "AppID" = $2), 0)) FROM "Channel_Event" WHERE "ChannelID" = (SELECT "ID" FROM "Channel" WHERE "ChannelID" = $1 AND "AppID" = $2);`
+var selectEventsAfterEventIDSQL = `SELECT "SenderID", "EventType", "Payload", "TimeStamp", "EventID" FROM "Channel_Event" WHERE "ChannelID" = (SELECT "ID" FROM "Channel" WHERE "ChannelID" = $1 AND "AppID" = $2) AND "EventID" > $3 ORDER BY "EventID" ASC;`
+var selectLastEventsAfterEventIDSQL = `SELECT "SenderID", "EventType", "Payload", "TimeStamp", "EventID" FROM "Channel_Event" WHERE "ChannelID" = (SELECT "ID" FROM "Channel" WHERE "ChannelID" = $1 AND "AppID" = $2) AND "EventID" > $3 ORDER BY "EventID" ASC LIMIT $4;`
+var selectLastEventsBeforeTimeStampSQL = `SELECT "SenderID", "EventType", "Payload", "TimeStamp", "EventID" FROM (SELECT "SenderID", "EventType", "Payload", "TimeStamp", "EventID" FROM "Channel_Event" WHERE "ChannelID" = (SELECT "ID" FROM "Channel" WHERE "ChannelID" = $1 AND "AppID" = $2) AND "TimeStamp" <= $3) as "t" ORDER BY "TimeStamp" DESC LIMIT $4;`
+var selectEventByIDSQL = `SELECT "SenderID", "EventType", "Payload", "TimeStamp", "EventID" FROM "Channel_Event" WHERE "ChannelID" = (SELECT "ID" FROM "Channel" WHERE "ChannelID" = $1 AND "AppID" = $2) AND "EventID" = $3 LIMIT 1;`
+var updateEventPayloadSQL = `UPDATE "Channel_Event" SET "Payload" = $4 WHERE "ChannelID" = (SELECT "ID" FROM "Channel" WHERE "ChannelID" = $1 AND "AppID" = $2) AND "EventID" = $3;`
+var deleteEventSQL = `DELETE FROM "Channel_Event" WHERE "ChannelID" = (SELECT "ID" FROM "Channel" WHERE "ChannelID" = $1 AND "AppID" = $2) AND "EventID" = $3;`
+var updateLastEventIDSQL = `UPDATE "Channel" SET "LastEventID" = $3 WHERE "ChannelID" = $1 AND "AppID" = $2 AND "LastEventID" < $3;`
+
+// Read marker SQL
+var setReadMarkerSQL = `INSERT INTO "Channel_Read"("ChannelID", "ClientID", "EventID", "TimeStamp") VALUES ((SELECT "ID" FROM "Channel" WHERE "ChannelID" = $1 AND "AppID" = $2 LIMIT 1), $3, $4, $5) ON CONFLICT ("ChannelID", "ClientID") DO UPDATE SET "EventID" = EXCLUDED."EventID", "TimeStamp" = EXCLUDED."TimeStamp";`
+var selectReadMarkerSQL = `SELECT "ClientID", "EventID", "TimeStamp" FROM "Channel_Read" WHERE "ChannelID" = (SELECT "ID" FROM "Channel" WHERE "ChannelID" = $1 AND "AppID" = $2) AND "ClientID" = $3;`
+var selectReadMarkersSQL = `SELECT "ClientID", "EventID", "TimeStamp" FROM "Channel_Read" WHERE "ChannelID" = (SELECT "ID" FROM "Channel" WHERE "ChannelID" = $1 AND "AppID" = $2);`
+var countUnreadEventsSQL = `SELECT COUNT(*) FROM "Channel_Event" WHERE "ChannelID" = (SELECT "ID" FROM "Channel" WHERE "ChannelID" = $1 AND "AppID" = $2) AND "EventID" > $3 AND "SenderID" <> $4;`
 
 // NewSQLChannelRepository - Create a new instance of SQLChannelRepository
 func NewSQLChannelRepository(db *PGXDatabaseStorage) *PGXChannelRepository {
@@ -149,6 +170,129 @@ func (repo *PGXChannelRepository) LeaveClient(appID string, channelID string, cl
 	return nil
 }
 
+// JoinClients - Add clients to channel in a single transaction, clients already in the channel are ignored
+func (repo *PGXChannelRepository) JoinClients(appID string, channelID string, clientIDs []string) error {
+	batch := &pgx.Batch{}
+
+	for _, clientID := range clientIDs {
+		batch.Queue(joinChannelIgnoreSQL, channelID, clientID, appID)
+	}
+
+	return repo.execBatch("JoinClients", batch, len(clientIDs))
+}
+
+// LeaveClients - Remove clients from channel in a single transaction
+func (repo *PGXChannelRepository) LeaveClients(appID string, channelID string, clientIDs []string) error {
+	batch := &pgx.Batch{}
+
+	for _, clientID := range clientIDs {
+		batch.Queue(leaveChannelSQL, channelID, clientID, appID)
+	}
+
+	return repo.execBatch("LeaveClients", batch, len(clientIDs))
+}
+
+// execBatch - Run a batch of statements in a single transaction, method is used in logs
+func (repo *PGXChannelRepository) execBatch(method string, batch *pgx.Batch, size int) error {
+	conn, err := repo.dbHolder.db.Acquire(repo.ctx)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "%s: failed to acquire connection: %v\n", method, err)
+		return err
+	}
+
+	defer conn.Release()
+
+	// The batch runs in a single implicit transaction
+	br := conn.SendBatch(repo.ctx, batch)
+
+	for i := 0; i < size; i++ {
+		if _, err = br.Exec(); err != nil {
+			break
+		}
+	}
+
+	closeErr := br.Close()
+
+	if err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "%s: batch execution failed: %v\n", method, err)
+		return err
+	}
+
+	return nil
+}
+
+// GetChannelClientsPage - Get a page of the channel clients, filtered and sorted by username or ID
+func (repo *PGXChannelRepository) GetChannelClientsPage(appID string, channelID string, options core.ListOptions) ([]*core.ChannelClient, error) {
+	query, args := pageSQL(selectChannelClientsPageSQL, []interface{}{channelID, appID}, `"ID"`, `"Username"`, options)
+
+	rows, err := repo.dbHolder.db.Query(repo.ctx, query, args...)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetChannelClientsPage: query failed: %v\n", err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	clients := make([]*core.ChannelClient, 0)
+
+	for rows.Next() {
+		client := &core.Client{}
+		var role string
+
+		if err := rows.Scan(&client.ID, &client.Username, &client.AppID, &client.Extra, &role); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "GetChannelClientsPage: row scan failed: %v\n", err)
+			return nil, err
+		}
+
+		clients = append(clients, &core.ChannelClient{Client: client, Role: core.ChannelRole(role)})
+	}
+
+	return clients, nil
+}
+
+// GetChannelClientRole - Get the client role in the channel, empty if the client isn't in the channel
+func (repo *PGXChannelRepository) GetChannelClientRole(appID string, channelID string, clientID string) (core.ChannelRole, error) {
+	rows, err := repo.dbHolder.db.Query(repo.ctx, selectChannelClientRoleSQL, channelID, appID, clientID)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetChannelClientRole: query failed: %v\n", err)
+		return "", err
+	}
+
+	defer rows.Close()
+
+	if !rows.Next() {
+		return "", rows.Err()
+	}
+
+	var role string
+
+	if err := rows.Scan(&role); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetChannelClientRole: row scan failed: %v\n", err)
+		return "", err
+	}
+
+	return core.ChannelRole(role), nil
+}
+
+// SetChannelClientRole - Set the client role in the channel
+func (repo *PGXChannelRepository) SetChannelClientRole(appID string, channelID string, clientID string, role core.ChannelRole) error {
+	_, err := repo.dbHolder.db.Exec(repo.ctx, updateChannelClientRoleSQL, channelID, appID, clientID, string(role))
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "SetChannelClientRole: statement execution failed: %v\n", err)
+		return err
+	}
+
+	return nil
+}
+
 // SetChannelCloseStatus - Set channel closed or open
 func (repo *PGXChannelRepository) SetChannelCloseStatus(appID string, channelID string, isClosed bool) error {
 	_, err := repo.dbHolder.db.Exec(repo.ctx, setCloseStatusSQL, isClosed, channelID, appID)
@@ -161,6 +305,57 @@ func (repo *PGXChannelRepository) SetChannelCloseStatus(appID string, channelID
 	return nil
 }
 
+// UpdateChannel - Update channel name, extra, persistent, private, presence and push
+func (repo *PGXChannelRepository) UpdateChannel(appID string, channel *core.Channel) error {
+	_, err := repo.dbHolder.db.Exec(repo.ctx, updateChannelSQL, channel.Name, channel.Extra, channel.Persistent, channel.Private, channel.Presence, channel.Push, channel.ID, appID)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "UpdateChannel: statement execution failed: %v\n", err)
+		return err
+	}
+
+	return nil
+}
+
+// GetEventTypeRules - Get the event type rules of the channel, or of the app if channelID is empty
+func (repo *PGXChannelRepository) GetEventTypeRules(appID string, channelID string) ([]*core.EventTypeRule, error) {
+	rows, err := repo.dbHolder.db.Query(repo.ctx, selectEventTypeRulesSQL, appID, channelID)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetEventTypeRules: query failed: %v\n", err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	rules := make([]*core.EventTypeRule, 0)
+
+	for rows.Next() {
+		rule := &core.EventTypeRule{}
+
+		if err := rows.Scan(&rule.EventType, &rule.Schema); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "GetEventTypeRules: row scan failed: %v\n", err)
+			return nil, err
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, rows.Err()
+}
+
+// SetEventTypeRules - Replace the event type rules of the channel, or of the app if channelID is empty, in a single transaction
+func (repo *PGXChannelRepository) SetEventTypeRules(appID string, channelID string, rules []*core.EventTypeRule) error {
+	batch := &pgx.Batch{}
+	batch.Queue(deleteEventTypeRulesSQL, appID, channelID)
+
+	for _, rule := range rules {
+		batch.Queue(insertEventTypeRuleSQL, appID, channelID, rule.EventType, rule.Schema)
+	}
+
+	return repo.execBatch("SetEventTypeRules", batch, len(rules)+1)
+}
+
 // GetClientAllowedChannels - Get all allowed channels for the given client, including public and private
 func (repo *PGXChannelRepository) GetClientAllowedChannels(clientID string) ([]string, error) {
 	rows, err := repo.dbHolder.db.Query(repo.ctx, selectClientAllowedChannelsSQL, clientID)
@@ -313,6 +508,47 @@ func (repo *PGXChannelRepository) GetAppPrivateChannels(appID string) ([]*core.C
 	return channels, nil
 }
 
+// GetAppChannelsPage - Get a page of the app private or public channels, filtered and sorted by name or ID
+func (repo *PGXChannelRepository) GetAppChannelsPage(appID string, private bool, options core.ListOptions) ([]*core.Channel, error) {
+	query, args := pageSQL(selectOpenOrPrivateAppChannels, []interface{}{private, appID}, `"ChannelID"`, `"Name"`, options)
+
+	return repo.queryChannelsPage("GetAppChannelsPage", query, args)
+}
+
+// GetClientChannelsPage - Get a page of the private or public channels the client joined, filtered and sorted by name or ID
+func (repo *PGXChannelRepository) GetClientChannelsPage(clientID string, private bool, options core.ListOptions) ([]*core.Channel, error) {
+	query, args := pageSQL(selectClientOpenOrPrivateChannels, []interface{}{private, clientID}, `"ChannelID"`, `"Name"`, options)
+
+	return repo.queryChannelsPage("GetClientChannelsPage", query, args)
+}
+
+// queryChannelsPage - Run a channels page query, method is used in logs
+func (repo *PGXChannelRepository) queryChannelsPage(method string, query string, args []interface{}) ([]*core.Channel, error) {
+	rows, err := repo.dbHolder.db.Query(repo.ctx, query, args...)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "%s: query failed: %v\n", method, err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	channels := make([]*core.Channel, 0)
+
+	for rows.Next() {
+		chann, err := repo.rowToChannel(rows)
+
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "%s: row scan failed: %v\n", method, err)
+			return nil, err
+		}
+
+		channels = append(channels, chann)
+	}
+
+	return channels, nil
+}
+
 // GetAppPublicChannels - Get all app public channels without joined users
 func (repo *PGXChannelRepository) GetAppPublicChannels(appID string) ([]*core.Channel, error) {
 	rows, err := repo.dbHolder.db.Query(repo.ctx, selectOpenOrPrivateAppChannels, false, appID)
@@ -459,7 +695,7 @@ func (repo *PGXChannelRepository) GetAllChannelsCount() (uint64, error) {
 // AddChannelEvent - Add event to given channel
 func (repo *PGXChannelRepository) AddChannelEvent(appID string, channelID string, event *core.ChannelEvent) error {
 
-	_, err := repo.dbHolder.db.Exec(repo.ctx, addChannelEventSQL, event.SenderID, event.EventType, event.Payload, channelID, event.Timestamp, appID)
+	_, err := repo.dbHolder.db.Exec(repo.ctx, addChannelEventSQL, event.SenderID, event.EventType, event.Payload, channelID, event.Timestamp, appID, event.ID)
 
 	if err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, "AddChannelEvent: statement execution failed: %v\n", err)
@@ -476,7 +712,7 @@ func (repo *PGXChannelRepository) AddChannelEvents(items []core.InsertItem) erro
 
 	for _, item := range items {
 		event := item.Event
-		batch.Queue(addChannelEventSQL, event.SenderID, event.EventType, event.Payload, event.ChannelID, event.Timestamp, item.AppID)
+		batch.Queue(addChannelEventSQL, event.SenderID, event.EventType, event.Payload, event.ChannelID, event.Timestamp, item.AppID, event.ID)
 	}
 	conn, err := repo.dbHolder.db.Acquire(repo.ctx)
 
@@ -485,12 +721,22 @@ func (repo *PGXChannelRepository) AddChannelEvents(items []core.InsertItem) erro
 		return err
 	}
 
+	defer conn.Release()
+
+	// The batch runs in a single implicit transaction
 	br := conn.SendBatch(repo.ctx, batch)
 
-	// br := repo.dbHolder.db.SendBatch(repo.ctx, batch)
+	for range items {
+		if _, err = br.Exec(); err != nil {
+			break
+		}
+	}
+
+	closeErr := br.Close()
 
-	_, err = br.Exec()
-	conn.Release()
+	if err == nil {
+		err = closeErr
+	}
 
 	if err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, "AddChannelEvents: batch execution failed: %v\n", err)
@@ -625,6 +871,206 @@ func (repo *PGXChannelRepository) GetChannelLastEvents(appID string, channelID s
 	return channelEvents, nil
 }
 
+// GetChannelLastEventID - Get the highest event ID stored or deleted for the channel, 0 if it has none
+func (repo *PGXChannelRepository) GetChannelLastEventID(appID string, channelID string) (uint64, error) {
+	row := repo.dbHolder.db.QueryRow(repo.ctx, selectLastEventIDSQL, channelID, appID)
+
+	var eventID uint64
+
+	err := row.Scan(&eventID)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetChannelLastEventID: row scan failed: %v\n", err)
+		return 0, err
+	}
+
+	return eventID, nil
+}
+
+// GetChannelEventsAfterID - Get all events after given event ID
+func (repo *PGXChannelRepository) GetChannelEventsAfterID(appID string, channelID string, eventID uint64) ([]*core.ChannelEvent, error) {
+	rows, err := repo.dbHolder.db.Query(repo.ctx, selectEventsAfterEventIDSQL, channelID, appID, eventID)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetChannelEventsAfterID: query failed: %v\n", err)
+		return nil, err
+	}
+
+	channelEvents := make([]*core.ChannelEvent, 0)
+
+	for rows.Next() {
+		event, err := repo.rowToChannelEvent(channelID, rows)
+
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "GetChannelEventsAfterID: row scan failed: %v\n", err)
+			return nil, err
+		}
+
+		channelEvents = append(channelEvents, event)
+	}
+
+	return channelEvents, nil
+}
+
+// GetChannelLastEventsAfterID - Get an given amount events after given event ID
+func (repo *PGXChannelRepository) GetChannelLastEventsAfterID(appID string, channelID string, amount int64, eventID uint64) ([]*core.ChannelEvent, error) {
+	rows, err := repo.dbHolder.db.Query(repo.ctx, selectLastEventsAfterEventIDSQL, channelID, appID, eventID, amount)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetChannelLastEventsAfterID: query failed: %v\n", err)
+		return nil, err
+	}
+
+	channelEvents := make([]*core.ChannelEvent, 0)
+
+	for rows.Next() {
+		event, err := repo.rowToChannelEvent(channelID, rows)
+
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "GetChannelLastEventsAfterID: row scan failed: %v\n", err)
+			return nil, err
+		}
+
+		channelEvents = append(channelEvents, event)
+	}
+
+	return channelEvents, nil
+}
+
+// GetChannelEvent - Get the event with the given event ID, nil if it doesn't exist
+func (repo *PGXChannelRepository) GetChannelEvent(appID string, channelID string, eventID uint64) (*core.ChannelEvent, error) {
+	rows, err := repo.dbHolder.db.Query(repo.ctx, selectEventByIDSQL, channelID, appID, eventID)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetChannelEvent: query failed: %v\n", err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+
+	event, err := repo.rowToChannelEvent(channelID, rows)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetChannelEvent: row scan failed: %v\n", err)
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// UpdateChannelEvent - Replace the payload of the event with the given event ID
+func (repo *PGXChannelRepository) UpdateChannelEvent(appID string, channelID string, eventID uint64, payload string) error {
+	_, err := repo.dbHolder.db.Exec(repo.ctx, updateEventPayloadSQL, channelID, appID, eventID, payload)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "UpdateChannelEvent: statement execution failed: %v\n", err)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteChannelEvent - Remove the event with the given event ID, remembering it so the ID isn't given again
+func (repo *PGXChannelRepository) DeleteChannelEvent(appID string, channelID string, eventID uint64) error {
+	_, err := repo.dbHolder.db.Exec(repo.ctx, updateLastEventIDSQL, channelID, appID, eventID)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "DeleteChannelEvent: last event ID update failed: %v\n", err)
+		return err
+	}
+
+	_, err = repo.dbHolder.db.Exec(repo.ctx, deleteEventSQL, channelID, appID, eventID)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "DeleteChannelEvent: statement execution failed: %v\n", err)
+		return err
+	}
+
+	return nil
+}
+
+// SetReadMarker - Store the last event read by the client, replacing the previous one
+func (repo *PGXChannelRepository) SetReadMarker(appID string, channelID string, clientID string, eventID uint64, timestamp int64) error {
+	_, err := repo.dbHolder.db.Exec(repo.ctx, setReadMarkerSQL, channelID, appID, clientID, eventID, timestamp)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "SetReadMarker: statement execution failed: %v\n", err)
+		return err
+	}
+
+	return nil
+}
+
+// GetReadMarker - Get the last event read by the client, nil if there is none
+func (repo *PGXChannelRepository) GetReadMarker(appID string, channelID string, clientID string) (*core.ReadMarker, error) {
+	rows, err := repo.dbHolder.db.Query(repo.ctx, selectReadMarkerSQL, channelID, appID, clientID)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetReadMarker: query failed: %v\n", err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+
+	marker := &core.ReadMarker{}
+
+	if err := rows.Scan(&marker.ClientID, &marker.EventID, &marker.Timestamp); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetReadMarker: row scan failed: %v\n", err)
+		return nil, err
+	}
+
+	return marker, nil
+}
+
+// GetReadMarkers - Get the last event read by each client of the channel
+func (repo *PGXChannelRepository) GetReadMarkers(appID string, channelID string) ([]*core.ReadMarker, error) {
+	rows, err := repo.dbHolder.db.Query(repo.ctx, selectReadMarkersSQL, channelID, appID)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetReadMarkers: query failed: %v\n", err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	markers := make([]*core.ReadMarker, 0)
+
+	for rows.Next() {
+		marker := &core.ReadMarker{}
+
+		if err := rows.Scan(&marker.ClientID, &marker.EventID, &marker.Timestamp); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "GetReadMarkers: row scan failed: %v\n", err)
+			return nil, err
+		}
+
+		markers = append(markers, marker)
+	}
+
+	return markers, nil
+}
+
+// CountUnreadChannelEvents - Count the events after the given event ID not sent by the client
+func (repo *PGXChannelRepository) CountUnreadChannelEvents(appID string, channelID string, clientID string, eventID uint64) (uint64, error) {
+	row := repo.dbHolder.db.QueryRow(repo.ctx, countUnreadEventsSQL, channelID, appID, eventID, clientID)
+
+	var count uint64
+
+	if err := row.Scan(&count); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "CountUnreadChannelEvents: row scan failed: %v\n", err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
 // rowToChannelEvent - Small helper to keep code cleaner
 func (repo *PGXChannelRepository) rowToChannelEvent(channelID string, rows pgx.Rows) (*core.ChannelEvent, error) {
 
@@ -632,8 +1078,9 @@ func (repo *PGXChannelRepository) rowToChannelEvent(channelID string, rows pgx.R
 	var eventType string
 	var payload string
 	var timestamp int64
+	var eventID uint64
 
-	err := rows.Scan(&senderID, &eventType, &payload, &timestamp)
+	err := rows.Scan(&senderID, &eventType, &payload, &timestamp, &eventID)
 
 	channEvent := &core.ChannelEvent{
 		SenderID:  senderID,
@@ -641,6 +1088,7 @@ func (repo *PGXChannelRepository) rowToChannelEvent(channelID string, rows pgx.R
 		Payload:   payload,
 		ChannelID: channelID,
 		Timestamp: timestamp,
+		ID:        eventID,
 	}
 
 	return channEvent, err
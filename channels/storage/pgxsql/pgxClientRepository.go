@@ -19,6 +19,8 @@ var selectAppClientSQL = `SELECT "ID", "Username", "AppID", "Extra" FROM "Client
 var selectAppClientExistsSQL = `SELECT COUNT("ID") FROM "Client" WHERE "AppID" = $1 AND "ID" = $2 LIMIT 1;`
 var selectAppClientsAmountSQL = `SELECT COUNT("ID") FROM "Client" WHERE "AppID" = $1;`
 var selectAllClientsSQL = `SELECT "ID", "Username", "AppID", "Extra" FROM "Client";`
+var selectAppClientsPageSQL = `SELECT "ID", "Username", "AppID", "Extra" FROM "Client" WHERE "AppID" = $1;`
+var selectAllClientsPageSQL = `SELECT "ID", "Username", "AppID", "Extra" FROM "Client" WHERE TRUE;`
 var selectAllClientsAmountSQL = `SELECT COUNT("ID") AS "AMOUNT" FROM "Client";`
 var selectClientExtraSQL = `SELECT "Extra" FROM "Client" WHERE "ID" = $1;`
 
@@ -249,6 +251,39 @@ func (repo *PGXClientRepository) GetAllClients() ([]*core.Client, error) {
 	return clients, nil
 }
 
+// GetClientsPage - Get a page of the app clients, or of all clients if no app is given, filtered and sorted by username or ID
+func (repo *PGXClientRepository) GetClientsPage(appID string, options core.ListOptions) ([]*core.Client, error) {
+	query, args := pageSQL(selectAllClientsPageSQL, nil, `"ID"`, `"Username"`, options)
+
+	if appID != "" {
+		query, args = pageSQL(selectAppClientsPageSQL, []interface{}{appID}, `"ID"`, `"Username"`, options)
+	}
+
+	rows, err := repo.dbHolder.db.Query(repo.ctx, query, args...)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetClientsPage: query failed: %v\n", err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	clients := make([]*core.Client, 0)
+
+	for rows.Next() {
+		client := &core.Client{}
+
+		if err := rows.Scan(&client.ID, &client.Username, &client.AppID, &client.Extra); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "GetClientsPage: row scan failed: %v\n", err)
+			return nil, err
+		}
+
+		clients = append(clients, client)
+	}
+
+	return clients, nil
+}
+
 // GetAllClientsCount - Get how much clients an App has
 func (repo *PGXClientRepository) GetAllClientsCount() (uint64, error) {
 	row, err := repo.dbHolder.db.Query(repo.ctx, selectAllClientsAmountSQL)
@@ -28,6 +28,7 @@ var appStorage *PGXAppRepository = nil
 var clientStorage *PGXClientRepository = nil
 var channelStorage *PGXChannelRepository = nil
 var deviceStorage *PGXDeviceRepository = nil
+var apiKeyStorage *PGXAPIKeyRepository = nil
 
 func PGXSetConnectionParams(dbUser string, dbPassword string, dbHost string, dbPort string, db string) {
 	user = dbUser
@@ -84,6 +85,16 @@ func (storage *PGXDatabaseStorage) GetChannelRepository() core.ChannelRepository
 	return channelStorage
 }
 
+// GetAPIKeyRepository - Get SQL implementation of APIKeyRepository
+func (storage *PGXDatabaseStorage) GetAPIKeyRepository() core.APIKeyRepository {
+
+	if apiKeyStorage == nil {
+		apiKeyStorage = NewPGXAPIKeyRepository(storage)
+	}
+
+	return apiKeyStorage
+}
+
 // NewSQLStorageDatabase - Create new SQLStorageDatabase implementation with postgre specific driver, it also works with YugaByteDB tested it
 func NewSQLStorageDatabase() *PGXDatabaseStorage {
 
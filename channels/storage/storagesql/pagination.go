@@ -0,0 +1,59 @@
+package storagesql
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/lisomatrix/channels/channels/core"
+)
+
+// likeEscaper - Escape LIKE wildcards so name filters match them literally, with ! as escape character
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// pageSQL - Append the name filter, cursor, order and limit of the listing options to a query ending in a WHERE clause
+// The query args come first, the ones added here continue their numbering
+func pageSQL(query string, args []interface{}, idColumn string, nameColumn string, options core.ListOptions) (string, []interface{}) {
+	var builder strings.Builder
+
+	builder.WriteString(strings.TrimSuffix(query, ";"))
+
+	param := func(value interface{}) string {
+		args = append(args, value)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	// Rows without name are sorted as an empty one
+	nameSQL := `COALESCE(` + nameColumn + `, '')`
+
+	if options.Name != "" {
+		builder.WriteString(" AND " + nameSQL + " ILIKE " + param("%"+likeEscaper.Replace(options.Name)+"%") + " ESCAPE '!'")
+	}
+
+	comparison, direction := ">", "ASC"
+
+	if options.Descending {
+		comparison, direction = "<", "DESC"
+	}
+
+	if options.After != nil {
+		if options.SortByName() {
+			builder.WriteString(" AND (" + nameSQL + ", " + idColumn + ") " + comparison + " (" + param(options.After.Name) + ", " + param(options.After.ID) + ")")
+		} else {
+			builder.WriteString(" AND " + idColumn + " " + comparison + " " + param(options.After.ID))
+		}
+	}
+
+	if options.SortByName() {
+		builder.WriteString(" ORDER BY " + nameSQL + " " + direction + ", " + idColumn + " " + direction)
+	} else {
+		builder.WriteString(" ORDER BY " + idColumn + " " + direction)
+	}
+
+	if options.Limit > 0 {
+		builder.WriteString(" LIMIT " + param(options.Limit))
+	}
+
+	builder.WriteString(";")
+
+	return builder.String(), args
+}
@@ -0,0 +1,155 @@
+package storagesql
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/lisomatrix/channels/channels/core"
+)
+
+// API Key SQL
+var createAPIKeySQL = `INSERT INTO "API_Key"("ID", "AppID", "Name", "KeyHash", "Scopes", "CreatedAt") VALUES ( $1 , $2 , $3 , $4 , $5 , $6 );`
+var getAPIKeyByHashSQL = `SELECT "ID", "AppID", "Name", "KeyHash", "Scopes", "CreatedAt" FROM "API_Key" WHERE "KeyHash" = $1 ;`
+var getAppAPIKeysSQL = `SELECT "ID", "AppID", "Name", "KeyHash", "Scopes", "CreatedAt" FROM "API_Key" WHERE "AppID" = $1 ORDER BY "CreatedAt";`
+var deleteAPIKeySQL = `DELETE FROM "API_Key" WHERE "AppID" = $1 AND "ID" = $2 ;`
+
+// APIKeyRepository - SQL repository for table API_Key
+type APIKeyRepository struct {
+	dbHolder *DatabaseStorage
+}
+
+// CreateAPIKey - Create a new API_Key row in the database
+func (repo *APIKeyRepository) CreateAPIKey(key *core.APIKey) error {
+	stmt, err := repo.dbHolder.db.Prepare(createAPIKeySQL)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "CreateAPIKey: preparing statement failed: %v\n", err)
+		return err
+	}
+
+	defer stmt.Close()
+
+	_, err = stmt.Exec(key.ID, key.AppID, key.Name, key.KeyHash, strings.Join(key.Scopes, ","), key.CreatedAt)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "CreateAPIKey: statement execution failed: %v\n", err)
+		return err
+	}
+
+	return nil
+}
+
+// GetAPIKeyByHash - Get the API key with the given hash
+func (repo *APIKeyRepository) GetAPIKeyByHash(keyHash string) (*core.APIKey, error) {
+	stmt, err := repo.dbHolder.db.Prepare(getAPIKeyByHashSQL)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetAPIKeyByHash: preparing statement failed: %v\n", err)
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	key, err := scanAPIKey(stmt.QueryRow(keyHash))
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetAPIKeyByHash: query scan failed: %v\n", err)
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// GetAppAPIKeys - Get all API keys of the app
+func (repo *APIKeyRepository) GetAppAPIKeys(appID string) ([]*core.APIKey, error) {
+	stmt, err := repo.dbHolder.db.Prepare(getAppAPIKeysSQL)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetAppAPIKeys: preparing statement failed: %v\n", err)
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	rows, err := stmt.Query(appID)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "GetAppAPIKeys: query failed: %v\n", err)
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	keys := make([]*core.APIKey, 0)
+
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "GetAppAPIKeys: row scan failed: %v\n", err)
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// DeleteAPIKey - Delete the app API key row
+func (repo *APIKeyRepository) DeleteAPIKey(appID string, keyID string) (bool, error) {
+	stmt, err := repo.dbHolder.db.Prepare(deleteAPIKeySQL)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "DeleteAPIKey: preparing statement failed: %v\n", err)
+		return false, err
+	}
+
+	defer stmt.Close()
+
+	result, err := stmt.Exec(appID, keyID)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "DeleteAPIKey: statement execution failed: %v\n", err)
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "DeleteAPIKey: getting affected rows failed: %v\n", err)
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// rowScanner - Either a sql.Row or sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAPIKey(row rowScanner) (*core.APIKey, error) {
+	key := &core.APIKey{}
+
+	var scopes string
+
+	if err := row.Scan(&key.ID, &key.AppID, &key.Name, &key.KeyHash, &scopes, &key.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	key.Scopes = strings.Split(scopes, ",")
+
+	return key, nil
+}
+
+// NewSQLAPIKeyRepository - Create a new instance of APIKeyRepository
+func NewSQLAPIKeyRepository(db *DatabaseStorage) *APIKeyRepository {
+	return &APIKeyRepository{dbHolder: db}
+}
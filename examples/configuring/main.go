@@ -22,6 +22,12 @@ func main() {
 	}
 
 	auth.SetSecret(config.JWTSecret)
+
+	// Accepted algorithms, public keys and claims to check
+	if err := auth.Configure(config.Auth); err != nil {
+		log.Fatal(err)
+	}
+
 	pgxsql.PGXSetConnectionParams(config.Database.User, config.Database.Password, config.Database.Host, config.Database.Port, config.Database.DB)
 
 
@@ -16,12 +16,14 @@ CREATE TABLE public."Channel" (
     "Persistent" boolean,
     "Private" boolean,
     "Presence" boolean,
-    "Push" boolean
+    "Push" boolean,
+    "LastEventID" bigint DEFAULT 0 NOT NULL
 );
 
 CREATE TABLE public."Channel_Client" (
     "clientID" character varying(100) NOT NULL,
-    "channelID" bigint NOT NULL
+    "channelID" bigint NOT NULL,
+    "Role" character varying(20) DEFAULT 'member' NOT NULL
 );
 
 
@@ -31,7 +33,31 @@ CREATE TABLE public."Channel_Event" (
     "EventType" character varying(50) NOT NULL,
     "TimeStamp" bigint NOT NULL,
     "Payload" text NOT NULL,
-    "ChannelID" bigint NOT NULL
+    "ChannelID" bigint NOT NULL,
+    "EventID" bigint DEFAULT 0 NOT NULL
+);
+
+CREATE TABLE public."Channel_Read" (
+    "ChannelID" bigint NOT NULL,
+    "ClientID" character varying(100) NOT NULL,
+    "EventID" bigint DEFAULT 0 NOT NULL,
+    "TimeStamp" bigint NOT NULL
+);
+
+CREATE TABLE public."Event_Type_Rule" (
+    "AppID" character varying(150) NOT NULL,
+    "ChannelID" character varying(100) DEFAULT '' NOT NULL,
+    "EventType" character varying(50) NOT NULL,
+    "PayloadSchema" text DEFAULT '' NOT NULL
+);
+
+CREATE TABLE public."API_Key" (
+    "ID" character varying(50) NOT NULL,
+    "AppID" character varying(150) NOT NULL,
+    "Name" character varying(100) DEFAULT '' NOT NULL,
+    "KeyHash" character(64) NOT NULL,
+    "Scopes" character varying(255) NOT NULL,
+    "CreatedAt" bigint NOT NULL
 );
 
 CREATE SEQUENCE public."Channel_Event_ID_seq"
@@ -120,15 +146,29 @@ ALTER TABLE ONLY public."NewChannel"
 ALTER TABLE ONLY public."Channel_Client"
     ADD CONSTRAINT "client_channelID_unique" UNIQUE ("clientID", "channelID");
 
+ALTER TABLE ONLY public."Channel_Read"
+    ADD CONSTRAINT "channel_read_unique" UNIQUE ("ChannelID", "ClientID");
+
 ALTER TABLE ONLY public."Channel"
     ADD CONSTRAINT unique_app_channel UNIQUE ("AppID", "ChannelID");
 
+ALTER TABLE ONLY public."Event_Type_Rule"
+    ADD CONSTRAINT event_type_rule_unique UNIQUE ("AppID", "ChannelID", "EventType");
+
+ALTER TABLE ONLY public."API_Key"
+    ADD CONSTRAINT "API_Key_pkey" PRIMARY KEY ("ID");
+
+ALTER TABLE ONLY public."API_Key"
+    ADD CONSTRAINT api_key_hash_unique UNIQUE ("KeyHash");
+
 
 CREATE INDEX "appID_channelID_indexx" ON public."Channel" USING btree ("ChannelID", "AppID");
 
 
 CREATE INDEX "channelID_TimeStamp_Indexx" ON public."Channel_Event" USING btree ("ChannelID", "TimeStamp");
 
+CREATE UNIQUE INDEX "channelID_EventID_Indexx" ON public."Channel_Event" USING btree ("ChannelID", "EventID");
+
 
 ALTER TABLE ONLY public."NewChannel"
     ADD CONSTRAINT "ch_appID_fk" FOREIGN KEY ("AppID") REFERENCES public."App"("AppID") ON UPDATE CASCADE ON DELETE CASCADE NOT VALID;
@@ -143,6 +183,9 @@ ALTER TABLE ONLY public."Channel_Client"
 ALTER TABLE ONLY public."Channel_Client"
     ADD CONSTRAINT client_channel_fk FOREIGN KEY ("clientID") REFERENCES public."Client"("ID");
 
+ALTER TABLE ONLY public."Channel_Read"
+    ADD CONSTRAINT channel_read_channel_fk FOREIGN KEY ("ChannelID") REFERENCES public."Channel"("ID");
+
 ALTER TABLE ONLY public."Channel"
     ADD CONSTRAINT fk_channel_app FOREIGN KEY ("AppID") REFERENCES public."App"("AppID");
 
@@ -151,3 +194,9 @@ ALTER TABLE ONLY public."Client"
 
 ALTER TABLE ONLY public."Device"
     ADD CONSTRAINT fk_device_client FOREIGN KEY ("ClientID") REFERENCES public."Client"("ID") NOT VALID;
+
+ALTER TABLE ONLY public."Event_Type_Rule"
+    ADD CONSTRAINT fk_event_type_rule_app FOREIGN KEY ("AppID") REFERENCES public."App"("AppID") ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE ONLY public."API_Key"
+    ADD CONSTRAINT fk_api_key_app FOREIGN KEY ("AppID") REFERENCES public."App"("AppID") ON UPDATE CASCADE ON DELETE CASCADE;
@@ -15,12 +15,14 @@ CREATE TABLE Channel (
     Private boolean,
     Presence boolean,
     Push boolean,
+    LastEventID bigint NOT NULL DEFAULT 0,
     primary key (ID)
 );
 
 CREATE TABLE Channel_Client (
     clientID character varying(100) NOT NULL,
-    channelID bigint NOT NULL
+    channelID bigint NOT NULL,
+    Role character varying(20) DEFAULT 'member' NOT NULL
 );
 
 CREATE TABLE Channel_Event (
@@ -30,9 +32,33 @@ CREATE TABLE Channel_Event (
     TimeStamp bigint NOT NULL,
     Payload text NOT NULL,
     ChannelID bigint NOT NULL,
+    EventID bigint NOT NULL DEFAULT 0,
     primary key (ID)
 );
 
+CREATE TABLE Channel_Read (
+    ChannelID bigint NOT NULL,
+    ClientID character varying(100) NOT NULL,
+    EventID bigint NOT NULL DEFAULT 0,
+    TimeStamp bigint NOT NULL
+);
+
+CREATE TABLE Event_Type_Rule (
+    AppID character varying(150) NOT NULL,
+    ChannelID character varying(100) NOT NULL DEFAULT '',
+    EventType character varying(50) NOT NULL,
+    PayloadSchema text NOT NULL
+);
+
+CREATE TABLE API_Key (
+    ID character varying(50) NOT NULL,
+    AppID character varying(150) NOT NULL,
+    Name character varying(100) NOT NULL DEFAULT '',
+    KeyHash character(64) NOT NULL,
+    Scopes character varying(255) NOT NULL,
+    CreatedAt bigint NOT NULL
+);
+
 CREATE TABLE Client (
     ID character varying(100) NOT NULL,
     Username character varying(100),
@@ -54,20 +80,36 @@ ALTER TABLE Device ADD CONSTRAINT Device_pkey PRIMARY KEY (ID);
 
 ALTER TABLE Channel_Client ADD CONSTRAINT client_channelID_unique UNIQUE (clientID, channelID);
 
+ALTER TABLE Channel_Read ADD CONSTRAINT channel_read_unique UNIQUE (ChannelID, ClientID);
+
 ALTER TABLE Channel ADD CONSTRAINT unique_app_channel UNIQUE (AppID, ChannelID);
 
+ALTER TABLE Event_Type_Rule ADD CONSTRAINT event_type_rule_unique UNIQUE (AppID, ChannelID, EventType);
+
+ALTER TABLE API_Key ADD CONSTRAINT API_Key_pkey PRIMARY KEY (ID);
+
+ALTER TABLE API_Key ADD CONSTRAINT api_key_hash_unique UNIQUE (KeyHash);
+
 CREATE INDEX appID_channelID_indexx ON Channel (ChannelID, AppID);
 
 CREATE INDEX channelID_TimeStamp_Indexx ON Channel_Event (ChannelID, TimeStamp);
 
+CREATE UNIQUE INDEX channelID_EventID_Indexx ON Channel_Event (ChannelID, EventID);
+
 ALTER TABLE Channel_Event ADD CONSTRAINT channelID_fk FOREIGN KEY (ChannelID) REFERENCES Channel(ID);
 
 ALTER TABLE Channel_Client ADD CONSTRAINT channel_client_channel_fk FOREIGN KEY (channelID) REFERENCES Channel(ID);
 
 ALTER TABLE Channel_Client ADD CONSTRAINT client_channel_fk FOREIGN KEY (clientID) REFERENCES Client(ID);
 
+ALTER TABLE Channel_Read ADD CONSTRAINT channel_read_channel_fk FOREIGN KEY (ChannelID) REFERENCES Channel(ID);
+
 ALTER TABLE Channel ADD CONSTRAINT fk_channel_app FOREIGN KEY (AppID) REFERENCES App(AppID);
 
 ALTER TABLE Client ADD CONSTRAINT fk_client_app FOREIGN KEY (AppID) REFERENCES App(AppID);
 
-ALTER TABLE Device ADD CONSTRAINT fk_device_client FOREIGN KEY (ClientID) REFERENCES Client(ID);
\ No newline at end of file
+ALTER TABLE Device ADD CONSTRAINT fk_device_client FOREIGN KEY (ClientID) REFERENCES Client(ID);
+
+ALTER TABLE Event_Type_Rule ADD CONSTRAINT fk_event_type_rule_app FOREIGN KEY (AppID) REFERENCES App(AppID) ON DELETE CASCADE;
+
+ALTER TABLE API_Key ADD CONSTRAINT fk_api_key_app FOREIGN KEY (AppID) REFERENCES App(AppID) ON DELETE CASCADE;
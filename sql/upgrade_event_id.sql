@@ -0,0 +1,34 @@
+-- POSTGRESQL
+
+-- Upgrade databases created before events had IDs, or before event IDs were unique
+-- Old events come first in each channel, so the events with ID are moved after them
+-- Flush the event ID counters of the cache (keys app:<AppID>:channel:<ChannelID>:eventID) after running it
+
+BEGIN;
+
+ALTER TABLE public."Channel" ADD COLUMN IF NOT EXISTS "LastEventID" bigint DEFAULT 0 NOT NULL;
+
+ALTER TABLE public."Channel_Event" ADD COLUMN IF NOT EXISTS "EventID" bigint DEFAULT 0 NOT NULL;
+
+
+CREATE TEMPORARY TABLE "Legacy_Event_Count" ON COMMIT DROP AS
+    SELECT "ChannelID", COUNT(*) AS "EventCount" FROM public."Channel_Event" WHERE "EventID" = 0 GROUP BY "ChannelID";
+
+UPDATE public."Channel_Event" AS e SET "EventID" = n."EventID"
+    FROM (SELECT "ID", ROW_NUMBER() OVER (PARTITION BY "ChannelID" ORDER BY ("EventID" <> 0), "EventID", "TimeStamp", "ID") AS "EventID"
+        FROM public."Channel_Event" WHERE "ChannelID" IN (SELECT "ChannelID" FROM "Legacy_Event_Count")) AS n
+    WHERE e."ID" = n."ID";
+
+-- Keep reads and deleted IDs pointing after the same events
+UPDATE public."Channel_Read" AS r SET "EventID" = r."EventID" + l."EventCount"
+    FROM "Legacy_Event_Count" AS l WHERE r."ChannelID" = l."ChannelID" AND r."EventID" > 0;
+
+UPDATE public."Channel" AS c SET "LastEventID" = c."LastEventID" + l."EventCount"
+    FROM "Legacy_Event_Count" AS l WHERE c."ID" = l."ChannelID" AND c."LastEventID" > 0;
+
+
+DROP INDEX IF EXISTS public."channelID_EventID_Indexx";
+
+CREATE UNIQUE INDEX "channelID_EventID_Indexx" ON public."Channel_Event" USING btree ("ChannelID", "EventID");
+
+COMMIT;
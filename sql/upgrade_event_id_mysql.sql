@@ -0,0 +1,36 @@
+-- MYSQL 8
+
+-- Upgrade databases created before events had IDs, or before event IDs were unique
+-- Old events come first in each channel, so the events with ID are moved after them
+-- Flush the event ID counters of the cache (keys app:<AppID>:channel:<ChannelID>:eventID) after running it
+-- Skip the statements adding columns or dropping the index that the database doesn't need
+
+ALTER TABLE Channel ADD COLUMN LastEventID bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE Channel_Event ADD COLUMN EventID bigint NOT NULL DEFAULT 0;
+
+
+CREATE TEMPORARY TABLE Legacy_Event_Count AS
+    SELECT ChannelID, COUNT(*) AS EventCount FROM Channel_Event WHERE EventID = 0 GROUP BY ChannelID;
+
+CREATE TEMPORARY TABLE New_Event_ID AS
+    SELECT ID, ROW_NUMBER() OVER (PARTITION BY ChannelID ORDER BY (EventID <> 0), EventID, TimeStamp, ID) AS EventID
+    FROM Channel_Event WHERE ChannelID IN (SELECT ChannelID FROM Legacy_Event_Count);
+
+UPDATE Channel_Event e JOIN New_Event_ID n ON e.ID = n.ID SET e.EventID = n.EventID;
+
+-- Keep reads and deleted IDs pointing after the same events
+UPDATE Channel_Read r JOIN Legacy_Event_Count l ON r.ChannelID = l.ChannelID
+    SET r.EventID = r.EventID + l.EventCount WHERE r.EventID > 0;
+
+UPDATE Channel c JOIN Legacy_Event_Count l ON c.ID = l.ChannelID
+    SET c.LastEventID = c.LastEventID + l.EventCount WHERE c.LastEventID > 0;
+
+DROP TEMPORARY TABLE New_Event_ID;
+
+DROP TEMPORARY TABLE Legacy_Event_Count;
+
+
+DROP INDEX channelID_EventID_Indexx ON Channel_Event;
+
+CREATE UNIQUE INDEX channelID_EventID_Indexx ON Channel_Event (ChannelID, EventID);